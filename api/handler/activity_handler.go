@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"github.com/sing3demons/go-backend-clean-architecture/bootstrap"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+)
+
+type ActivityHandler struct {
+	ActivityService domain.TaskActivityUsecase
+}
+
+func NewActivityHandler(activityService domain.TaskActivityUsecase) *ActivityHandler {
+	return &ActivityHandler{
+		ActivityService: activityService,
+	}
+}
+
+// GetTaskHistory lists the changes made to a task newest first, paginated by the page and size query
+// parameters.
+func (h *ActivityHandler) GetTaskHistory(ctx bootstrap.IContext) error {
+	page, err := pageRequest(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	history, err := h.ActivityService.FetchByTaskID(requestContext(ctx), ctx.Param("id"), page)
+	if err != nil {
+		return ctx.Response(errorStatus(err), err.Error())
+	}
+
+	return ctx.Response(200, history)
+}
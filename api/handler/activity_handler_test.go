@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"testing"
+
+	bootstrap "github.com/sing3demons/go-backend-clean-architecture/bootstrap/mocks"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestActivityHandler(t *testing.T) {
+	t.Run("Get Task History", func(t *testing.T) {
+		service := new(usecase.MockActivityUsecase)
+		service.On("FetchByTaskID", mock.Anything, "1", domain.PageRequest{Page: 2}).Return(domain.Page[domain.TaskActivity]{
+			Items: []domain.TaskActivity{{
+				Action:  domain.ActivityUpdated,
+				Actor:   "user-1",
+				Changes: []domain.FieldChange{{Field: "title", From: "Draft", To: "Final"}},
+			}},
+			Page:  2,
+			Size:  20,
+			Total: 21,
+		}, nil).Once()
+
+		handler := NewActivityHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Params: map[string]string{"id": "1"},
+			Query:  map[string]string{"page": "2"},
+		})
+
+		assert.NoError(t, handler.GetTaskHistory(c))
+
+		actual := domain.Page[domain.TaskActivity]{}
+		assert.NoError(t, c.Body(&actual))
+		assert.Equal(t, 200, c.Res.Code)
+		assert.Equal(t, int64(21), actual.Total)
+		assert.Equal(t, "Final", actual.Items[0].Changes[0].To)
+	})
+
+	t.Run("Get Task History Not Found", func(t *testing.T) {
+		service := new(usecase.MockActivityUsecase)
+		service.On("FetchByTaskID", mock.Anything, "1", mock.Anything).Return(domain.Page[domain.TaskActivity]{}, domain.ErrNotFound).Once()
+
+		handler := NewActivityHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Params: map[string]string{"id": "1"},
+		})
+
+		assert.NoError(t, handler.GetTaskHistory(c))
+		assert.Equal(t, 404, c.Res.Code)
+	})
+
+	t.Run("Get Task History Invalid Page", func(t *testing.T) {
+		service := new(usecase.MockActivityUsecase)
+
+		handler := NewActivityHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Params: map[string]string{"id": "1"},
+			Query:  map[string]string{"size": "0"},
+		})
+
+		assert.NoError(t, handler.GetTaskHistory(c))
+		assert.Equal(t, 422, c.Res.Code)
+		service.AssertNotCalled(t, "FetchByTaskID", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
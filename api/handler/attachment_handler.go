@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/sing3demons/go-backend-clean-architecture/bootstrap"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+)
+
+// FormFieldAttachment is the multipart field of an uploaded attachment.
+const FormFieldAttachment = "file"
+
+type AttachmentHandler struct {
+	AttachmentService domain.AttachmentUsecase
+	Policy            domain.AttachmentPolicy
+}
+
+func NewAttachmentHandler(attachmentService domain.AttachmentUsecase, policy domain.AttachmentPolicy) *AttachmentHandler {
+	return &AttachmentHandler{
+		AttachmentService: attachmentService,
+		Policy:            policy,
+	}
+}
+
+// UploadAttachment reads the file of a multipart/form-data body, larger bodies than the policy allows are
+// rejected while they are read.
+func (h *AttachmentHandler) UploadAttachment(ctx bootstrap.IContext) error {
+	header, err := ctx.FormFile(FormFieldAttachment, h.Policy.MaxSize)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return ctx.Response(413, domain.ErrTooLarge.Error())
+	} else if err != nil {
+		return ctx.Response(400, err.Error())
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return ctx.Response(500, err.Error())
+	}
+	defer file.Close()
+
+	attachment := domain.Attachment{
+		Filename: header.Filename,
+		Size:     header.Size,
+	}
+	attachment.ContentType = header.Header.Get("Content-Type")
+	if err := h.AttachmentService.Upload(requestContext(ctx), ctx.Param("id"), &attachment, file); err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return ctx.Response(201, attachment)
+}
+
+func (h *AttachmentHandler) GetAttachments(ctx bootstrap.IContext) error {
+	attachments, err := h.AttachmentService.FetchByTaskID(requestContext(ctx), ctx.Param("id"))
+	if err != nil {
+		return ctx.Response(errorStatus(err), err.Error())
+	}
+
+	return ctx.Response(200, attachments)
+}
+
+// DownloadAttachment streams the content of an attachment. A single Range is served with 206,
+// attachments never change so the id is their entity tag for If-Range and If-None-Match.
+func (h *AttachmentHandler) DownloadAttachment(ctx bootstrap.IContext) error {
+	taskID, attachmentID := ctx.Param("id"), ctx.Param("attachmentId")
+
+	attachment, content, err := h.AttachmentService.Open(requestContext(ctx), taskID, attachmentID)
+	if err != nil {
+		return ctx.Response(errorStatus(err), err.Error())
+	}
+	defer content.Close()
+
+	etag := strconv.Quote(attachment.ID.Hex())
+	bootstrap.SetETag(ctx, etag)
+	ctx.SetHeader(bootstrap.HeaderAcceptRanges, "bytes")
+	if status := bootstrap.CheckPreconditions(ctx, etag, true); status != 0 {
+		return ctx.Response(status, nil)
+	}
+
+	ctx.SetHeader("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+	ctx.SetHeader("X-Content-Type-Options", "nosniff")
+
+	byteRange, partial, err := bootstrap.RequestRange(ctx, etag, attachment.Size)
+	if err != nil {
+		ctx.SetHeader(bootstrap.HeaderContentRange, "bytes */"+strconv.FormatInt(attachment.Size, 10))
+		return ctx.Response(416, err.Error())
+	}
+	if !partial {
+		ctx.SetHeader(bootstrap.HeaderContentLength, strconv.FormatInt(attachment.Size, 10))
+		return ctx.Stream(200, attachment.ContentType, content)
+	}
+
+	if _, err := content.Skip(byteRange.Start); err != nil {
+		return ctx.Response(500, err.Error())
+	}
+	ctx.SetHeader(bootstrap.HeaderContentRange, byteRange.ContentRange(attachment.Size))
+	ctx.SetHeader(bootstrap.HeaderContentLength, strconv.FormatInt(byteRange.Length(), 10))
+	return ctx.Stream(206, attachment.ContentType, io.LimitReader(content, byteRange.Length()))
+}
+
+func (h *AttachmentHandler) DeleteAttachment(ctx bootstrap.IContext) error {
+	taskID, attachmentID := ctx.Param("id"), ctx.Param("attachmentId")
+
+	if err := h.AttachmentService.Delete(requestContext(ctx), taskID, attachmentID); err != nil {
+		return ctx.Response(errorStatus(err), err.Error())
+	}
+
+	return ctx.Response(204, nil)
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	bootstrap "github.com/sing3demons/go-backend-clean-architecture/bootstrap/mocks"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeContent serves attachment content from memory.
+type fakeContent struct {
+	*strings.Reader
+}
+
+func (c fakeContent) Skip(n int64) (int64, error) {
+	return c.Seek(n, io.SeekCurrent)
+}
+
+func (c fakeContent) Close() error {
+	return nil
+}
+
+func TestAttachmentHandler(t *testing.T) {
+	policy := domain.AttachmentPolicy{MaxSize: 16, ContentTypes: []string{"text/plain"}}
+	attachment := domain.Attachment{ID: primitive.NewObjectID(), Filename: "notes.txt", Size: 11}
+	attachment.ContentType = "text/plain"
+	etag := strconv.Quote(attachment.ID.Hex())
+
+	t.Run("Upload Attachment", func(t *testing.T) {
+		service := new(usecase.MockAttachmentUsecase)
+		service.On("Upload", mock.Anything, "1", mock.MatchedBy(func(attachment *domain.Attachment) bool {
+			return attachment.Filename == "notes.txt" && attachment.Size == 5 && attachment.ContentType == "text/plain"
+		}), mock.Anything).Return(nil).Once()
+
+		handler := NewAttachmentHandler(service, policy)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Params: map[string]string{"id": "1"},
+			Files:  []bootstrap.File{{Field: FormFieldAttachment, Filename: "notes.txt", ContentType: "text/plain", Content: []byte("hello")}},
+		})
+
+		if err := handler.UploadAttachment(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 201, c.Res.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Upload Attachment Too Large", func(t *testing.T) {
+		service := new(usecase.MockAttachmentUsecase)
+
+		handler := NewAttachmentHandler(service, policy)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Params: map[string]string{"id": "1"},
+			Files:  []bootstrap.File{{Field: FormFieldAttachment, Filename: "notes.txt", Content: []byte(strings.Repeat("a", 17))}},
+		})
+
+		if err := handler.UploadAttachment(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 413, c.Res.Code)
+		service.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Upload Attachment Unsupported Media Type", func(t *testing.T) {
+		service := new(usecase.MockAttachmentUsecase)
+		service.On("Upload", mock.Anything, "1", mock.Anything, mock.Anything).Return(domain.ErrUnsupportedMediaType).Once()
+
+		handler := NewAttachmentHandler(service, policy)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Params: map[string]string{"id": "1"},
+			Files:  []bootstrap.File{{Field: FormFieldAttachment, Filename: "page.html", ContentType: "text/html", Content: []byte("<html>")}},
+		})
+
+		if err := handler.UploadAttachment(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 415, c.Res.Code)
+	})
+
+	t.Run("Upload Attachment Missing File", func(t *testing.T) {
+		service := new(usecase.MockAttachmentUsecase)
+
+		handler := NewAttachmentHandler(service, policy)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{Body: map[string]string{}})
+
+		if err := handler.UploadAttachment(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 400, c.Res.Code)
+	})
+
+	t.Run("Download Attachment", func(t *testing.T) {
+		service := new(usecase.MockAttachmentUsecase)
+		service.On("Open", mock.Anything, "1", "2").Return(attachment, fakeContent{strings.NewReader("hello world")}, nil).Once()
+
+		handler := NewAttachmentHandler(service, policy)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{Params: map[string]string{"id": "1", "attachmentId": "2"}})
+
+		if err := handler.DownloadAttachment(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 200, c.Res.Code)
+		assert.Equal(t, "hello world", c.Res.Body.String())
+		assert.Equal(t, "text/plain", c.Res.Header().Get("Content-Type"))
+		assert.Equal(t, "11", c.Res.Header().Get("Content-Length"))
+		assert.Equal(t, "bytes", c.Res.Header().Get("Accept-Ranges"))
+		assert.Equal(t, `attachment; filename=notes.txt`, c.Res.Header().Get("Content-Disposition"))
+	})
+
+	t.Run("Download Attachment Range", func(t *testing.T) {
+		service := new(usecase.MockAttachmentUsecase)
+		service.On("Open", mock.Anything, "1", "2").Return(attachment, fakeContent{strings.NewReader("hello world")}, nil).Once()
+
+		handler := NewAttachmentHandler(service, policy)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Params: map[string]string{"id": "1", "attachmentId": "2"},
+			Header: map[string]string{"Range": "bytes=6-", "If-Range": etag},
+		})
+
+		if err := handler.DownloadAttachment(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 206, c.Res.Code)
+		assert.Equal(t, "world", c.Res.Body.String())
+		assert.Equal(t, "bytes 6-10/11", c.Res.Header().Get("Content-Range"))
+		assert.Equal(t, "5", c.Res.Header().Get("Content-Length"))
+	})
+
+	t.Run("Download Attachment Stale If-Range", func(t *testing.T) {
+		service := new(usecase.MockAttachmentUsecase)
+		service.On("Open", mock.Anything, "1", "2").Return(attachment, fakeContent{strings.NewReader("hello world")}, nil).Once()
+
+		handler := NewAttachmentHandler(service, policy)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Params: map[string]string{"id": "1", "attachmentId": "2"},
+			Header: map[string]string{"Range": "bytes=6-", "If-Range": `"other"`},
+		})
+
+		if err := handler.DownloadAttachment(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 200, c.Res.Code)
+		assert.Equal(t, "hello world", c.Res.Body.String())
+	})
+
+	t.Run("Download Attachment Range Not Satisfiable", func(t *testing.T) {
+		service := new(usecase.MockAttachmentUsecase)
+		service.On("Open", mock.Anything, "1", "2").Return(attachment, fakeContent{strings.NewReader("hello world")}, nil).Once()
+
+		handler := NewAttachmentHandler(service, policy)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Params: map[string]string{"id": "1", "attachmentId": "2"},
+			Header: map[string]string{"Range": "bytes=20-"},
+		})
+
+		if err := handler.DownloadAttachment(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 416, c.Res.Code)
+		assert.Equal(t, "bytes */11", c.Res.Header().Get("Content-Range"))
+	})
+
+	t.Run("Download Attachment Not Found", func(t *testing.T) {
+		service := new(usecase.MockAttachmentUsecase)
+		service.On("Open", mock.Anything, "1", "2").Return(domain.Attachment{}, nil, domain.ErrNotFound).Once()
+
+		handler := NewAttachmentHandler(service, policy)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{Params: map[string]string{"id": "1", "attachmentId": "2"}})
+
+		if err := handler.DownloadAttachment(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 404, c.Res.Code)
+	})
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/sing3demons/go-backend-clean-architecture/bootstrap"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+)
+
+type BulkHandler struct {
+	BulkService domain.TaskBulkUsecase
+}
+
+func NewBulkHandler(bulkService domain.TaskBulkUsecase) *BulkHandler {
+	return &BulkHandler{
+		BulkService: bulkService,
+	}
+}
+
+// bulkRequest runs ordered unless ordered is false.
+type bulkRequest struct {
+	Ordered    *bool                  `json:"ordered"`
+	Operations []domain.BulkOperation `json:"operations"`
+}
+
+type bulkResponse struct {
+	Ordered   bool             `json:"ordered"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []bulkItemResult `json:"results"`
+}
+
+// bulkItemResult carries the status the operation would have had as a single request, error is a
+// message or the validation error of the operation.
+type bulkItemResult struct {
+	Index   int               `json:"index"`
+	Action  domain.BulkAction `json:"action"`
+	ID      string            `json:"id,omitempty"`
+	Version int64             `json:"version,omitempty"`
+	Status  int               `json:"status"`
+	Error   any               `json:"error,omitempty"`
+}
+
+// BulkTasks applies a batch of create, update and delete operations. The request fails as a whole only
+// when it is malformed, empty or over the batch size, otherwise it responds 200 with one result per
+// operation whether or not it was applied.
+func (h *BulkHandler) BulkTasks(ctx bootstrap.IContext) error {
+	var input bulkRequest
+	if err := ctx.ReadInput(&input); err != nil {
+		return ctx.Response(400, err.Error())
+	}
+
+	opts := domain.BulkOptions{Ordered: input.Ordered == nil || *input.Ordered}
+	results, err := h.BulkService.Bulk(requestContext(ctx), input.Operations, opts)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	response := bulkResponse{Ordered: opts.Ordered, Results: make([]bulkItemResult, len(results))}
+	for i, result := range results {
+		item := bulkItemResult{Index: result.Index, Action: result.Action, Version: result.Version}
+		if !result.ID.IsZero() {
+			item.ID = result.ID.Hex()
+		}
+
+		if result.Err != nil {
+			response.Failed++
+			item.Status, item.Error = errorStatus(result.Err), bulkError(result.Err)
+			item.Version = 0
+		} else {
+			response.Succeeded++
+			item.Status = bulkStatus(result.Action)
+		}
+		response.Results[i] = item
+	}
+
+	return ctx.Response(200, response)
+}
+
+func bulkStatus(action domain.BulkAction) int {
+	switch action {
+	case domain.BulkCreate:
+		return 201
+	case domain.BulkDelete:
+		return 204
+	default:
+		return 200
+	}
+}
+
+func bulkError(err error) any {
+	var validation *domain.ValidationError
+	if errors.As(err, &validation) {
+		return validation
+	}
+	return err.Error()
+}
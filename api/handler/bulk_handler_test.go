@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"fmt"
+	"testing"
+
+	bootstrap "github.com/sing3demons/go-backend-clean-architecture/bootstrap/mocks"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestBulkHandler(t *testing.T) {
+	taskID := primitive.NewObjectID()
+
+	t.Run("Bulk Tasks", func(t *testing.T) {
+		service := new(usecase.MockTaskBulkUsecase)
+		service.On("Bulk", mock.Anything, mock.MatchedBy(func(operations []domain.BulkOperation) bool {
+			return len(operations) == 3 && operations[0].Task.Title == "New" && operations[2].ID == taskID.Hex()
+		}), domain.BulkOptions{Ordered: true}).Return([]domain.BulkResult{
+			{Index: 0, Action: domain.BulkCreate, ID: taskID, Version: 1},
+			{Index: 1, Action: domain.BulkUpdate, ID: taskID, Version: 2, Err: &domain.ValidationError{Field: "status", Message: "unknown"}},
+			{Index: 2, Action: domain.BulkDelete, ID: taskID, Err: domain.ErrSkipped},
+		}, nil).Once()
+
+		handler := NewBulkHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Body: map[string]any{"operations": []map[string]any{
+				{"action": "create", "task": map[string]string{"title": "New"}},
+				{"action": "update", "id": taskID.Hex(), "task": map[string]any{"status": "later", "version": 1}},
+				{"action": "delete", "id": taskID.Hex()},
+			}},
+		})
+
+		if err := handler.BulkTasks(c); err != nil {
+			t.Error("Error")
+		}
+
+		var actual bulkResponse
+		assert.NoError(t, c.Body(&actual))
+		assert.Equal(t, 200, c.Res.Code)
+		assert.True(t, actual.Ordered)
+		assert.Equal(t, 1, actual.Succeeded)
+		assert.Equal(t, 2, actual.Failed)
+		assert.Equal(t, bulkItemResult{Index: 0, Action: domain.BulkCreate, ID: taskID.Hex(), Version: 1, Status: 201}, actual.Results[0])
+		assert.Equal(t, 422, actual.Results[1].Status)
+		assert.Equal(t, map[string]any{"field": "status", "message": "unknown"}, actual.Results[1].Error)
+		assert.Equal(t, int64(0), actual.Results[1].Version)
+		assert.Equal(t, 424, actual.Results[2].Status)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Bulk Tasks Unordered", func(t *testing.T) {
+		service := new(usecase.MockTaskBulkUsecase)
+		service.On("Bulk", mock.Anything, mock.Anything, domain.BulkOptions{}).Return([]domain.BulkResult{
+			{Index: 0, Action: domain.BulkDelete, ID: taskID},
+		}, nil).Once()
+
+		handler := NewBulkHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Body: map[string]any{"ordered": false, "operations": []map[string]any{{"action": "delete", "id": taskID.Hex()}}},
+		})
+
+		if err := handler.BulkTasks(c); err != nil {
+			t.Error("Error")
+		}
+
+		var actual bulkResponse
+		assert.NoError(t, c.Body(&actual))
+		assert.False(t, actual.Ordered)
+		assert.Equal(t, 204, actual.Results[0].Status)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Bulk Tasks Too Many Operations", func(t *testing.T) {
+		service := new(usecase.MockTaskBulkUsecase)
+		service.On("Bulk", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, fmt.Errorf("%w: at most 100 operations per request", domain.ErrTooLarge)).Once()
+
+		handler := NewBulkHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{Body: map[string]any{"operations": []map[string]any{}}})
+
+		if err := handler.BulkTasks(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 413, c.Res.Code)
+	})
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/sing3demons/go-backend-clean-architecture/bootstrap"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+)
+
+type CommentHandler struct {
+	CommentService domain.CommentUsecase
+}
+
+func NewCommentHandler(commentService domain.CommentUsecase) *CommentHandler {
+	return &CommentHandler{
+		CommentService: commentService,
+	}
+}
+
+type commentRequest struct {
+	Body string `json:"body"`
+}
+
+// CreateComment adds a comment by the user of the X-User-ID header.
+func (h *CommentHandler) CreateComment(ctx bootstrap.IContext) error {
+	var input commentRequest
+	if err := ctx.ReadInput(&input); err != nil {
+		return ctx.Response(400, err.Error())
+	}
+
+	comment := domain.Comment{Body: input.Body}
+	err := h.CommentService.Create(requestContext(ctx), ctx.Param("id"), &comment)
+	if errors.Is(err, domain.ErrEventNotPublished) {
+		ctx.Log().Errorf("Comment %s on task %s: %v", comment.ID.Hex(), comment.TaskID.Hex(), err)
+	} else if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return ctx.Response(201, comment)
+}
+
+// GetComments lists the comments of a task oldest first, the page and size query parameters select a page.
+func (h *CommentHandler) GetComments(ctx bootstrap.IContext) error {
+	taskID := ctx.Param("id")
+
+	page, err := pageRequest(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	comments, err := h.CommentService.FetchByTaskID(requestContext(ctx), taskID, page)
+	if err != nil {
+		return ctx.Response(errorStatus(err), err.Error())
+	}
+
+	return ctx.Response(200, comments)
+}
+
+func (h *CommentHandler) DeleteComment(ctx bootstrap.IContext) error {
+	taskID, commentID := ctx.Param("id"), ctx.Param("commentId")
+
+	if err := h.CommentService.Delete(requestContext(ctx), taskID, commentID); err != nil {
+		return ctx.Response(errorStatus(err), err.Error())
+	}
+
+	return ctx.Response(204, nil)
+}
+
+func pageRequest(ctx bootstrap.IContext) (domain.PageRequest, error) {
+	var page domain.PageRequest
+
+	for _, p := range []struct {
+		name  string
+		value *int64
+	}{{"page", &page.Page}, {"size", &page.Size}} {
+		raw := ctx.Query(p.name)
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || v < 1 {
+			return page, &domain.ValidationError{Field: p.name, Message: "must be a positive integer"}
+		}
+		*p.value = v
+	}
+
+	return page, nil
+}
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	bootstrap "github.com/sing3demons/go-backend-clean-architecture/bootstrap/mocks"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCommentHandler(t *testing.T) {
+	t.Run("Create Comment", func(t *testing.T) {
+		service := new(usecase.MockCommentUsecase)
+		service.On("Create", mock.MatchedBy(func(c context.Context) bool {
+			return domain.ActorFromContext(c) == "user-1"
+		}), "1", mock.MatchedBy(func(comment *domain.Comment) bool {
+			return comment.Body == "looks good"
+		})).Return(nil).Once()
+
+		handler := NewCommentHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Body:   map[string]string{"body": "looks good", "author": "someone-else"},
+			Params: map[string]string{"id": "1"},
+			Header: map[string]string{"X-User-ID": "user-1"},
+		})
+
+		if err := handler.CreateComment(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 201, c.Res.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Create Comment Unauthenticated", func(t *testing.T) {
+		service := new(usecase.MockCommentUsecase)
+		service.On("Create", mock.Anything, "1", mock.Anything).Return(domain.ErrUnauthenticated).Once()
+
+		handler := NewCommentHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Body:   map[string]string{"body": "looks good"},
+			Params: map[string]string{"id": "1"},
+		})
+
+		if err := handler.CreateComment(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 401, c.Res.Code)
+	})
+
+	t.Run("Get Comments", func(t *testing.T) {
+		service := new(usecase.MockCommentUsecase)
+		service.On("FetchByTaskID", mock.Anything, "1", domain.PageRequest{Page: 2, Size: 10}).
+			Return(domain.Page[domain.Comment]{Items: []domain.Comment{{Body: "hi"}}, Page: 2, Size: 10, Total: 11}, nil).Once()
+
+		handler := NewCommentHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Params: map[string]string{"id": "1"},
+			Query:  map[string]string{"page": "2", "size": "10"},
+		})
+
+		if err := handler.GetComments(c); err != nil {
+			t.Error("Error")
+		}
+
+		actual := domain.Page[domain.Comment]{}
+		assert.NoError(t, c.Body(&actual))
+		assert.Equal(t, 200, c.Res.Code)
+		assert.Equal(t, int64(11), actual.Total)
+		assert.Equal(t, "hi", actual.Items[0].Body)
+	})
+
+	t.Run("Get Comments Invalid Page", func(t *testing.T) {
+		service := new(usecase.MockCommentUsecase)
+
+		handler := NewCommentHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Params: map[string]string{"id": "1"},
+			Query:  map[string]string{"page": "0"},
+		})
+
+		if err := handler.GetComments(c); err != nil {
+			t.Error("Error")
+		}
+
+		actual := domain.ValidationError{}
+		assert.NoError(t, c.Body(&actual))
+		assert.Equal(t, 422, c.Res.Code)
+		assert.Equal(t, "page", actual.Field)
+	})
+
+	t.Run("Delete Comment", func(t *testing.T) {
+		service := new(usecase.MockCommentUsecase)
+		service.On("Delete", mock.Anything, "1", "2").Return(nil).Once()
+
+		handler := NewCommentHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Params: map[string]string{"id": "1", "commentId": "2"},
+			Header: map[string]string{"X-User-ID": "user-1"},
+		})
+
+		if err := handler.DeleteComment(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 204, c.Res.Code)
+	})
+
+	t.Run("Delete Comment Forbidden", func(t *testing.T) {
+		service := new(usecase.MockCommentUsecase)
+		service.On("Delete", mock.Anything, "1", "2").Return(domain.ErrForbidden).Once()
+
+		handler := NewCommentHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Params: map[string]string{"id": "1", "commentId": "2"},
+			Header: map[string]string{"X-User-ID": "user-2"},
+		})
+
+		if err := handler.DeleteComment(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 403, c.Res.Code)
+	})
+}
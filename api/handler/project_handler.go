@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/sing3demons/go-backend-clean-architecture/bootstrap"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ProjectHandler struct {
+	ProjectService domain.ProjectUsecase
+}
+
+func NewProjectHandler(projectService domain.ProjectUsecase) *ProjectHandler {
+	return &ProjectHandler{
+		ProjectService: projectService,
+	}
+}
+
+type projectRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Version     int64  `json:"version"`
+}
+
+func (h *ProjectHandler) CreateProject(ctx bootstrap.IContext) error {
+	var input projectRequest
+	if err := ctx.ReadInput(&input); err != nil {
+		return ctx.Response(400, err.Error())
+	}
+
+	project := domain.Project{Name: input.Name, Description: input.Description}
+	if err := h.ProjectService.Create(requestContext(ctx), ctx.Param("id"), &project); err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return ctx.Response(201, project)
+}
+
+// GetProjects lists the projects of a workspace, archived=true includes the archived ones.
+func (h *ProjectHandler) GetProjects(ctx bootstrap.IContext) error {
+	archived := false
+	if query := ctx.Query("archived"); query != "" {
+		var err error
+		if archived, err = strconv.ParseBool(query); err != nil {
+			return ctx.Response(400, &domain.ValidationError{Field: "archived", Message: "must be true or false"})
+		}
+	}
+
+	projects, err := h.ProjectService.FetchByWorkspaceID(requestContext(ctx), ctx.Param("id"), archived)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return ctx.Response(200, projects)
+}
+
+func (h *ProjectHandler) GetProjectByID(ctx bootstrap.IContext) error {
+	project, err := h.ProjectService.FetchByID(requestContext(ctx), ctx.Param("id"))
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return ctx.Response(200, project)
+}
+
+// UpdateProject renames the project or rewrites its description, the version of the request must match.
+func (h *ProjectHandler) UpdateProject(ctx bootstrap.IContext) error {
+	var input projectRequest
+	if err := ctx.ReadInput(&input); err != nil {
+		return ctx.Response(400, err.Error())
+	}
+
+	id, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		return errorResponse(ctx, domain.ErrInvalidID)
+	}
+
+	project := domain.Project{ID: id, Name: input.Name, Description: input.Description, Version: input.Version}
+	if err := h.ProjectService.Update(requestContext(ctx), &project); err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return ctx.Response(200, project)
+}
+
+// ArchiveProject archives the project together with its open tasks.
+func (h *ProjectHandler) ArchiveProject(ctx bootstrap.IContext) error {
+	project, err := h.ProjectService.Archive(requestContext(ctx), ctx.Param("id"))
+	if savedWithErrors(err) {
+		ctx.Log().Errorf("Project %s archived: %v", project.ID.Hex(), err)
+	} else if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return ctx.Response(200, project)
+}
+
+func (h *ProjectHandler) UnarchiveProject(ctx bootstrap.IContext) error {
+	project, err := h.ProjectService.Unarchive(requestContext(ctx), ctx.Param("id"))
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return ctx.Response(200, project)
+}
+
+func (h *ProjectHandler) GetProjectTasks(ctx bootstrap.IContext) error {
+	page, err := pageRequest(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	tasks, err := h.ProjectService.FetchTasks(requestContext(ctx), ctx.Param("id"), page)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return ctx.Response(200, tasks)
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"testing"
+
+	bootstrap "github.com/sing3demons/go-backend-clean-architecture/bootstrap/mocks"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestProjectHandler(t *testing.T) {
+	t.Run("Get Projects", func(t *testing.T) {
+		service := new(usecase.MockProjectUsecase)
+		service.On("FetchByWorkspaceID", mock.Anything, "1", true).Return([]domain.Project{{Name: "Launch"}}, nil).Once()
+
+		handler := NewProjectHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Params: map[string]string{"id": "1"},
+			Query:  map[string]string{"archived": "true"},
+		})
+
+		assert.NoError(t, handler.GetProjects(c))
+
+		actual := []domain.Project{}
+		assert.NoError(t, c.Body(&actual))
+		assert.Equal(t, 200, c.Res.Code)
+		assert.Equal(t, "Launch", actual[0].Name)
+	})
+
+	t.Run("Get Projects Invalid Archived", func(t *testing.T) {
+		service := new(usecase.MockProjectUsecase)
+
+		handler := NewProjectHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Params: map[string]string{"id": "1"},
+			Query:  map[string]string{"archived": "maybe"},
+		})
+
+		assert.NoError(t, handler.GetProjects(c))
+		assert.Equal(t, 400, c.Res.Code)
+		service.AssertNotCalled(t, "FetchByWorkspaceID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Archive Project", func(t *testing.T) {
+		service := new(usecase.MockProjectUsecase)
+		service.On("Archive", mock.Anything, "1").Return(domain.Project{ID: primitive.NewObjectID()}, nil).Once()
+
+		handler := NewProjectHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Params: map[string]string{"id": "1"},
+		})
+
+		assert.NoError(t, handler.ArchiveProject(c))
+		assert.Equal(t, 200, c.Res.Code)
+	})
+
+	t.Run("Archive Project Forbidden", func(t *testing.T) {
+		service := new(usecase.MockProjectUsecase)
+		service.On("Archive", mock.Anything, "1").Return(domain.Project{}, domain.ErrForbidden).Once()
+
+		handler := NewProjectHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Params: map[string]string{"id": "1"},
+		})
+
+		assert.NoError(t, handler.ArchiveProject(c))
+		assert.Equal(t, 403, c.Res.Code)
+	})
+
+	t.Run("Get Project Tasks", func(t *testing.T) {
+		service := new(usecase.MockProjectUsecase)
+		service.On("FetchTasks", mock.Anything, "1", domain.PageRequest{Page: 2}).Return(domain.Page[domain.Task]{
+			Items: []domain.Task{{Title: "Ship"}},
+			Page:  2,
+			Size:  20,
+			Total: 21,
+		}, nil).Once()
+
+		handler := NewProjectHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Params: map[string]string{"id": "1"},
+			Query:  map[string]string{"page": "2"},
+		})
+
+		assert.NoError(t, handler.GetProjectTasks(c))
+
+		actual := domain.Page[domain.Task]{}
+		assert.NoError(t, c.Body(&actual))
+		assert.Equal(t, 200, c.Res.Code)
+		assert.Equal(t, "Ship", actual.Items[0].Title)
+	})
+
+	t.Run("Update Project Invalid ID", func(t *testing.T) {
+		service := new(usecase.MockProjectUsecase)
+
+		handler := NewProjectHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Body:   map[string]any{"name": "Launch", "version": 1},
+			Params: map[string]string{"id": "bad"},
+		})
+
+		assert.NoError(t, handler.UpdateProject(c))
+		assert.Equal(t, 400, c.Res.Code)
+		service.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
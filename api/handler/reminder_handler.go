@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/sing3demons/go-backend-clean-architecture/bootstrap"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+)
+
+type ReminderHandler struct {
+	ReminderService domain.TaskReminderUsecase
+}
+
+func NewReminderHandler(reminderService domain.TaskReminderUsecase) *ReminderHandler {
+	return &ReminderHandler{
+		ReminderService: reminderService,
+	}
+}
+
+// SendReminders is a scheduled job, the reminders are published through the producer of ctx.
+func (h *ReminderHandler) SendReminders(ctx bootstrap.IContext) error {
+	sent, err := h.ReminderService.SendReminders(ctx.Context(), contextPublisher{ctx: ctx})
+	if sent > 0 {
+		ctx.Log().Infof("Sent %d task reminders", sent)
+	}
+	return err
+}
+
+// contextPublisher publishes domain events with the producer of a request or job context.
+type contextPublisher struct {
+	ctx bootstrap.IContext
+}
+
+func (p contextPublisher) Publish(c context.Context, topic, key string, payload any) error {
+	_, err := p.ctx.SendMessage(topic, payload, bootstrap.WithKey(key))
+	return err
+}
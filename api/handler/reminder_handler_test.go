@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/sing3demons/go-backend-clean-architecture/bootstrap"
+	"github.com/sing3demons/go-backend-clean-architecture/bootstrap/mocks"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestSendReminders(t *testing.T) {
+	now := time.Date(2025, 2, 1, 10, 0, 0, 0, time.UTC)
+	dueDate := now.Add(time.Hour)
+	taskID := primitive.NewObjectID()
+	log := bootstrap.NewZapLogger(zap.NewNop())
+
+	newHandler := func(repo *repository.MockTaskRepository) *ReminderHandler {
+		return NewReminderHandler(usecase.NewTaskReminderUsecase(repo, fixedClock(now), 24*time.Hour, 2*time.Second))
+	}
+
+	t.Run("publishes keyed by task id and records the reminder", func(t *testing.T) {
+		repo := new(repository.MockTaskRepository)
+		repo.On("FetchDueForReminder", mock.Anything, 24*time.Hour).Return([]domain.Task{{ID: taskID, Title: "title", DueDate: &dueDate}}, nil).Once()
+		repo.On("MarkReminded", mock.Anything, taskID.Hex()).Return(nil).Once()
+
+		producer := mocks.NewMockSyncProducer()
+		producer.On("SendMessage", mock.MatchedBy(func(msg *sarama.ProducerMessage) bool {
+			key, _ := msg.Key.Encode()
+			value, _ := msg.Value.Encode()
+
+			var event domain.TaskReminder
+			if err := json.Unmarshal(value, &event); err != nil {
+				return false
+			}
+			return msg.Topic == domain.TopicTaskReminder && string(key) == taskID.Hex() &&
+				event.TaskID == taskID.Hex() && event.DueDate.Equal(dueDate) && event.At.Equal(now)
+		})).Return(int32(0), int64(1), nil).Once()
+
+		ctx := bootstrap.NewConsumerContext("", "", producer, log)
+		assert.NoError(t, newHandler(repo).SendReminders(ctx))
+
+		producer.AssertExpectations(t)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("producer failure is reminded again", func(t *testing.T) {
+		repo := new(repository.MockTaskRepository)
+		repo.On("FetchDueForReminder", mock.Anything, 24*time.Hour).Return([]domain.Task{{ID: taskID, Title: "title", DueDate: &dueDate}}, nil).Once()
+
+		producer := mocks.NewMockSyncProducer()
+		producer.On("SendMessage", mock.Anything).Return(int32(0), int64(0), errors.New("broker down")).Once()
+
+		ctx := bootstrap.NewConsumerContext("", "", producer, log)
+		assert.Error(t, newHandler(repo).SendReminders(ctx))
+
+		repo.AssertNotCalled(t, "MarkReminded", mock.Anything, mock.Anything)
+	})
+
+	t.Run("no producer", func(t *testing.T) {
+		repo := new(repository.MockTaskRepository)
+		repo.On("FetchDueForReminder", mock.Anything, 24*time.Hour).Return([]domain.Task{{ID: taskID, Title: "title", DueDate: &dueDate}}, nil).Once()
+
+		ctx := bootstrap.NewConsumerContext("", "", nil, log)
+		err := newHandler(repo).SendReminders(ctx)
+
+		assert.ErrorIs(t, err, bootstrap.ErrNoProducer)
+		repo.AssertNotCalled(t, "MarkReminded", mock.Anything, mock.Anything)
+	})
+}
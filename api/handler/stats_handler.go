@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/bootstrap"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+)
+
+type StatsHandler struct {
+	StatsService domain.TaskStatsUsecase
+}
+
+func NewStatsHandler(statsService domain.TaskStatsUsecase) *StatsHandler {
+	return &StatsHandler{
+		StatsService: statsService,
+	}
+}
+
+// GetTaskStats reports on the tasks between the from and to query parameters, grouped into periods of
+// interval (day, week or month) in the tz time zone. from and to are RFC 3339 times or dates in tz,
+// a date in to includes that whole day.
+func (h *StatsHandler) GetTaskStats(ctx bootstrap.IContext) error {
+	query := domain.TaskStatsQuery{
+		Interval: domain.StatsInterval(ctx.Query("interval")),
+		TimeZone: ctx.Query("tz"),
+	}
+
+	location, err := time.LoadLocation(query.TimeZone)
+	if err != nil {
+		return ctx.Response(400, &domain.ValidationError{Field: "tz", Message: "unknown time zone " + query.TimeZone})
+	}
+	if query.From, err = statsTime(ctx.Query("from"), location, false); err != nil {
+		return ctx.Response(400, &domain.ValidationError{Field: "from", Message: err.Error()})
+	}
+	if query.To, err = statsTime(ctx.Query("to"), location, true); err != nil {
+		return ctx.Response(400, &domain.ValidationError{Field: "to", Message: err.Error()})
+	}
+
+	stats, err := h.StatsService.Stats(requestContext(ctx), query)
+	var validation *domain.ValidationError
+	if errors.As(err, &validation) {
+		return ctx.Response(400, validation)
+	} else if err != nil {
+		return ctx.Response(errorStatus(err), err.Error())
+	}
+
+	return ctx.Response(200, stats)
+}
+
+func statsTime(value string, location *time.Location, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	day, err := time.ParseInLocation(time.DateOnly, value, location)
+	if err != nil {
+		return time.Time{}, errors.New("must be an RFC 3339 time or a YYYY-MM-DD date")
+	}
+	if endOfDay {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day, nil
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	bootstrap "github.com/sing3demons/go-backend-clean-architecture/bootstrap/mocks"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestStatsHandler(t *testing.T) {
+	bangkok, err := time.LoadLocation("Asia/Bangkok")
+	assert.NoError(t, err)
+
+	t.Run("Get Task Stats", func(t *testing.T) {
+		service := new(usecase.MockTaskStatsUsecase)
+		service.On("Stats", mock.Anything, mock.MatchedBy(func(query domain.TaskStatsQuery) bool {
+			// A date in to includes the whole day
+			return query.From.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, bangkok)) &&
+				query.To.Equal(time.Date(2025, 2, 1, 0, 0, 0, 0, bangkok)) &&
+				query.Interval == domain.IntervalDay && query.TimeZone == "Asia/Bangkok"
+		})).Return(domain.TaskStats{Total: 2, ByStatus: map[domain.TaskStatus]int64{domain.StatusTodo: 2}}, nil).Once()
+
+		handler := NewStatsHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Query: map[string]string{"from": "2025-01-01", "to": "2025-01-31", "interval": "day", "tz": "Asia/Bangkok"},
+		})
+
+		if err := handler.GetTaskStats(c); err != nil {
+			t.Error("Error")
+		}
+
+		var actual domain.TaskStats
+		assert.NoError(t, c.Body(&actual))
+		assert.Equal(t, 200, c.Res.Code)
+		assert.Equal(t, int64(2), actual.ByStatus[domain.StatusTodo])
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Get Task Stats RFC 3339", func(t *testing.T) {
+		from := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+		service := new(usecase.MockTaskStatsUsecase)
+		service.On("Stats", mock.Anything, domain.TaskStatsQuery{From: from}).Return(domain.TaskStats{}, nil).Once()
+
+		handler := NewStatsHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{Query: map[string]string{"from": from.Format(time.RFC3339)}})
+
+		if err := handler.GetTaskStats(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 200, c.Res.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Get Task Stats Invalid Date", func(t *testing.T) {
+		service := new(usecase.MockTaskStatsUsecase)
+
+		handler := NewStatsHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{Query: map[string]string{"to": "last week"}})
+
+		if err := handler.GetTaskStats(c); err != nil {
+			t.Error("Error")
+		}
+
+		actual := domain.ValidationError{}
+		assert.NoError(t, c.Body(&actual))
+		assert.Equal(t, 400, c.Res.Code)
+		assert.Equal(t, "to", actual.Field)
+	})
+
+	t.Run("Get Task Stats Invalid Query", func(t *testing.T) {
+		service := new(usecase.MockTaskStatsUsecase)
+		service.On("Stats", mock.Anything, mock.Anything).
+			Return(domain.TaskStats{}, &domain.ValidationError{Field: "interval", Message: "must be day, week or month"}).Once()
+
+		handler := NewStatsHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{Query: map[string]string{"interval": "year"}})
+
+		if err := handler.GetTaskStats(c); err != nil {
+			t.Error("Error")
+		}
+
+		actual := domain.ValidationError{}
+		assert.NoError(t, c.Body(&actual))
+		assert.Equal(t, 400, c.Res.Code)
+		assert.Equal(t, "interval", actual.Field)
+	})
+}
@@ -1,12 +1,25 @@
 package handler
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/sing3demons/go-backend-clean-architecture/bootstrap"
 	"github.com/sing3demons/go-backend-clean-architecture/domain"
 )
 
+const (
+	// HeaderUserID identifies the caller, it is recorded as the actor on audited writes.
+	HeaderUserID = "X-User-ID"
+	// HeaderUserRole is the role of the caller, set by the gateway like HeaderUserID.
+	HeaderUserRole = "X-User-Role"
+
+	RoleAdmin = "admin"
+)
+
 type TaskHandler struct {
 	TaskService domain.TaskUsecase
 }
@@ -24,19 +37,256 @@ func (handler *TaskHandler) CreateTask(ctx bootstrap.IContext) error {
 		return ctx.Response(400, err.Error())
 	}
 
-	if err := handler.TaskService.Create(ctx.Context(), &task); err != nil {
-		return ctx.Response(500, err.Error())
+	if err := handler.TaskService.Create(requestContext(ctx), &task); err != nil {
+		return errorResponse(ctx, err)
 	}
 
 	return ctx.Response(200, task)
 }
 
+// GetTask lists tasks, the status, priority, overdue=true and dueWithin (a duration such as 48h) query
+// parameters narrow the list.
 func (h *TaskHandler) GetTask(ctx bootstrap.IContext) error {
-	tasks, err := h.TaskService.FetchAll(ctx.Context())
+	filter, err := taskFilter(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	var tasks []domain.Task
+	if filter.IsZero() {
+		tasks, err = h.TaskService.FetchAll(requestContext(ctx))
+	} else {
+		tasks, err = h.TaskService.FetchByFilter(requestContext(ctx), filter)
+	}
+
+	if err != nil {
+		return ctx.Response(500, err.Error())
+	}
+
+	return ctx.Response(200, tasks)
+}
+
+func (h *TaskHandler) GetTaskByID(ctx bootstrap.IContext) error {
+	task, err := h.TaskService.FetchByTaskID(requestContext(ctx), ctx.Param("id"))
+	if err != nil {
+		return ctx.Response(errorStatus(err), err.Error())
+	}
+
+	etag := bootstrap.ETag(task.Version)
+	bootstrap.SetETag(ctx, etag)
+	if status := bootstrap.CheckPreconditions(ctx, etag, true); status != 0 {
+		return ctx.Response(status, nil)
+	}
+
+	return ctx.Response(200, task)
+}
+
+// UpdateTask expects the version being edited in If-Match or in the body, without either it responds 428
+// so that clients cannot overwrite changes they have not seen.
+func (h *TaskHandler) UpdateTask(ctx bootstrap.IContext) error {
+	var task domain.Task
+	if err := ctx.ReadInput(&task); err != nil {
+		return ctx.Response(400, err.Error())
+	}
+
+	current, err := h.TaskService.FetchByTaskID(requestContext(ctx), ctx.Param("id"))
+	if err != nil {
+		return ctx.Response(errorStatus(err), err.Error())
+	}
+
+	if status := bootstrap.CheckPreconditions(ctx, bootstrap.ETag(current.Version), false); status != 0 {
+		return ctx.Response(status, domain.ErrConflict.Error())
+	}
+
+	conflictStatus := 409
+	if ctx.GetHeader(bootstrap.HeaderIfMatch) != "" {
+		task.Version = current.Version
+		conflictStatus = 412
+	}
+	if task.Version == 0 {
+		return ctx.Response(428, "If-Match header or version is required")
+	}
+
+	task.ID = current.ID
+	if err := h.TaskService.Update(requestContext(ctx), &task); err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			return ctx.Response(conflictStatus, err.Error())
+		}
+		return ctx.Response(errorStatus(err), err.Error())
+	}
+
+	bootstrap.SetETag(ctx, bootstrap.ETag(task.Version))
+	return ctx.Response(200, task)
+}
+
+type transitionRequest struct {
+	Status domain.TaskStatus `json:"status"`
+}
+
+func (h *TaskHandler) TransitionTask(ctx bootstrap.IContext) error {
+	var input transitionRequest
+	if err := ctx.ReadInput(&input); err != nil {
+		return ctx.Response(400, err.Error())
+	}
+
+	task, err := h.TaskService.Transition(requestContext(ctx), ctx.Param("id"), input.Status)
+	if savedWithErrors(err) {
+		ctx.Log().Errorf("Task %s moved to %s: %v", task.ID.Hex(), task.Status, err)
+	} else if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	bootstrap.SetETag(ctx, bootstrap.ETag(task.Version))
+	return ctx.Response(200, task)
+}
 
+// GetTaskTree returns the task with its subtasks nested under "subtasks".
+func (h *TaskHandler) GetTaskTree(ctx bootstrap.IContext) error {
+	tree, err := h.TaskService.FetchTree(requestContext(ctx), ctx.Param("id"))
+	if err != nil {
+		return ctx.Response(errorStatus(err), err.Error())
+	}
+
+	return ctx.Response(200, tree)
+}
+
+// SearchTasks finds tasks by the words of the q query parameter, most relevant first. The page and size
+// query parameters select a page.
+func (h *TaskHandler) SearchTasks(ctx bootstrap.IContext) error {
+	page, err := pageRequest(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	matches, err := h.TaskService.Search(requestContext(ctx), ctx.Query("q"), page)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return ctx.Response(200, matches)
+}
+
+func (h *TaskHandler) DeleteTask(ctx bootstrap.IContext) error {
+	if err := h.TaskService.Delete(requestContext(ctx), ctx.Param("id")); err != nil {
+		return ctx.Response(errorStatus(err), err.Error())
+	}
+
+	return ctx.Response(204, nil)
+}
+
+func (h *TaskHandler) RestoreTask(ctx bootstrap.IContext) error {
+	task, err := h.TaskService.Restore(requestContext(ctx), ctx.Param("id"))
+	if err != nil {
+		return ctx.Response(errorStatus(err), err.Error())
+	}
+
+	return ctx.Response(200, task)
+}
+
+// GetDeletedTasks lists the trash of every user, its route is guarded by RequireAdmin.
+func (h *TaskHandler) GetDeletedTasks(ctx bootstrap.IContext) error {
+	tasks, err := h.TaskService.FetchDeleted(ctx.Context())
 	if err != nil {
 		return ctx.Response(500, err.Error())
 	}
 
 	return ctx.Response(200, tasks)
 }
+
+func taskFilter(ctx bootstrap.IContext) (domain.TaskFilter, error) {
+	var filter domain.TaskFilter
+
+	if status := domain.TaskStatus(ctx.Query("status")); status != "" {
+		if !status.Valid() {
+			return filter, &domain.ValidationError{Field: "status", Message: fmt.Sprintf("unknown status %q", status)}
+		}
+		filter.Status = status
+	}
+
+	if priority := ctx.Query("priority"); priority != "" {
+		p, err := domain.ParseTaskPriority(priority)
+		if err != nil {
+			return filter, err
+		}
+		filter.Priority = p
+	}
+
+	if overdue := ctx.Query("overdue"); overdue != "" {
+		v, err := strconv.ParseBool(overdue)
+		if err != nil {
+			return filter, &domain.ValidationError{Field: "overdue", Message: "must be true or false"}
+		}
+		filter.Overdue = v
+	}
+
+	if dueWithin := ctx.Query("dueWithin"); dueWithin != "" {
+		d, err := time.ParseDuration(dueWithin)
+		if err != nil || d <= 0 {
+			return filter, &domain.ValidationError{Field: "dueWithin", Message: "must be a positive duration such as 48h"}
+		}
+		filter.DueWithin = d
+	}
+
+	if filter.Overdue && filter.DueWithin > 0 {
+		return filter, &domain.ValidationError{Field: "dueWithin", Message: "cannot be combined with overdue"}
+	}
+
+	return filter, nil
+}
+
+// RequireAdmin lets only admins through: a request without a user responds 401, any other role 403.
+func RequireAdmin(next bootstrap.HandleFunc) bootstrap.HandleFunc {
+	return func(ctx bootstrap.IContext) error {
+		if ctx.GetHeader(HeaderUserID) == "" {
+			return errorResponse(ctx, domain.ErrUnauthenticated)
+		}
+		if ctx.GetHeader(HeaderUserRole) != RoleAdmin {
+			return errorResponse(ctx, domain.ErrForbidden)
+		}
+		return next(ctx)
+	}
+}
+
+func requestContext(ctx bootstrap.IContext) context.Context {
+	return domain.WithActor(ctx.Context(), ctx.GetHeader(HeaderUserID))
+}
+
+// errorResponse responds with the status of err, validation errors keep their field in the body.
+func errorResponse(ctx bootstrap.IContext, err error) error {
+	var validation *domain.ValidationError
+	if errors.As(err, &validation) {
+		return ctx.Response(errorStatus(err), validation)
+	}
+	return ctx.Response(errorStatus(err), err.Error())
+}
+
+// savedWithErrors reports an error returned together with a change that was saved, the handler logs it
+// and answers as if the change had fully succeeded.
+func savedWithErrors(err error) bool {
+	return errors.Is(err, domain.ErrEventNotPublished)
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return 404
+	case errors.Is(err, domain.ErrInvalidID):
+		return 400
+	case errors.Is(err, domain.ErrUnauthenticated):
+		return 401
+	case errors.Is(err, domain.ErrForbidden):
+		return 403
+	case errors.Is(err, domain.ErrConflict), errors.Is(err, domain.ErrDuplicate):
+		return 409
+	case errors.Is(err, domain.ErrTooLarge):
+		return 413
+	case errors.Is(err, domain.ErrUnsupportedMediaType):
+		return 415
+	case errors.Is(err, domain.ErrValidation):
+		return 422
+	case errors.Is(err, domain.ErrSkipped):
+		return 424
+	default:
+		return 500
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	app "github.com/sing3demons/go-backend-clean-architecture/bootstrap"
 	bootstrap "github.com/sing3demons/go-backend-clean-architecture/bootstrap/mocks"
 	"github.com/sing3demons/go-backend-clean-architecture/domain"
 	"github.com/sing3demons/go-backend-clean-architecture/repository"
@@ -141,6 +142,441 @@ func TestTaskHandler(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	})
 
+	t.Run("Get Task By ID Not Modified", func(t *testing.T) {
+		service := new(usecase.MockTaskUsecase)
+		service.On("FetchByTaskID", mock.Anything, "1").Return(domain.Task{Title: "title", Version: 2}, nil).Once()
+
+		handler := NewTaskHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Params: map[string]string{"id": "1"},
+			Header: map[string]string{"If-None-Match": `"2"`},
+		})
+
+		if err := handler.GetTaskByID(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 304, c.Res.Code)
+		assert.Equal(t, `"2"`, c.Res.Header().Get("ETag"))
+		assert.Empty(t, c.Res.Body.String())
+	})
+
+	t.Run("Get Task Tree", func(t *testing.T) {
+		service := new(usecase.MockTaskUsecase)
+		service.On("FetchTree", mock.Anything, "1").Return(domain.TaskTree{
+			Task:     domain.Task{Title: "root"},
+			Subtasks: []*domain.TaskTree{{Task: domain.Task{Title: "subtask"}, Subtasks: []*domain.TaskTree{}}},
+		}, nil).Once()
+
+		handler := NewTaskHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{Params: map[string]string{"id": "1"}})
+
+		if err := handler.GetTaskTree(c); err != nil {
+			t.Error("Error")
+		}
+
+		var actual struct {
+			Title    string `json:"title"`
+			Subtasks []struct {
+				Title    string `json:"title"`
+				Subtasks []any  `json:"subtasks"`
+			} `json:"subtasks"`
+		}
+		assert.NoError(t, c.Body(&actual))
+		assert.Equal(t, 200, c.Res.Code)
+		assert.Equal(t, "root", actual.Title)
+		assert.Equal(t, "subtask", actual.Subtasks[0].Title)
+	})
+
+	t.Run("Get Task Tree Not Found", func(t *testing.T) {
+		service := new(usecase.MockTaskUsecase)
+		service.On("FetchTree", mock.Anything, "1").Return(domain.TaskTree{}, domain.ErrNotFound).Once()
+
+		handler := NewTaskHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{Params: map[string]string{"id": "1"}})
+
+		if err := handler.GetTaskTree(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 404, c.Res.Code)
+	})
+
+	t.Run("Update Task", func(t *testing.T) {
+		service := new(usecase.MockTaskUsecase)
+		service.On("FetchByTaskID", mock.Anything, "1").Return(domain.Task{Title: "title", Version: 2}, nil).Once()
+		service.On("Update", mock.Anything, mock.MatchedBy(func(task *domain.Task) bool {
+			return task.Version == 2 && task.Title == "new title"
+		})).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.Task).Version = 3
+		}).Return(nil).Once()
+
+		handler := NewTaskHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Body:   domain.Task{Title: "new title"},
+			Params: map[string]string{"id": "1"},
+			Header: map[string]string{"If-Match": `"2"`},
+		})
+
+		if err := handler.UpdateTask(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 200, c.Res.Code)
+		assert.Equal(t, `"3"`, c.Res.Header().Get("ETag"))
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Update Task Stale If-Match", func(t *testing.T) {
+		service := new(usecase.MockTaskUsecase)
+		service.On("FetchByTaskID", mock.Anything, "1").Return(domain.Task{Title: "title", Version: 3}, nil).Once()
+
+		handler := NewTaskHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Body:   domain.Task{Title: "new title"},
+			Params: map[string]string{"id": "1"},
+			Header: map[string]string{"If-Match": `"2"`},
+		})
+
+		if err := handler.UpdateTask(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 412, c.Res.Code)
+		service.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Update Task Version Conflict", func(t *testing.T) {
+		service := new(usecase.MockTaskUsecase)
+		service.On("FetchByTaskID", mock.Anything, "1").Return(domain.Task{Title: "title", Version: 3}, nil).Once()
+		service.On("Update", mock.Anything, mock.Anything).Return(domain.ErrConflict).Once()
+
+		handler := NewTaskHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Body:   domain.Task{Title: "new title", Version: 2},
+			Params: map[string]string{"id": "1"},
+		})
+
+		if err := handler.UpdateTask(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 409, c.Res.Code)
+	})
+
+	t.Run("Update Task Without Version", func(t *testing.T) {
+		service := new(usecase.MockTaskUsecase)
+		service.On("FetchByTaskID", mock.Anything, "1").Return(domain.Task{Title: "title", Version: 3}, nil).Once()
+
+		handler := NewTaskHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Body:   domain.Task{Title: "new title"},
+			Params: map[string]string{"id": "1"},
+		})
+
+		if err := handler.UpdateTask(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 428, c.Res.Code)
+	})
+
+	t.Run("Transition Task Illegal", func(t *testing.T) {
+		service := new(usecase.MockTaskUsecase)
+		service.On("Transition", mock.Anything, "1", domain.StatusDone).
+			Return(domain.Task{}, &domain.ValidationError{Field: "status", Message: "cannot move from archived to done"}).Once()
+
+		handler := NewTaskHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Body:   map[string]string{"status": "done"},
+			Params: map[string]string{"id": "1"},
+		})
+
+		if err := handler.TransitionTask(c); err != nil {
+			t.Error("Error")
+		}
+
+		actual := domain.ValidationError{}
+		assert.NoError(t, c.Body(&actual))
+		assert.Equal(t, 422, c.Res.Code)
+		assert.Equal(t, "status", actual.Field)
+	})
+
+	t.Run("Get Task Filtered", func(t *testing.T) {
+		service := new(usecase.MockTaskUsecase)
+		service.On("FetchByFilter", mock.Anything, domain.TaskFilter{
+			Priority:  domain.PriorityHigh,
+			DueWithin: 48 * time.Hour,
+		}).Return([]domain.Task{{Title: "title"}}, nil).Once()
+
+		handler := NewTaskHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Query: map[string]string{"priority": "high", "dueWithin": "48h"},
+		})
+
+		if err := handler.GetTask(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 200, c.Res.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Get Task Invalid Filter", func(t *testing.T) {
+		service := new(usecase.MockTaskUsecase)
+
+		handler := NewTaskHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Query: map[string]string{"overdue": "true", "dueWithin": "24h"},
+		})
+
+		if err := handler.GetTask(c); err != nil {
+			t.Error("Error")
+		}
+
+		actual := domain.ValidationError{}
+		assert.NoError(t, c.Body(&actual))
+		assert.Equal(t, 422, c.Res.Code)
+		assert.Equal(t, "dueWithin", actual.Field)
+	})
+
+	t.Run("Search Tasks", func(t *testing.T) {
+		service := new(usecase.MockTaskUsecase)
+		service.On("Search", mock.Anything, "login", domain.PageRequest{Page: 2}).Return(domain.Page[domain.TaskMatch]{
+			Items: []domain.TaskMatch{{
+				Task:       domain.Task{Title: "Fix login"},
+				Score:      1.5,
+				Highlights: map[string]string{"title": "Fix <mark>login</mark>"},
+			}},
+			Page:  2,
+			Size:  domain.DefaultPageSize,
+			Total: 21,
+		}, nil).Once()
+
+		handler := NewTaskHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Query: map[string]string{"q": "login", "page": "2"},
+		})
+
+		if err := handler.SearchTasks(c); err != nil {
+			t.Error("Error")
+		}
+
+		var actual struct {
+			Items []struct {
+				Title      string            `json:"title"`
+				Score      float64           `json:"score"`
+				Highlights map[string]string `json:"highlights"`
+			} `json:"items"`
+			Total int64 `json:"total"`
+		}
+		assert.NoError(t, c.Body(&actual))
+		assert.Equal(t, 200, c.Res.Code)
+		assert.Equal(t, int64(21), actual.Total)
+		assert.Equal(t, "Fix login", actual.Items[0].Title)
+		assert.Equal(t, "Fix <mark>login</mark>", actual.Items[0].Highlights["title"])
+	})
+
+	t.Run("Search Tasks Invalid Query", func(t *testing.T) {
+		service := new(usecase.MockTaskUsecase)
+		service.On("Search", mock.Anything, "", domain.PageRequest{}).
+			Return(domain.Page[domain.TaskMatch]{}, &domain.ValidationError{Field: "q", Message: "must contain a word"}).Once()
+
+		handler := NewTaskHandler(service)
+		c := bootstrap.NewMockMuxContext()
+
+		if err := handler.SearchTasks(c); err != nil {
+			t.Error("Error")
+		}
+
+		actual := domain.ValidationError{}
+		assert.NoError(t, c.Body(&actual))
+		assert.Equal(t, 422, c.Res.Code)
+		assert.Equal(t, "q", actual.Field)
+	})
+
+	t.Run("Delete Task", func(t *testing.T) {
+		service := new(usecase.MockTaskUsecase)
+		service.On("Delete", mock.Anything, "").Return(nil).Once()
+
+		handler := NewTaskHandler(service)
+		c := bootstrap.NewMockMuxContext()
+
+		assert.NoError(t, handler.DeleteTask(c))
+		assert.Equal(t, 204, c.Res.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Delete Task History Failure", func(t *testing.T) {
+		service := new(usecase.MockTaskUsecase)
+		service.On("Delete", mock.Anything, mock.Anything).Return(errors.New("history write failed")).Once()
+
+		handler := NewTaskHandler(service)
+		c := bootstrap.NewMockMuxContext()
+
+		assert.NoError(t, handler.DeleteTask(c))
+		assert.Equal(t, 500, c.Res.Code)
+	})
+
+	t.Run("Delete Task Not Found", func(t *testing.T) {
+		service := new(usecase.MockTaskUsecase)
+		service.On("Delete", mock.Anything, mock.Anything).Return(domain.ErrNotFound).Once()
+
+		handler := NewTaskHandler(service)
+		c := bootstrap.NewMockMuxContext()
+
+		if err := handler.DeleteTask(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 404, c.Res.Code)
+	})
+
+	t.Run("Restore Task Invalid ID", func(t *testing.T) {
+		service := new(usecase.MockTaskUsecase)
+		service.On("Restore", mock.Anything, mock.Anything).Return(domain.Task{}, domain.ErrInvalidID).Once()
+
+		handler := NewTaskHandler(service)
+		c := bootstrap.NewMockMuxContext()
+
+		if err := handler.RestoreTask(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 400, c.Res.Code)
+	})
+
+	t.Run("Get Deleted Tasks", func(t *testing.T) {
+		service := new(usecase.MockTaskUsecase)
+		service.On("FetchDeleted", mock.Anything).Return([]domain.Task{{Title: "title"}}, nil).Once()
+
+		handler := NewTaskHandler(service)
+		c := bootstrap.NewMockMuxContext()
+
+		if err := handler.GetDeletedTasks(c); err != nil {
+			t.Error("Error")
+		}
+
+		actual := []domain.Task{}
+		assert.NoError(t, c.Body(&actual))
+		assert.Len(t, actual, 1)
+		assert.Equal(t, 200, c.Res.Code)
+	})
+}
+
+// TestTaskHandlerProjectTasks checks that the handlers pass the user of the request to the usecase, the
+// tasks of a project are only seen by the members of its workspace.
+func TestTaskHandlerProjectTasks(t *testing.T) {
+	workspace := domain.Workspace{ID: primitive.NewObjectID(), Members: []domain.Member{{UserID: "member", Role: domain.RoleMember}}}
+	project := domain.Project{ID: primitive.NewObjectID(), WorkspaceID: workspace.ID, Name: "Launch", Version: 1}
+	task := domain.Task{ID: primitive.NewObjectID(), Title: "title", ProjectID: project.ID, Version: 2}
+
+	newHandler := func(repo *repository.MockTaskRepository) *TaskHandler {
+		projects := new(repository.MockProjectRepository)
+		workspaces := new(repository.MockWorkspaceRepository)
+		projects.On("FetchByID", mock.Anything, project.ID.Hex()).Return(project, nil).Maybe()
+		projects.On("FetchByWorkspaceIDs", mock.Anything, []primitive.ObjectID{workspace.ID}).Return([]domain.Project{project}, nil).Maybe()
+		workspaces.On("FetchByID", mock.Anything, workspace.ID.Hex()).Return(workspace, nil).Maybe()
+		workspaces.On("FetchByMember", mock.Anything, "member").Return([]domain.Workspace{workspace}, nil).Maybe()
+
+		repo.On("FetchByTaskID", mock.Anything, task.ID.Hex()).Return(task, nil).Maybe()
+		return NewTaskHandler(usecase.NewTaskUsecaseWithDeps(usecase.TaskDeps{
+			Tasks:      repo,
+			Projects:   projects,
+			Workspaces: workspaces,
+		}, 2*time.Second))
+	}
+
+	t.Run("Get Task By ID", func(t *testing.T) {
+		handler := newHandler(new(repository.MockTaskRepository))
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Params: map[string]string{"id": task.ID.Hex()},
+			Header: map[string]string{HeaderUserID: "member"},
+		})
+
+		if err := handler.GetTaskByID(c); err != nil {
+			t.Error("Error")
+		}
+
+		actual := domain.Task{}
+		assert.NoError(t, c.Body(&actual))
+		assert.Equal(t, 200, c.Res.Code)
+		assert.Equal(t, task.ID, actual.ID)
+	})
+
+	t.Run("Get Task By ID Without User", func(t *testing.T) {
+		handler := newHandler(new(repository.MockTaskRepository))
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Params: map[string]string{"id": task.ID.Hex()},
+		})
+
+		if err := handler.GetTaskByID(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 401, c.Res.Code)
+	})
+
+	t.Run("Update Task", func(t *testing.T) {
+		repo := new(repository.MockTaskRepository)
+		repo.On("Update", mock.Anything, mock.MatchedBy(func(updated *domain.Task) bool {
+			return updated.ID == task.ID && updated.Title == "new title" && updated.Version == 2
+		})).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.Task).Version = 3
+		}).Return(nil).Once()
+
+		handler := newHandler(repo)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Body:   domain.Task{Title: "new title", ProjectID: project.ID},
+			Params: map[string]string{"id": task.ID.Hex()},
+			Header: map[string]string{HeaderUserID: "member", "If-Match": `"2"`},
+		})
+
+		if err := handler.UpdateTask(c); err != nil {
+			t.Error("Error")
+		}
+		assert.Equal(t, 200, c.Res.Code)
+		assert.Equal(t, `"3"`, c.Res.Header().Get("ETag"))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Search Tasks", func(t *testing.T) {
+		repo := new(repository.MockTaskRepository)
+		repo.On("Search", mock.Anything, domain.TaskScope{ProjectIDs: []primitive.ObjectID{project.ID}}, "title", mock.Anything).
+			Return([]domain.TaskMatch{{Task: task, Score: 1}}, int64(1), nil).Once()
+
+		handler := newHandler(repo)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Query:  map[string]string{"q": "title"},
+			Header: map[string]string{HeaderUserID: "member"},
+		})
+
+		if err := handler.SearchTasks(c); err != nil {
+			t.Error("Error")
+		}
+
+		var actual domain.Page[domain.TaskMatch]
+		assert.NoError(t, c.Body(&actual))
+		assert.Equal(t, 200, c.Res.Code)
+		assert.Equal(t, int64(1), actual.Total)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestRequireAdmin(t *testing.T) {
+	tests := []struct {
+		name   string
+		header map[string]string
+		code   int
+	}{
+		{name: "admin", header: map[string]string{HeaderUserID: "user-1", HeaderUserRole: RoleAdmin}, code: 200},
+		{name: "member", header: map[string]string{HeaderUserID: "user-1", HeaderUserRole: "member"}, code: 403},
+		{name: "anonymous", header: map[string]string{HeaderUserRole: RoleAdmin}, code: 401},
+	}
+
+	for _, tt := range tests {
+		called := false
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{Header: tt.header})
+
+		err := RequireAdmin(func(ctx app.IContext) error {
+			called = true
+			return ctx.Response(200, nil)
+		})(c)
+
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.code, c.Res.Code, tt.name)
+		assert.Equal(t, tt.code == 200, called, tt.name)
+	}
 }
 
 type fakeService struct{}
@@ -169,6 +605,38 @@ func (f fakeService) Update(c context.Context, task *domain.Task) error {
 	return nil
 }
 
+func (f fakeService) FetchByFilter(c context.Context, filter domain.TaskFilter) ([]domain.Task, error) {
+	return nil, errors.New("failed to fetch task")
+}
+
+func (f fakeService) Transition(c context.Context, taskID string, status domain.TaskStatus) (domain.Task, error) {
+	return domain.Task{}, nil
+}
+
+func (f fakeService) Search(c context.Context, query string, page domain.PageRequest) (domain.Page[domain.TaskMatch], error) {
+	return domain.Page[domain.TaskMatch]{}, nil
+}
+
+func (f fakeService) Delete(c context.Context, taskID string) error {
+	return nil
+}
+
+func (f fakeService) Restore(c context.Context, taskID string) (domain.Task, error) {
+	return domain.Task{}, nil
+}
+
+func (f fakeService) FetchTree(c context.Context, taskID string) (domain.TaskTree, error) {
+	return domain.TaskTree{}, nil
+}
+
+func (f fakeService) FetchDeleted(c context.Context) ([]domain.Task, error) {
+	return nil, nil
+}
+
+func (f fakeService) PurgeDeleted(c context.Context, retention time.Duration) (int64, error) {
+	return 0, nil
+}
+
 func CreateTaskFail() fakeService {
 	f := fakeService{}
 
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/sing3demons/go-backend-clean-architecture/bootstrap"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+)
+
+// FormFieldImport is the multipart field of an imported file.
+const FormFieldImport = "file"
+
+type TransferHandler struct {
+	TransferService domain.TaskTransferUsecase
+}
+
+func NewTransferHandler(transferService domain.TaskTransferUsecase) *TransferHandler {
+	return &TransferHandler{
+		TransferService: transferService,
+	}
+}
+
+// ExportTasks streams every live task as csv (the default) or ndjson while the tasks are read. The status
+// is sent with the first bytes, an error after that can only cut the response short.
+func (h *TransferHandler) ExportTasks(ctx bootstrap.IContext) error {
+	format := domain.FormatCSV
+	if query := ctx.Query("format"); query != "" {
+		format = domain.TaskFormat(query)
+	}
+	if !format.Valid() {
+		return ctx.Response(400, &domain.ValidationError{Field: "format", Message: "must be csv or ndjson"})
+	}
+
+	// The export writes into the pipe while the response copies from it, closing the reader when the
+	// response ends early makes the export fail and return before the handler does.
+	c := requestContext(ctx)
+	reader, writer := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		writer.CloseWithError(h.TransferService.Export(c, format, writer))
+	}()
+	defer func() {
+		reader.Close()
+		<-done
+	}()
+
+	ctx.SetHeader("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": "tasks." + string(format)}))
+	return ctx.Stream(200, format.ContentType(), reader)
+}
+
+// ImportTasks creates the tasks of an uploaded csv or ndjson file, the format is the format query
+// parameter or else the extension of the file. With dryRun=true the rows are only validated.
+func (h *TransferHandler) ImportTasks(ctx bootstrap.IContext) error {
+	dryRun := false
+	if query := ctx.Query("dryRun"); query != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(query); err != nil {
+			return ctx.Response(400, &domain.ValidationError{Field: "dryRun", Message: "must be true or false"})
+		}
+	}
+
+	header, err := ctx.FormFile(FormFieldImport, domain.MaxImportSize)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return ctx.Response(413, domain.ErrTooLarge.Error())
+	} else if err != nil {
+		return ctx.Response(400, err.Error())
+	}
+
+	format := domain.TaskFormat(ctx.Query("format"))
+	if format == "" {
+		format = importFormat(header.Filename)
+	}
+	if !format.Valid() {
+		return ctx.Response(400, &domain.ValidationError{Field: "format", Message: "must be csv or ndjson"})
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return ctx.Response(500, err.Error())
+	}
+	defer file.Close()
+
+	result, err := h.TransferService.Import(requestContext(ctx), format, file, dryRun)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return ctx.Response(200, result)
+}
+
+// importFormat tells the format of a file from its extension, .jsonl is another name for ndjson.
+func importFormat(filename string) domain.TaskFormat {
+	switch strings.ToLower(path.Ext(filename)) {
+	case ".csv":
+		return domain.FormatCSV
+	case ".ndjson", ".jsonl":
+		return domain.FormatNDJSON
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	bootstrap "github.com/sing3demons/go-backend-clean-architecture/bootstrap/mocks"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTransferHandler(t *testing.T) {
+	t.Run("Export Tasks", func(t *testing.T) {
+		service := new(usecase.MockTaskTransferUsecase)
+		service.On("Export", mock.Anything, domain.FormatNDJSON, mock.Anything).Run(func(args mock.Arguments) {
+			_, err := io.WriteString(args.Get(2).(io.Writer), "{\"title\":\"First\"}\n")
+			assert.NoError(t, err)
+		}).Return(nil).Once()
+
+		handler := NewTransferHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{Query: map[string]string{"format": "ndjson"}})
+
+		assert.NoError(t, handler.ExportTasks(c))
+		assert.Equal(t, 200, c.Res.Code)
+		assert.Equal(t, "application/x-ndjson", c.Res.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename=tasks.ndjson`, c.Res.Header().Get("Content-Disposition"))
+		assert.Equal(t, "{\"title\":\"First\"}\n", c.Res.Body.String())
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Export Tasks Failure", func(t *testing.T) {
+		service := new(usecase.MockTaskTransferUsecase)
+		service.On("Export", mock.Anything, domain.FormatCSV, mock.Anything).Return(errors.New("cursor closed")).Once()
+
+		handler := NewTransferHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{})
+
+		assert.EqualError(t, handler.ExportTasks(c), "cursor closed")
+	})
+
+	t.Run("Export Tasks Unknown Format", func(t *testing.T) {
+		service := new(usecase.MockTaskTransferUsecase)
+		handler := NewTransferHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{Query: map[string]string{"format": "xlsx"}})
+
+		assert.NoError(t, handler.ExportTasks(c))
+		assert.Equal(t, 400, c.Res.Code)
+		service.AssertNotCalled(t, "Export", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Import Tasks", func(t *testing.T) {
+		service := new(usecase.MockTaskTransferUsecase)
+		service.On("Import", mock.Anything, domain.FormatCSV, mock.MatchedBy(func(r io.Reader) bool {
+			content, err := io.ReadAll(r)
+			return err == nil && string(content) == "title\nFirst\n"
+		}), true).Return(domain.ImportResult{DryRun: true, Total: 1, Valid: 1, Errors: []domain.ImportRowError{}}, nil).Once()
+
+		handler := NewTransferHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Query: map[string]string{"dryRun": "true"},
+			Files: []bootstrap.File{{Field: FormFieldImport, Filename: "tasks.CSV", ContentType: "text/csv", Content: []byte("title\nFirst\n")}},
+		})
+
+		assert.NoError(t, handler.ImportTasks(c))
+
+		var actual domain.ImportResult
+		assert.NoError(t, c.Body(&actual))
+		assert.Equal(t, 200, c.Res.Code)
+		assert.True(t, actual.DryRun)
+		assert.Equal(t, 1, actual.Valid)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Import Tasks Invalid File", func(t *testing.T) {
+		service := new(usecase.MockTaskTransferUsecase)
+		service.On("Import", mock.Anything, domain.FormatNDJSON, mock.Anything, false).
+			Return(domain.ImportResult{}, &domain.ValidationError{Field: "file", Message: "is empty"}).Once()
+
+		handler := NewTransferHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Files: []bootstrap.File{{Field: FormFieldImport, Filename: "tasks.jsonl"}},
+		})
+
+		assert.NoError(t, handler.ImportTasks(c))
+		assert.Equal(t, 422, c.Res.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Import Tasks Bad Request", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			option bootstrap.Option
+		}{
+			{name: "dry run", option: bootstrap.Option{
+				Query: map[string]string{"dryRun": "maybe"},
+				Files: []bootstrap.File{{Field: FormFieldImport, Filename: "tasks.csv"}},
+			}},
+			{name: "unknown extension", option: bootstrap.Option{
+				Files: []bootstrap.File{{Field: FormFieldImport, Filename: "tasks.xlsx"}},
+			}},
+			{name: "missing file", option: bootstrap.Option{
+				Files: []bootstrap.File{{Field: "other", Filename: "tasks.csv"}},
+			}},
+		}
+
+		service := new(usecase.MockTaskTransferUsecase)
+		handler := NewTransferHandler(service)
+		for _, tt := range tests {
+			c := bootstrap.NewMockMuxContext(tt.option)
+			assert.NoError(t, handler.ImportTasks(c))
+			assert.Equal(t, 400, c.Res.Code, tt.name)
+		}
+		service.AssertNotCalled(t, "Import", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Import Tasks Too Large", func(t *testing.T) {
+		service := new(usecase.MockTaskTransferUsecase)
+		handler := NewTransferHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Files: []bootstrap.File{{Field: FormFieldImport, Filename: "tasks.csv", Content: []byte(strings.Repeat("a", int(domain.MaxImportSize)+1))}},
+		})
+
+		assert.NoError(t, handler.ImportTasks(c))
+		assert.Equal(t, 413, c.Res.Code)
+	})
+}
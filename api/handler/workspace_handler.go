@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"github.com/sing3demons/go-backend-clean-architecture/bootstrap"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+)
+
+type WorkspaceHandler struct {
+	WorkspaceService domain.WorkspaceUsecase
+}
+
+func NewWorkspaceHandler(workspaceService domain.WorkspaceUsecase) *WorkspaceHandler {
+	return &WorkspaceHandler{
+		WorkspaceService: workspaceService,
+	}
+}
+
+type workspaceRequest struct {
+	Name    string          `json:"name"`
+	Members []domain.Member `json:"members"`
+}
+
+type memberRequest struct {
+	Role domain.WorkspaceRole `json:"role"`
+}
+
+// CreateWorkspace creates a workspace owned by the user of the X-User-ID header.
+func (h *WorkspaceHandler) CreateWorkspace(ctx bootstrap.IContext) error {
+	var input workspaceRequest
+	if err := ctx.ReadInput(&input); err != nil {
+		return ctx.Response(400, err.Error())
+	}
+
+	workspace := domain.Workspace{Name: input.Name, Members: input.Members}
+	if err := h.WorkspaceService.Create(requestContext(ctx), &workspace); err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return ctx.Response(201, workspace)
+}
+
+// GetWorkspaces lists the workspaces of the user of the X-User-ID header.
+func (h *WorkspaceHandler) GetWorkspaces(ctx bootstrap.IContext) error {
+	workspaces, err := h.WorkspaceService.FetchMine(requestContext(ctx))
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return ctx.Response(200, workspaces)
+}
+
+func (h *WorkspaceHandler) GetWorkspaceByID(ctx bootstrap.IContext) error {
+	workspace, err := h.WorkspaceService.FetchByID(requestContext(ctx), ctx.Param("id"))
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return ctx.Response(200, workspace)
+}
+
+// SetMember adds the user of the path to the workspace or changes their role, member by default.
+func (h *WorkspaceHandler) SetMember(ctx bootstrap.IContext) error {
+	var input memberRequest
+	if err := ctx.ReadInput(&input); err != nil {
+		return ctx.Response(400, err.Error())
+	}
+
+	member := domain.Member{UserID: ctx.Param("userId"), Role: input.Role}
+	workspace, err := h.WorkspaceService.SetMember(requestContext(ctx), ctx.Param("id"), member)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return ctx.Response(200, workspace)
+}
+
+func (h *WorkspaceHandler) RemoveMember(ctx bootstrap.IContext) error {
+	workspace, err := h.WorkspaceService.RemoveMember(requestContext(ctx), ctx.Param("id"), ctx.Param("userId"))
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return ctx.Response(200, workspace)
+}
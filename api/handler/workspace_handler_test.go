@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	bootstrap "github.com/sing3demons/go-backend-clean-architecture/bootstrap/mocks"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWorkspaceHandler(t *testing.T) {
+	t.Run("Create Workspace", func(t *testing.T) {
+		service := new(usecase.MockWorkspaceUsecase)
+		service.On("Create", mock.MatchedBy(func(c context.Context) bool {
+			return domain.ActorFromContext(c) == "user-1"
+		}), mock.MatchedBy(func(workspace *domain.Workspace) bool {
+			return workspace.Name == "Team"
+		})).Return(nil).Once()
+
+		handler := NewWorkspaceHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Body:   map[string]string{"name": "Team"},
+			Header: map[string]string{"X-User-ID": "user-1"},
+		})
+
+		assert.NoError(t, handler.CreateWorkspace(c))
+		assert.Equal(t, 201, c.Res.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Set Member", func(t *testing.T) {
+		service := new(usecase.MockWorkspaceUsecase)
+		service.On("SetMember", mock.Anything, "1", domain.Member{UserID: "user-2", Role: domain.RoleOwner}).
+			Return(domain.Workspace{Members: []domain.Member{
+				{UserID: "user-1", Role: domain.RoleOwner},
+				{UserID: "user-2", Role: domain.RoleOwner},
+			}}, nil).Once()
+
+		handler := NewWorkspaceHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Body:   map[string]string{"role": "owner"},
+			Params: map[string]string{"id": "1", "userId": "user-2"},
+			Header: map[string]string{"X-User-ID": "user-1"},
+		})
+
+		assert.NoError(t, handler.SetMember(c))
+
+		actual := domain.Workspace{}
+		assert.NoError(t, c.Body(&actual))
+		assert.Equal(t, 200, c.Res.Code)
+		assert.Len(t, actual.Members, 2)
+	})
+
+	t.Run("Set Member Forbidden", func(t *testing.T) {
+		service := new(usecase.MockWorkspaceUsecase)
+		service.On("SetMember", mock.Anything, "1", mock.Anything).Return(domain.Workspace{}, domain.ErrForbidden).Once()
+
+		handler := NewWorkspaceHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Body:   map[string]string{},
+			Params: map[string]string{"id": "1", "userId": "user-2"},
+			Header: map[string]string{"X-User-ID": "user-3"},
+		})
+
+		assert.NoError(t, handler.SetMember(c))
+		assert.Equal(t, 403, c.Res.Code)
+	})
+
+	t.Run("Remove Last Owner", func(t *testing.T) {
+		service := new(usecase.MockWorkspaceUsecase)
+		service.On("RemoveMember", mock.Anything, "1", "user-1").
+			Return(domain.Workspace{}, &domain.ValidationError{Field: "members", Message: "a workspace needs an owner"}).Once()
+
+		handler := NewWorkspaceHandler(service)
+		c := bootstrap.NewMockMuxContext(bootstrap.Option{
+			Params: map[string]string{"id": "1", "userId": "user-1"},
+			Header: map[string]string{"X-User-ID": "user-1"},
+		})
+
+		assert.NoError(t, handler.RemoveMember(c))
+
+		actual := domain.ValidationError{}
+		assert.NoError(t, c.Body(&actual))
+		assert.Equal(t, 422, c.Res.Code)
+		assert.Equal(t, "members", actual.Field)
+	})
+}
@@ -0,0 +1,32 @@
+package route
+
+import (
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/api/handler"
+	"github.com/sing3demons/go-backend-clean-architecture/bootstrap"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+)
+
+// NewTaskActivityRoute serves the history written by the task, bulk and import routes.
+func NewTaskActivityRoute(db mongo.Database, taskCollection string, router bootstrap.IApplication) {
+	timeout := time.Duration(2) * time.Second
+	activities := repository.NewActivityRepository(db, domain.CollectionTaskActivity)
+	tasks := repository.NewTaskRepository(db, taskCollection)
+	projects := repository.NewProjectRepository(db, domain.CollectionProject)
+	workspaces := repository.NewWorkspaceRepository(db, domain.CollectionWorkspace)
+	service := usecase.NewActivityUsecaseWithDeps(usecase.ActivityDeps{
+		Activities: activities,
+		Tasks:      tasks,
+		Projects:   projects,
+		Workspaces: workspaces,
+	}, timeout)
+	handler := handler.NewActivityHandler(service)
+
+	router.RegisterIndexes(domain.CollectionTaskActivity, repository.ActivityIndexes()...)
+
+	router.Get("/task/{id}/history", handler.GetTaskHistory)
+}
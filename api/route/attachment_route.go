@@ -0,0 +1,36 @@
+package route
+
+import (
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/api/handler"
+	"github.com/sing3demons/go-backend-clean-architecture/bootstrap"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+)
+
+// attachmentTimeout also bounds storing the content of an upload, so it is longer than the other routes.
+const attachmentTimeout = 30 * time.Second
+
+func NewAttachmentRoute(db mongo.Database, taskCollection string, router bootstrap.IApplication) {
+	attachments := repository.NewAttachmentRepository(db, domain.BucketAttachment)
+	tasks := repository.NewTaskRepository(db, taskCollection)
+	projects := repository.NewProjectRepository(db, domain.CollectionProject)
+	workspaces := repository.NewWorkspaceRepository(db, domain.CollectionWorkspace)
+	service := usecase.NewAttachmentUsecaseWithDeps(usecase.AttachmentDeps{
+		Attachments: attachments,
+		Tasks:       tasks,
+		Projects:    projects,
+		Workspaces:  workspaces,
+	}, domain.DefaultAttachmentPolicy, attachmentTimeout)
+	handler := handler.NewAttachmentHandler(service, domain.DefaultAttachmentPolicy)
+
+	router.RegisterIndexes(repository.AttachmentFiles(domain.BucketAttachment), repository.AttachmentIndexes()...)
+
+	router.Get("/task/{id}/attachments", handler.GetAttachments)
+	router.Post("/task/{id}/attachments", handler.UploadAttachment)
+	router.Get("/task/{id}/attachments/{attachmentId}", handler.DownloadAttachment)
+	router.Delete("/task/{id}/attachments/{attachmentId}", handler.DeleteAttachment)
+}
@@ -0,0 +1,38 @@
+package route
+
+import (
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/api/handler"
+	"github.com/sing3demons/go-backend-clean-architecture/bootstrap"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+)
+
+const (
+	// taskBulkMaxOperations caps the operations of one POST /task/bulk, a larger batch responds 413.
+	taskBulkMaxOperations = domain.DefaultMaxBulkOperations
+	// taskBulkTimeout covers the validation reads and the batch write of a full request.
+	taskBulkTimeout = 10 * time.Second
+)
+
+func NewTaskBulkRoute(db mongo.Database, taskCollection string, router bootstrap.IApplication) {
+	repo := repository.NewTaskRepository(db, taskCollection)
+	history := repository.NewActivityRepository(db, domain.CollectionTaskActivity)
+	projects := repository.NewProjectRepository(db, domain.CollectionProject)
+	workspaces := repository.NewWorkspaceRepository(db, domain.CollectionWorkspace)
+	transactions := mongo.NewUnitOfWork(db.Client())
+	service := usecase.NewTaskBulkUsecaseWithDeps(usecase.TaskBulkDeps{
+		Tasks:        repo,
+		Bulk:         repository.NewTaskBulkRepository(db, taskCollection),
+		History:      history,
+		Projects:     projects,
+		Workspaces:   workspaces,
+		Transactions: transactions,
+	}, taskBulkMaxOperations, taskBulkTimeout)
+	handler := handler.NewBulkHandler(service)
+
+	router.Post("/task/bulk", handler.BulkTasks)
+}
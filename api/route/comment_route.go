@@ -0,0 +1,34 @@
+package route
+
+import (
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/api/handler"
+	"github.com/sing3demons/go-backend-clean-architecture/bootstrap"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+)
+
+func NewCommentRoute(db mongo.Database, taskCollection string, router bootstrap.IApplication) {
+	timeout := time.Duration(2) * time.Second
+	comments := repository.NewCommentRepository(db, domain.CollectionComment)
+	tasks := repository.NewTaskRepository(db, taskCollection)
+	projects := repository.NewProjectRepository(db, domain.CollectionProject)
+	workspaces := repository.NewWorkspaceRepository(db, domain.CollectionWorkspace)
+	service := usecase.NewCommentUsecaseWithDeps(usecase.CommentDeps{
+		Comments:   comments,
+		Tasks:      tasks,
+		Publisher:  newKafkaPublisher(router),
+		Projects:   projects,
+		Workspaces: workspaces,
+	}, timeout)
+	handler := handler.NewCommentHandler(service)
+
+	router.RegisterIndexes(domain.CollectionComment, repository.CommentIndexes()...)
+
+	router.Get("/task/{id}/comments", handler.GetComments)
+	router.Post("/task/{id}/comments", handler.CreateComment)
+	router.Delete("/task/{id}/comments/{commentId}", handler.DeleteComment)
+}
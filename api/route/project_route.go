@@ -0,0 +1,45 @@
+package route
+
+import (
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/api/handler"
+	"github.com/sing3demons/go-backend-clean-architecture/bootstrap"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+)
+
+// projectTimeout covers archiving a project, which archives all of its open tasks.
+const projectTimeout = 10 * time.Second
+
+func NewProjectRoute(db mongo.Database, taskCollection string, router bootstrap.IApplication) {
+	projects := repository.NewProjectRepository(db, domain.CollectionProject)
+	workspaces := repository.NewWorkspaceRepository(db, domain.CollectionWorkspace)
+	tasks := repository.NewTaskRepository(db, taskCollection)
+	history := repository.NewActivityRepository(db, domain.CollectionTaskActivity)
+	transactions := mongo.NewUnitOfWork(db.Client())
+	service := usecase.NewProjectUsecaseWithDeps(usecase.ProjectDeps{
+		Projects:     projects,
+		Workspaces:   workspaces,
+		Tasks:        tasks,
+		ProjectTasks: repository.NewTaskProjectRepository(db, taskCollection),
+		Publisher:    newKafkaPublisher(router),
+		History:      history,
+		Transactions: transactions,
+	}, projectTimeout)
+	handler := handler.NewProjectHandler(service)
+
+	router.RegisterIndexes(domain.CollectionProject, repository.ProjectIndexes()...)
+
+	router.Get("/workspace/{id}/projects", handler.GetProjects)
+	router.Get("/project/{id}", handler.GetProjectByID)
+	router.Get("/project/{id}/tasks", handler.GetProjectTasks)
+
+	router.Post("/workspace/{id}/projects", handler.CreateProject)
+	router.Post("/project/{id}/archive", handler.ArchiveProject)
+	router.Post("/project/{id}/unarchive", handler.UnarchiveProject)
+
+	router.Put("/project/{id}", handler.UpdateProject)
+}
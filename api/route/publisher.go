@@ -0,0 +1,22 @@
+package route
+
+import (
+	"context"
+
+	"github.com/sing3demons/go-backend-clean-architecture/bootstrap"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+)
+
+// kafkaPublisher publishes domain events through the application producer.
+type kafkaPublisher struct {
+	app bootstrap.IApplication
+}
+
+func newKafkaPublisher(app bootstrap.IApplication) domain.EventPublisher {
+	return kafkaPublisher{app: app}
+}
+
+func (p kafkaPublisher) Publish(c context.Context, topic, key string, payload any) error {
+	_, err := p.app.SendMessage(topic, payload, bootstrap.WithKey(key))
+	return err
+}
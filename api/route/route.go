@@ -7,5 +7,13 @@ import (
 
 func Setup(db mongo.Database, collection string, router bootstrap.IApplication) bootstrap.IApplication {
 	NewTaskRoute(db, collection, router)
+	NewCommentRoute(db, collection, router)
+	NewAttachmentRoute(db, collection, router)
+	NewTaskStatsRoute(db, collection, router)
+	NewTaskBulkRoute(db, collection, router)
+	NewTaskTransferRoute(db, collection, router)
+	NewTaskActivityRoute(db, collection, router)
+	NewWorkspaceRoute(db, router)
+	NewProjectRoute(db, collection, router)
 	return router
 }
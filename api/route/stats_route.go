@@ -0,0 +1,29 @@
+package route
+
+import (
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/api/handler"
+	"github.com/sing3demons/go-backend-clean-architecture/bootstrap"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+)
+
+// statsTimeout is longer than the other routes, a report aggregates every task of its range.
+const statsTimeout = 10 * time.Second
+
+func NewTaskStatsRoute(db mongo.Database, taskCollection string, router bootstrap.IApplication) {
+	stats := repository.NewTaskStatsRepository(db, taskCollection)
+	projects := repository.NewProjectRepository(db, domain.CollectionProject)
+	workspaces := repository.NewWorkspaceRepository(db, domain.CollectionWorkspace)
+	service := usecase.NewTaskStatsUsecaseWithDeps(usecase.TaskStatsDeps{
+		Stats:      stats,
+		Projects:   projects,
+		Workspaces: workspaces,
+	}, domain.SystemClock{}, statsTimeout)
+	handler := handler.NewStatsHandler(service)
+
+	router.Get("/task/stats", handler.GetTaskStats)
+}
@@ -5,18 +5,83 @@ import (
 
 	"github.com/sing3demons/go-backend-clean-architecture/api/handler"
 	"github.com/sing3demons/go-backend-clean-architecture/bootstrap"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
 	"github.com/sing3demons/go-backend-clean-architecture/mongo"
 	"github.com/sing3demons/go-backend-clean-architecture/repository"
 	"github.com/sing3demons/go-backend-clean-architecture/usecase"
 )
 
+const (
+	// taskRetention is how long soft-deleted tasks stay restorable before they are purged.
+	taskRetention     = 30 * 24 * time.Hour
+	taskPurgeInterval = time.Hour
+
+	// taskReminderWindow is how long before its due date a task is reminded.
+	taskReminderWindow   = 24 * time.Hour
+	taskReminderInterval = 5 * time.Minute
+)
+
 func NewTaskRoute(db mongo.Database, collection string, router bootstrap.IApplication) {
 	timeout := time.Duration(2) * time.Second
 	repo := repository.NewTaskRepository(db, collection)
-	service := usecase.NewTaskUsecase(repo, timeout)
+	history := repository.NewActivityRepository(db, domain.CollectionTaskActivity)
+	projects := repository.NewProjectRepository(db, domain.CollectionProject)
+	workspaces := repository.NewWorkspaceRepository(db, domain.CollectionWorkspace)
+	transactions := mongo.NewUnitOfWork(db.Client())
+	service := usecase.NewTaskUsecaseWithDeps(usecase.TaskDeps{
+		Tasks:        repo,
+		Publisher:    newKafkaPublisher(router),
+		History:      history,
+		Projects:     projects,
+		Workspaces:   workspaces,
+		Transactions: transactions,
+	}, timeout)
+	reminders := handler.NewReminderHandler(usecase.NewTaskReminderUsecase(repository.NewTaskReminderRepository(db, collection), domain.SystemClock{}, taskReminderWindow, timeout))
+	admin := handler.RequireAdmin
 	handler := handler.NewTaskHandler(service)
 
+	router.RegisterIndexes(collection, repository.TaskIndexes()...)
+
 	router.Get("/task", handler.GetTask)
+	router.Get("/task/trash", handler.GetDeletedTasks, admin)
+	router.Get("/task/search", handler.SearchTasks)
+	router.Get("/task/{id}", handler.GetTaskByID)
+	router.Get("/task/{id}/tree", handler.GetTaskTree)
 
 	router.Post("/task", handler.CreateTask)
+	router.Post("/task/{id}/restore", handler.RestoreTask)
+	router.Post("/task/{id}/transitions", handler.TransitionTask)
+
+	router.Put("/task/{id}", handler.UpdateTask)
+
+	router.Delete("/task/{id}", handler.DeleteTask)
+
+	schedulePurge(router, service)
+	scheduleReminders(router, reminders)
+}
+
+// schedulePurge removes expired soft-deleted tasks every taskPurgeInterval, on one replica when
+// the application has a database for the leader lock.
+func schedulePurge(router bootstrap.IApplication, service domain.TaskUsecase) {
+	router.Schedule("@every "+taskPurgeInterval.String(), func(ctx bootstrap.IContext) error {
+		count, err := service.PurgeDeleted(ctx.Context(), taskRetention)
+		if err != nil {
+			return err
+		}
+		ctx.Log().Infof("Purged %d deleted tasks", count)
+		return nil
+	}, bootstrap.ScheduleOptions{
+		Name:       "task-purge",
+		Jitter:     time.Minute,
+		LeaderLock: router.Database() != nil,
+	})
+}
+
+// scheduleReminders publishes the reminders of tasks due within taskReminderWindow.
+func scheduleReminders(router bootstrap.IApplication, reminders *handler.ReminderHandler) {
+	router.Schedule("@every "+taskReminderInterval.String(), reminders.SendReminders, bootstrap.ScheduleOptions{
+		Name:       "task-reminders",
+		Jitter:     10 * time.Second,
+		LeaderLock: router.Database() != nil,
+	})
 }
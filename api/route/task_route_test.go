@@ -2,6 +2,7 @@ package route
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -16,6 +17,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
 )
 
@@ -31,11 +33,34 @@ func (d *DB) collectionName() string {
 	return "task"
 }
 
+// session stands in for a driver session, the routes only run transactions on it.
+type session struct {
+	mongo.Session
+}
+
+func (session) StartTransaction(...*options.TransactionOptions) error {
+	return nil
+}
+
+func (session) AbortTransaction(context.Context) error {
+	return nil
+}
+
+func (session) CommitTransaction(context.Context) error {
+	return nil
+}
+
+func (session) EndSession(context.Context) {}
+
 func (d *DB) DatabaseSuccess() *mocks.Database {
 	database := &mocks.Database{}
 	collectionName := d.collectionName()
 
+	client := &mocks.Client{}
+	client.On("StartSession").Return(session{}, nil).Maybe()
+
 	database.On("Collection", collectionName).Return(d.collection).Once()
+	database.On("Client").Return(client)
 	return database
 }
 
@@ -65,7 +90,11 @@ func (d *DB) Create(document interface{}) *mocks.Database {
 	mockTaskTD := primitive.NewObjectID()
 	d.collection.On("InsertOne", mock.Anything, mock.AnythingOfType(d.GetTypeString(document))).Return(mockTaskTD, nil).Once()
 
-	return d.DatabaseSuccess()
+	database := d.DatabaseSuccess()
+	history := &mocks.Collection{}
+	history.On("InsertMany", mock.Anything, mock.Anything).Return([]interface{}{primitive.NewObjectID()}, nil).Once()
+	database.On("Collection", domain.CollectionTaskActivity).Return(history).Once()
+	return database
 }
 
 type MockContext struct {
@@ -0,0 +1,35 @@
+package route
+
+import (
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/api/handler"
+	"github.com/sing3demons/go-backend-clean-architecture/bootstrap"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+)
+
+// transferTimeout bounds a whole export or import, both go through every task of the request.
+const transferTimeout = 5 * time.Minute
+
+func NewTaskTransferRoute(db mongo.Database, taskCollection string, router bootstrap.IApplication) {
+	repo := repository.NewTaskRepository(db, taskCollection)
+	projects := repository.NewProjectRepository(db, domain.CollectionProject)
+	workspaces := repository.NewWorkspaceRepository(db, domain.CollectionWorkspace)
+	history := repository.NewActivityRepository(db, domain.CollectionTaskActivity)
+	transactions := mongo.NewUnitOfWork(db.Client())
+	service := usecase.NewTaskTransferUsecaseWithDeps(usecase.TaskTransferDeps{
+		Tasks:        repo,
+		Transfers:    repository.NewTaskTransferRepository(db, taskCollection),
+		History:      history,
+		Projects:     projects,
+		Workspaces:   workspaces,
+		Transactions: transactions,
+	}, transferTimeout)
+	handler := handler.NewTransferHandler(service)
+
+	router.Get("/task/export", handler.ExportTasks)
+	router.Post("/task/import", handler.ImportTasks)
+}
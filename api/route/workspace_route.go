@@ -0,0 +1,30 @@
+package route
+
+import (
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/api/handler"
+	"github.com/sing3demons/go-backend-clean-architecture/bootstrap"
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+)
+
+func NewWorkspaceRoute(db mongo.Database, router bootstrap.IApplication) {
+	timeout := time.Duration(2) * time.Second
+	workspaces := repository.NewWorkspaceRepository(db, domain.CollectionWorkspace)
+	service := usecase.NewWorkspaceUsecase(workspaces, timeout)
+	handler := handler.NewWorkspaceHandler(service)
+
+	router.RegisterIndexes(domain.CollectionWorkspace, repository.WorkspaceIndexes()...)
+
+	router.Get("/workspace", handler.GetWorkspaces)
+	router.Get("/workspace/{id}", handler.GetWorkspaceByID)
+
+	router.Post("/workspace", handler.CreateWorkspace)
+
+	router.Put("/workspace/{id}/members/{userId}", handler.SetMember)
+
+	router.Delete("/workspace/{id}/members/{userId}", handler.RemoveMember)
+}
@@ -2,14 +2,17 @@ package bootstrap
 
 import (
 	"context"
-
+	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/IBM/sarama"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
 )
 
 type IApplication interface {
@@ -21,9 +24,18 @@ type IApplication interface {
 
 	Use(middlewares ...Middleware)
 	Start()
+	Stop(ctx context.Context) error
+	OnStart(hook Hook)
+	OnStop(hook Hook)
+	AddHealthCheck(name string, check HealthCheck)
 	ServeHTTP(w http.ResponseWriter, r *http.Request)
 
+	Database() mongo.Database
+	RegisterIndexes(collection string, indexes ...mongo.Index)
+
 	Consume(topic string, handler ServiceHandleFunc)
+	Watch(collection string, pipeline any, handler ServiceHandleFunc)
+	Schedule(spec string, handler ServiceHandleFunc, opts ...ScheduleOptions)
 	SendMessage(topic string, payload any, opts ...OptionProducerMsg) (RecordMetadata, error)
 }
 
@@ -41,6 +53,8 @@ type IRouter interface {
 type AppConfig struct {
 	Port   string
 	Router Router
+	// HealthPath exposes the registered health checks on GET when set, e.g. "/health".
+	HealthPath string
 }
 
 type KafkaConfig struct {
@@ -48,6 +62,14 @@ type KafkaConfig struct {
 	GroupID  string
 	Username string
 	Password string
+	// DeadLetterTopic receives the messages whose handler failed before they are committed. Without it a
+	// failed message is not committed and the claim stops, so the message is consumed again.
+	DeadLetterTopic string
+	// HandlerRetries runs a failed handler again up to HandlerRetries times before the message counts as
+	// failed (default 3), a negative value does not retry.
+	HandlerRetries int
+	// RetryBackoff is the wait before the first retry, it doubles after each retry (default 100ms).
+	RetryBackoff time.Duration
 
 	producer sarama.SyncProducer
 	consumer sarama.ConsumerGroup
@@ -58,11 +80,29 @@ type KafkaProducerOptions struct {
 	ReturnErrors    bool
 }
 
+// ShutdownConfig holds the timeout of each graceful shutdown phase, a zero value uses the default.
+type ShutdownConfig struct {
+	HTTPTimeout     time.Duration
+	ConsumerTimeout time.Duration
+	ProducerTimeout time.Duration
+	WatchTimeout    time.Duration
+	ScheduleTimeout time.Duration
+	HookTimeout     time.Duration
+	MongoTimeout    time.Duration
+}
+
 type Config struct {
-	AppConfig   AppConfig
-	KafkaConfig KafkaConfig
+	AppConfig       AppConfig
+	KafkaConfig     KafkaConfig
+	RecoveryConfig  RecoveryConfig
+	ShutdownConfig  ShutdownConfig
+	MongoConfig     MongoConfig
+	SchedulerConfig SchedulerConfig
 }
 
+// Hook is a user component callback run on application start or stop.
+type Hook func(ctx context.Context) error
+
 // enum Router {gin, mux}
 type Router int
 
@@ -74,15 +114,44 @@ const (
 	Fiber
 )
 
+const (
+	defaultHTTPShutdownTimeout     = 5 * time.Second
+	defaultConsumerShutdownTimeout = 10 * time.Second
+	defaultProducerShutdownTimeout = 5 * time.Second
+	defaultHookTimeout             = 5 * time.Second
+	defaultMongoShutdownTimeout    = 5 * time.Second
+	defaultIndexTimeout            = 30 * time.Second
+	defaultWatchShutdownTimeout    = 10 * time.Second
+	defaultScheduleShutdownTimeout = 10 * time.Second
+)
+
 type Server struct {
 	httpServer *http.Server
 	kafka      *KafkaServer
 	router     IRouter
 	Log        ILogger
+	mongo      mongo.Client
+	database   mongo.Database
+	indexes    *mongo.IndexRegistry
+	watchers   *watchGroup
+	jobs       *scheduleGroup
+	health     *healthRegistry
+	config     *Config
+
+	shutdown       ShutdownConfig
+	onStart        []Hook
+	onStop         []Hook
+	mutex          sync.Mutex
+	consumerCancel context.CancelFunc
+	consumerDone   chan struct{}
+	stopOnce       sync.Once
+	stopped        chan struct{}
+	stopErr        error
 }
 
 func NewApplication(config *Config, logger ILogger) IApplication {
-	kafka := &KafkaServer{}
+	// kafka stays nil without brokers, SendMessage then reports ErrNoProducer
+	var kafka *KafkaServer
 
 	if len(config.KafkaConfig.Brokers) != 0 {
 		producer, err := newProducer(&config.KafkaConfig)
@@ -99,6 +168,7 @@ func NewApplication(config *Config, logger ILogger) IApplication {
 		if err != nil {
 			logger.Fatalf("Failed to create Kafka server: %v", err)
 		}
+		k.Use(recoveryMiddlewares(config)...)
 
 		kafka = k
 	}
@@ -119,26 +189,90 @@ func NewApplication(config *Config, logger ILogger) IApplication {
 		}
 	}
 
-	return &Server{
-		kafka:  kafka,
-		router: router,
-		Log:    logger,
+	server := &Server{
+		kafka:    kafka,
+		router:   router,
+		Log:      logger,
+		indexes:  mongo.NewIndexRegistry(),
+		watchers: &watchGroup{},
+		jobs:     &scheduleGroup{},
+		health:   newHealthRegistry(),
+		config:   config,
+		shutdown: config.ShutdownConfig,
+		stopped:  make(chan struct{}),
 	}
+
+	if config.MongoConfig.URI != "" || config.MongoConfig.client != nil {
+		client, err := newMongoClient(&config.MongoConfig)
+		if err != nil {
+			logger.Fatalf("Failed to create MongoDB client: %v", err)
+		}
+
+		if err := pingMongo(client, &config.MongoConfig, logger); err != nil {
+			if config.MongoConfig.Required {
+				logger.Fatalf("Failed to connect to MongoDB: %v", err)
+			}
+			logger.Errorf("Failed to connect to MongoDB: %v", err)
+		}
+
+		server.mongo = client
+		server.database = client.Database(config.MongoConfig.Database)
+		server.AddHealthCheck("mongo", client.Ping)
+	}
+
+	if router != nil && config.AppConfig.HealthPath != "" {
+		router.Get(config.AppConfig.HealthPath, server.health.handler)
+	}
+
+	return server
+}
+
+func (s *Server) OnStart(hook Hook) {
+	s.onStart = append(s.onStart, hook)
+}
+
+func (s *Server) OnStop(hook Hook) {
+	s.onStop = append(s.onStop, hook)
 }
 
 func (s *Server) Start() {
-	if s.router != nil {
-		s.httpServer = s.router.Register()
+	if s.database != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultIndexTimeout)
+		err := s.indexes.Apply(ctx, s.database)
+		cancel()
+		if err != nil {
+			s.Log.Fatalf("Failed to create MongoDB indexes: %v", err)
+		}
 	}
-	ctx, cancel := context.WithCancel(context.Background())
+
+	for _, hook := range s.onStart {
+		if err := s.runHook(hook); err != nil {
+			s.Log.Fatalf("OnStart hook error: %v", err)
+		}
+	}
+
 	signalChan := make(chan os.Signal, 1)
 	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalChan)
+
+	s.mutex.Lock()
+	select {
+	case <-s.stopped:
+		s.mutex.Unlock()
+		return
+	default:
+	}
+
+	if s.router != nil {
+		s.httpServer = s.router.Register()
+	}
 
 	if s.httpServer != nil {
 		// Start HTTP Server
+		httpServer := s.httpServer
 		go func() {
-			s.Log.Println("Starting HTTP server on " + s.httpServer.Addr)
-			if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			s.Log.Println("Starting HTTP server on " + httpServer.Addr)
+			if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 				s.Log.Fatalf("HTTP Server Error: %v", err)
 			}
 		}()
@@ -146,43 +280,265 @@ func (s *Server) Start() {
 
 	if s.kafka != nil {
 		// Start Kafka Consumer
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		s.consumerCancel = cancel
+		s.consumerDone = done
 		go func() {
+			defer close(done)
 			s.Log.Println("Starting Kafka consumer...")
 			if err := s.kafka.StartConsumer(ctx); err != nil {
 				s.Log.Printf("Kafka consumer error: %v", err)
 			}
 		}()
 	}
+	// Start change streams and scheduled jobs
+	s.watchers.start()
+	s.jobs.start()
+	s.mutex.Unlock()
+
+	// Wait for termination signal or Stop
+	select {
+	case <-signalChan:
+		s.Log.Println("Shutdown signal received")
+		s.Stop(context.Background())
+	case <-s.stopped:
+	}
+}
+
+// Stop shuts the application down in order: HTTP, Kafka consumer, change streams, scheduled jobs,
+// Kafka producer, OnStop hooks, then MongoDB.
+// It is safe to call more than once; later calls wait for and return the result of the first.
+func (s *Server) Stop(ctx context.Context) error {
+	s.stopOnce.Do(func() {
+		s.stopErr = s.gracefulShutdown(ctx)
+		close(s.stopped)
+	})
+	<-s.stopped
+	return s.stopErr
+}
+
+func (s *Server) gracefulShutdown(ctx context.Context) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var errs []error
+
+	// Stop accepting HTTP requests and drain in-flight ones
+	if s.httpServer != nil {
+		httpCtx, cancel := context.WithTimeout(ctx, timeoutOrDefault(s.shutdown.HTTPTimeout, defaultHTTPShutdownTimeout))
+		if err := s.httpServer.Shutdown(httpCtx); err != nil {
+			s.Log.Printf("HTTP Server Shutdown Error: %v", err)
+			errs = append(errs, fmt.Errorf("http shutdown: %w", err))
+		} else {
+			s.Log.Println("HTTP server shutdown complete")
+		}
+		cancel()
+	}
+
+	if s.kafka != nil {
+		// Finish in-flight Kafka messages and commit offsets
+		if s.consumerCancel != nil {
+			s.consumerCancel()
+			if err := wait(ctx, s.consumerDone, timeoutOrDefault(s.shutdown.ConsumerTimeout, defaultConsumerShutdownTimeout)); err != nil {
+				s.Log.Printf("Kafka consumer drain error: %v", err)
+				errs = append(errs, fmt.Errorf("kafka consumer shutdown: %w", err))
+			}
+		}
+		s.kafka.closeConsumer()
+	}
 
-	// Wait for termination signal
-	<-signalChan
-	s.Log.Println("Shutdown signal received")
+	// Finish in-flight change stream events
+	if err := s.watchers.stop(ctx, timeoutOrDefault(s.shutdown.WatchTimeout, defaultWatchShutdownTimeout)); err != nil {
+		s.Log.Printf("Change stream shutdown error: %v", err)
+		errs = append(errs, fmt.Errorf("change stream shutdown: %w", err))
+	}
 
-	// Gracefully shutdown Kafka
-	cancel()
+	// Finish running scheduled jobs, they may still send messages
+	if err := s.jobs.stop(ctx, timeoutOrDefault(s.shutdown.ScheduleTimeout, defaultScheduleShutdownTimeout)); err != nil {
+		s.Log.Printf("Scheduler shutdown error: %v", err)
+		errs = append(errs, fmt.Errorf("scheduler shutdown: %w", err))
+	}
 
 	if s.kafka != nil {
-		s.kafka.Shutdown()
+		// Flush the producer
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			s.kafka.closeProducer()
+		}()
+		if err := wait(ctx, done, timeoutOrDefault(s.shutdown.ProducerTimeout, defaultProducerShutdownTimeout)); err != nil {
+			s.Log.Printf("Kafka producer flush error: %v", err)
+			errs = append(errs, fmt.Errorf("kafka producer shutdown: %w", err))
+		}
 	}
 
-	// Gracefully shutdown HTTP server
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer shutdownCancel()
+	// Stop user components in reverse registration order
+	for i := len(s.onStop) - 1; i >= 0; i-- {
+		hookCtx, cancel := context.WithTimeout(ctx, timeoutOrDefault(s.shutdown.HookTimeout, defaultHookTimeout))
+		if err := s.onStop[i](hookCtx); err != nil {
+			s.Log.Printf("OnStop hook error: %v", err)
+			errs = append(errs, fmt.Errorf("stop hook: %w", err))
+		}
+		cancel()
+	}
 
-	if err := s.httpServer.Shutdown(shutdownCtx); err != nil {
-		s.Log.Printf("HTTP Server Shutdown Error: %v", err)
-	} else {
-		s.Log.Println("HTTP server shutdown complete")
+	// Disconnect datastores last, OnStop hooks may still need them
+	if s.mongo != nil {
+		mongoCtx, cancel := context.WithTimeout(ctx, timeoutOrDefault(s.shutdown.MongoTimeout, defaultMongoShutdownTimeout))
+		if err := s.mongo.Disconnect(mongoCtx); err != nil {
+			s.Log.Printf("MongoDB disconnect error: %v", err)
+			errs = append(errs, fmt.Errorf("mongo disconnect: %w", err))
+		} else {
+			s.Log.Println("MongoDB disconnected")
+		}
+		cancel()
 	}
 
 	s.Log.Println("Application exited cleanly")
+	return errors.Join(errs...)
+}
+
+func (s *Server) AddHealthCheck(name string, check HealthCheck) {
+	s.health.add(name, check)
+}
+
+func (s *Server) Database() mongo.Database {
+	return s.database
+}
+
+// RegisterIndexes declares indexes created on the configured database when the application starts.
+func (s *Server) RegisterIndexes(collection string, indexes ...mongo.Index) {
+	s.indexes.Register(collection, indexes...)
+}
+
+func (s *Server) runHook(hook Hook) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeoutOrDefault(s.shutdown.HookTimeout, defaultHookTimeout))
+	defer cancel()
+	return hook(ctx)
+}
+
+func timeoutOrDefault(timeout, def time.Duration) time.Duration {
+	if timeout > 0 {
+		return timeout
+	}
+	return def
+}
+
+func wait(ctx context.Context, done <-chan struct{}, timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return nil
+	case <-timer.C:
+		return context.DeadlineExceeded
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 func (s *Server) Consume(topic string, handler ServiceHandleFunc) {
+	if s.kafka == nil {
+		s.Log.Fatalf("Consume %s requires KafkaConfig", topic)
+		return
+	}
 	s.kafka.Consume(topic, handler)
 }
 
+// producer returns the Kafka producer handed to handler contexts, nil without KafkaConfig.
+func (s *Server) producer() sarama.SyncProducer {
+	if s.kafka == nil {
+		return nil
+	}
+	return s.kafka.producer
+}
+
+// Watch runs handler for every change on collection matching pipeline while the application runs.
+// The resume token of the last handled event is persisted so a restart does not miss events. An event
+// whose handler fails is delivered again when the stream restarts.
+func (s *Server) Watch(collection string, pipeline any, handler ServiceHandleFunc) {
+	if s.database == nil {
+		s.Log.Fatalf("Watch %s requires MongoConfig", collection)
+		return
+	}
+
+	if pipeline == nil {
+		pipeline = []any{}
+	}
+
+	tokens := s.config.MongoConfig.ResumeTokenCollection
+	if tokens == "" {
+		tokens = DefaultResumeTokenCollection
+	}
+
+	name := collection
+	if n := s.watchers.count(collection); n > 0 {
+		name = fmt.Sprintf("%s#%d", collection, n+1)
+	}
+
+	s.watchers.add(&changeStreamWatcher{
+		name:        name,
+		collection:  collection,
+		pipeline:    pipeline,
+		handler:     handler,
+		middlewares: recoveryMiddlewares(s.config),
+		database:    s.database,
+		tokens:      tokens,
+		producer:    s.producer(),
+		log:         s.Log,
+		retryDelay:  defaultWatchRetryDelay,
+	})
+}
+
+// Schedule runs handler on a cron expression ("*/5 * * * *", "@daily") or an interval ("@every 30s", "1h")
+// while the application runs. An activation is skipped while the previous one is still running.
+func (s *Server) Schedule(spec string, handler ServiceHandleFunc, opts ...ScheduleOptions) {
+	var opt ScheduleOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Name == "" {
+		opt.Name = spec
+	}
+
+	sched, err := parseSchedule(spec, s.config.SchedulerConfig.Location)
+	if err != nil {
+		s.Log.Fatalf("Schedule %s: %v", opt.Name, err)
+		return
+	}
+
+	var lock *leaderLock
+	if opt.LeaderLock {
+		if s.database == nil {
+			s.Log.Fatalf("Schedule %s with LeaderLock requires MongoConfig", opt.Name)
+			return
+		}
+
+		collection := s.config.SchedulerConfig.LockCollection
+		if collection == "" {
+			collection = DefaultSchedulerLockCollection
+		}
+		lock = newLeaderLock(s.database, collection)
+	}
+
+	s.jobs.add(&scheduledJob{
+		name:        opt.Name,
+		schedule:    sched,
+		handler:     handler,
+		middlewares: recoveryMiddlewares(s.config),
+		jitter:      opt.Jitter,
+		lock:        lock,
+		producer:    s.producer(),
+		log:         s.Log,
+	})
+}
+
 func (s *Server) SendMessage(topic string, payload any, opts ...OptionProducerMsg) (RecordMetadata, error) {
+	if s.kafka == nil {
+		return RecordMetadata{}, ErrNoProducer
+	}
 	return producer(s.kafka.producer, topic, payload, opts...)
 }
 
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/IBM/sarama/mocks"
+	mongomocks "github.com/sing3demons/go-backend-clean-architecture/mongo/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
@@ -370,3 +371,180 @@ func TestServerStart(t *testing.T) {
 
 	t.Log("Server shut down successfully")
 }
+
+func TestServerStop(t *testing.T) {
+	mockConsumer := &MockConsumerGroup{}
+
+	server := NewApplication(&Config{
+		AppConfig: AppConfig{
+			Port: "0",
+		},
+		KafkaConfig: KafkaConfig{
+			Brokers:  []string{"localhost:9092"},
+			producer: mocks.NewSyncProducer(t, nil),
+			consumer: mockConsumer,
+		},
+		ShutdownConfig: ShutdownConfig{
+			HTTPTimeout: time.Second,
+		},
+	}, NewZapLogger(zap.NewNop()))
+
+	var events []string
+	started := make(chan struct{})
+
+	server.OnStart(func(ctx context.Context) error {
+		events = append(events, "start")
+		close(started)
+		return nil
+	})
+	server.OnStop(func(ctx context.Context) error {
+		events = append(events, "stop-1")
+		return nil
+	})
+	server.OnStop(func(ctx context.Context) error {
+		events = append(events, "stop-2")
+		return nil
+	})
+
+	exited := make(chan struct{})
+	go func() {
+		server.Start()
+		close(exited)
+	}()
+
+	<-started
+	err := server.Stop(context.Background())
+	assert.NoError(t, err)
+
+	select {
+	case <-exited:
+	case <-time.After(3 * time.Second):
+		t.Fatal("Start did not return after Stop")
+	}
+
+	assert.Equal(t, []string{"start", "stop-2", "stop-1"}, events)
+
+	// Stop is idempotent
+	assert.NoError(t, server.Stop(context.Background()))
+}
+
+func TestServerStopWithoutRouter(t *testing.T) {
+	server := NewApplication(&Config{}, NewZapLogger(zap.NewNop()))
+
+	assert.NotPanics(t, func() {
+		err := server.Stop(context.Background())
+		assert.NoError(t, err)
+	})
+}
+
+func TestApplicationWithoutKafka(t *testing.T) {
+	server := NewApplication(&Config{}, NewZapLogger(zap.NewNop()))
+
+	_, err := server.SendMessage(topic, "hello")
+	assert.ErrorIs(t, err, ErrNoProducer)
+}
+
+func TestServerStopHookError(t *testing.T) {
+	server := NewApplication(&Config{}, NewZapLogger(zap.NewNop()))
+
+	server.OnStop(func(ctx context.Context) error {
+		return assert.AnError
+	})
+
+	err := server.Stop(context.Background())
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestApplicationMongo(t *testing.T) {
+	client := &mongomocks.Client{}
+	database := &mongomocks.Database{}
+	client.On("Ping", mock.Anything).Return(nil)
+	client.On("Database", "test").Return(database).Once()
+	client.On("Disconnect", mock.Anything).Return(nil).Once()
+
+	app := NewApplication(&Config{
+		AppConfig: AppConfig{
+			Port:       "3000",
+			HealthPath: "/health",
+		},
+		MongoConfig: MongoConfig{
+			Database: "test",
+			client:   client,
+		},
+	}, NewZapLogger(zap.NewNop()))
+
+	assert.Equal(t, database, app.Database())
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"UP","checks":{"mongo":"UP"}}`, rec.Body.String())
+
+	err := app.Stop(context.Background())
+	assert.NoError(t, err)
+
+	client.AssertExpectations(t)
+}
+
+func TestApplicationMongoPingRetry(t *testing.T) {
+	client := &mongomocks.Client{}
+	database := &mongomocks.Database{}
+	client.On("Ping", mock.Anything).Return(assert.AnError).Times(2)
+	client.On("Ping", mock.Anything).Return(nil).Once()
+	client.On("Database", "test").Return(database).Once()
+
+	app := NewApplication(&Config{
+		MongoConfig: MongoConfig{
+			Database:       "test",
+			ConnectRetries: 3,
+			RetryBackoff:   time.Millisecond,
+			Required:       true,
+			client:         client,
+		},
+	}, NewZapLogger(zap.NewNop()))
+
+	assert.Equal(t, database, app.Database())
+	client.AssertExpectations(t)
+}
+
+func TestApplicationHealthDown(t *testing.T) {
+	app := NewApplication(&Config{
+		AppConfig: AppConfig{
+			Port:       "3000",
+			Router:     Gin,
+			HealthPath: "/health",
+		},
+	}, NewZapLogger(zap.NewNop()))
+
+	app.AddHealthCheck("cache", func(ctx context.Context) error {
+		return assert.AnError
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"DOWN"`)
+}
+
+func TestMongoClientOptions(t *testing.T) {
+	cfg := &MongoConfig{
+		URI:            "mongodb://localhost:27017",
+		MaxPoolSize:    20,
+		ReadPreference: "secondaryPreferred",
+		WriteConcern:   "majority",
+	}
+
+	opts, err := cfg.clientOptions()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(20), *opts.MaxPoolSize)
+	assert.Equal(t, "majority", opts.WriteConcern.W)
+	assert.Equal(t, "secondaryPreferred", opts.ReadPreference.Mode().String())
+
+	cfg.ReadPreference = "invalid"
+	_, err = cfg.clientOptions()
+	assert.Error(t, err)
+}
@@ -3,13 +3,19 @@ package bootstrap
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"reflect"
 	"time"
 
 	"github.com/IBM/sarama"
 )
 
+// errNoMultipart is returned by FormFile outside of http requests.
+var errNoMultipart = errors.New("multipart input is only available to http requests")
+
 type kafkaContext struct {
 	topic    string
 	headers  map[string]string
@@ -28,6 +34,15 @@ type OptionProducerMsg struct {
 	Partition int32
 }
 
+// WithKey sets the message key, messages with the same key go to the same partition in order.
+func WithKey(key string) OptionProducerMsg {
+	return OptionProducerMsg{key: key}
+}
+
+func WithHeaders(headers map[string]string) OptionProducerMsg {
+	return OptionProducerMsg{headers: []map[string]string{headers}}
+}
+
 func newConsumer(option *KafkaConfig) (sarama.ConsumerGroup, error) {
 	if option.consumer != nil {
 		return option.consumer, nil
@@ -126,10 +141,18 @@ func (ctx *kafkaContext) ReadInput(data any) error {
 	}
 }
 
+func (ctx *kafkaContext) FormFile(name string, maxSize int64) (*multipart.FileHeader, error) {
+	return nil, errNoMultipart
+}
+
 func (ctx *kafkaContext) Response(code int, data any) error {
 	return nil
 }
 
+func (ctx *kafkaContext) Stream(code int, contentType string, body io.Reader) error {
+	return nil
+}
+
 func (ctx *kafkaContext) SendMessage(topic string, payload any, opts ...OptionProducerMsg) (RecordMetadata, error) {
 	return producer(ctx.producer, topic, payload, opts...)
 }
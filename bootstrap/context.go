@@ -1,6 +1,10 @@
 package bootstrap
 
-import "context"
+import (
+	"context"
+	"io"
+	"mime/multipart"
+)
 
 type IContext interface {
 	Context() context.Context
@@ -11,7 +15,11 @@ type IContext interface {
 	Param(name string) string
 	Query(name string) string
 	ReadInput(data any) error
+	// FormFile reads a multipart upload, files over maxSize bytes fail with *http.MaxBytesError.
+	FormFile(name string, maxSize int64) (*multipart.FileHeader, error)
 	Response(code int, data any) error
+	// Stream writes body as the response without encoding it.
+	Stream(code int, contentType string, body io.Reader) error
 
 	SendMessage(topic string, payload any, opts ...OptionProducerMsg) (RecordMetadata, error)
 }
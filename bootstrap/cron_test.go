@@ -0,0 +1,90 @@
+package bootstrap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScheduleErrors(t *testing.T) {
+	for _, spec := range []string{"", "* * *", "61 * * * *", "* 24 * * *", "*/0 * * * *", "5-1 * * * *", "@every -1s", "@every soon"} {
+		t.Run(spec, func(t *testing.T) {
+			_, err := parseSchedule(spec, nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestIntervalSchedule(t *testing.T) {
+	now := time.Date(2025, 2, 1, 10, 0, 0, 0, time.UTC)
+
+	for _, spec := range []string{"@every 90s", "90s", "1m30s"} {
+		s, err := parseSchedule(spec, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, now.Add(90*time.Second), s.Next(now), spec)
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	tests := []struct {
+		spec     string
+		from     time.Time
+		expected time.Time
+	}{
+		{"*/15 * * * *", time.Date(2025, 2, 3, 10, 7, 30, 0, time.UTC), time.Date(2025, 2, 3, 10, 15, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, 2, 3, 10, 15, 0, 0, time.UTC), time.Date(2025, 2, 3, 10, 30, 0, 0, time.UTC)},
+		// Friday to Monday
+		{"0 9 * * 1-5", time.Date(2025, 2, 7, 10, 0, 0, 0, time.UTC), time.Date(2025, 2, 10, 9, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2025, 2, 28, 23, 59, 0, 0, time.UTC), time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2025, 12, 15, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		// Sunday as 7
+		{"0 0 * * 7", time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 9, 0, 0, 0, 0, time.UTC)},
+		// Day of month or Friday when both are restricted
+		{"0 0 13 * 5", time.Date(2025, 2, 8, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 13, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0,30 8-9 * * *", time.Date(2025, 2, 3, 8, 30, 0, 0, time.UTC), time.Date(2025, 2, 3, 9, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			s, err := parseSchedule(tt.spec, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, s.Next(tt.from))
+		})
+	}
+}
+
+func TestCronScheduleDST(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+
+	t.Run("spring forward skips the missing time", func(t *testing.T) {
+		s, err := parseSchedule("30 2 * * *", newYork)
+		assert.NoError(t, err)
+
+		next := s.Next(time.Date(2025, 3, 8, 3, 0, 0, 0, newYork))
+		assert.Equal(t, time.Date(2025, 3, 10, 2, 30, 0, 0, newYork), next)
+	})
+
+	t.Run("fall back runs the repeated time once", func(t *testing.T) {
+		s, err := parseSchedule("30 1 * * *", newYork)
+		assert.NoError(t, err)
+
+		first := s.Next(time.Date(2025, 11, 2, 0, 0, 0, 0, newYork))
+		assert.Equal(t, "2025-11-02T01:30:00-04:00", first.Format(time.RFC3339))
+
+		second := s.Next(first)
+		assert.Equal(t, "2025-11-03T01:30:00-05:00", second.Format(time.RFC3339))
+	})
+
+	t.Run("hourly across fall back", func(t *testing.T) {
+		s, err := parseSchedule("0 * * * *", newYork)
+		assert.NoError(t, err)
+
+		next := s.Next(time.Date(2025, 11, 2, 1, 30, 0, 0, time.FixedZone("EDT", -4*3600)))
+		assert.Equal(t, "2025-11-02T02:00:00-05:00", next.Format(time.RFC3339))
+	})
+}
@@ -2,6 +2,8 @@ package bootstrap
 
 import (
 	"context"
+	"io"
+	"mime/multipart"
 
 	"github.com/labstack/echo/v4"
 )
@@ -48,7 +50,18 @@ func (c *EchoContext) ReadInput(data any) error {
 	return c.ctx.Bind(&data)
 }
 
+func (c *EchoContext) FormFile(name string, maxSize int64) (*multipart.FileHeader, error) {
+	return formFile(c.ctx.Response(), c.ctx.Request(), name, maxSize)
+}
+
+func (c *EchoContext) Stream(code int, contentType string, body io.Reader) error {
+	return streamResponse(c.ctx.Response(), code, contentType, body)
+}
+
 func (c *EchoContext) Response(code int, data any) error {
+	if !bodyAllowed(code) {
+		return c.ctx.NoContent(code)
+	}
 	return c.ctx.JSON(code, data)
 }
 
@@ -2,6 +2,8 @@ package bootstrap
 
 import (
 	"context"
+	"io"
+	"mime/multipart"
 
 	"github.com/gin-gonic/gin"
 )
@@ -47,6 +49,14 @@ func (c *GinContext) ReadInput(data any) error {
 	return c.ctx.BindJSON(data)
 }
 
+func (c *GinContext) FormFile(name string, maxSize int64) (*multipart.FileHeader, error) {
+	return formFile(c.ctx.Writer, c.ctx.Request, name, maxSize)
+}
+
+func (c *GinContext) Stream(code int, contentType string, body io.Reader) error {
+	return streamResponse(c.ctx.Writer, code, contentType, body)
+}
+
 func (c *GinContext) Response(responseCode int, responseData any) error {
 	c.ctx.JSON(responseCode, responseData)
 	return nil
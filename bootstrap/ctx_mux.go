@@ -3,6 +3,8 @@ package bootstrap
 import (
 	"context"
 	"encoding/json"
+	"io"
+	"mime/multipart"
 	"net/http"
 )
 
@@ -61,10 +63,21 @@ func (c *HttpContext) ReadInput(data any) error {
 	return json.NewDecoder(c.r.Body).Decode(data)
 }
 
+func (c *HttpContext) FormFile(name string, maxSize int64) (*multipart.FileHeader, error) {
+	return formFile(c.w, c.r, name, maxSize)
+}
+
+func (c *HttpContext) Stream(code int, contentType string, body io.Reader) error {
+	return streamResponse(c.w, code, contentType, body)
+}
+
 func (c *HttpContext) Response(responseCode int, responseData any) error {
 	c.w.Header().Set("Content-type", "application/json; charset=UTF8")
 
 	c.w.WriteHeader(responseCode)
+	if !bodyAllowed(responseCode) {
+		return nil
+	}
 
 	return json.NewEncoder(c.w).Encode(responseData)
 }
@@ -0,0 +1,95 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+var errNoScheduleInput = errors.New("scheduled jobs have no input")
+
+// scheduleContext runs one activation of a scheduled job, Param("job") and Param("scheduledAt")
+// describe the activation.
+type scheduleContext struct {
+	job         string
+	scheduledAt time.Time
+	headers     map[string]string
+	producer    sarama.SyncProducer
+	Logger      ILogger
+	ctx         context.Context
+}
+
+func newScheduleContext(ctx context.Context, job string, scheduledAt time.Time, producer sarama.SyncProducer, log ILogger) IContext {
+	return &scheduleContext{
+		job:         job,
+		scheduledAt: scheduledAt,
+		producer:    producer,
+		Logger:      log,
+		ctx:         InitSession(ctx, log),
+	}
+}
+
+func (c *scheduleContext) Context() context.Context {
+	return c.ctx
+}
+
+func (c *scheduleContext) Log() ILogger {
+	switch logger := c.Context().Value(key).(type) {
+	case ILogger:
+		return logger
+	default:
+		return c.Logger
+	}
+}
+
+func (c *scheduleContext) Param(name string) string {
+	switch name {
+	case "job":
+		return c.job
+	case "scheduledAt":
+		return c.scheduledAt.Format(time.RFC3339)
+	}
+	return ""
+}
+
+func (c *scheduleContext) Query(name string) string {
+	return ""
+}
+
+func (c *scheduleContext) SetHeader(key, value string) {
+	if c.headers == nil {
+		c.headers = make(map[string]string)
+	}
+	c.headers[key] = value
+}
+
+func (c *scheduleContext) GetHeader(key string) string {
+	if c.headers == nil {
+		return ""
+	}
+	return c.headers[key]
+}
+
+func (c *scheduleContext) ReadInput(data any) error {
+	return errNoScheduleInput
+}
+
+func (c *scheduleContext) FormFile(name string, maxSize int64) (*multipart.FileHeader, error) {
+	return nil, errNoScheduleInput
+}
+
+func (c *scheduleContext) Response(code int, data any) error {
+	return nil
+}
+
+func (c *scheduleContext) Stream(code int, contentType string, body io.Reader) error {
+	return nil
+}
+
+func (c *scheduleContext) SendMessage(topic string, payload any, opts ...OptionProducerMsg) (RecordMetadata, error) {
+	return producer(c.producer, topic, payload, opts...)
+}
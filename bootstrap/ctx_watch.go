@@ -0,0 +1,98 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+
+	"github.com/IBM/sarama"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// watchContext delivers one change stream event, ReadInput decodes the raw change event document.
+type watchContext struct {
+	collection    string
+	operationType string
+	headers       map[string]string
+	event         bson.Raw
+	producer      sarama.SyncProducer
+	Logger        ILogger
+	ctx           context.Context
+}
+
+func newWatchContext(ctx context.Context, collection string, event bson.Raw, producer sarama.SyncProducer, log ILogger) IContext {
+	operationType, _ := event.Lookup("operationType").StringValueOK()
+	return &watchContext{
+		collection:    collection,
+		operationType: operationType,
+		event:         event,
+		producer:      producer,
+		Logger:        log,
+		ctx:           InitSession(ctx, log),
+	}
+}
+
+func (c *watchContext) Context() context.Context {
+	return c.ctx
+}
+
+func (c *watchContext) Log() ILogger {
+	switch logger := c.Context().Value(key).(type) {
+	case ILogger:
+		return logger
+	default:
+		return c.Logger
+	}
+}
+
+func (c *watchContext) Param(name string) string {
+	switch name {
+	case "collection":
+		return c.collection
+	case "operationType":
+		return c.operationType
+	}
+	return ""
+}
+
+func (c *watchContext) Query(name string) string {
+	return ""
+}
+
+func (c *watchContext) SetHeader(key, value string) {
+	if c.headers == nil {
+		c.headers = make(map[string]string)
+	}
+	c.headers[key] = value
+}
+
+func (c *watchContext) GetHeader(key string) string {
+	if c.headers == nil {
+		return ""
+	}
+	return c.headers[key]
+}
+
+func (c *watchContext) ReadInput(data any) error {
+	if err := bson.Unmarshal(c.event, data); err != nil {
+		return fmt.Errorf("%s, collection: %s", err.Error(), c.collection)
+	}
+	return nil
+}
+
+func (c *watchContext) FormFile(name string, maxSize int64) (*multipart.FileHeader, error) {
+	return nil, errNoMultipart
+}
+
+func (c *watchContext) Response(code int, data any) error {
+	return nil
+}
+
+func (c *watchContext) Stream(code int, contentType string, body io.Reader) error {
+	return nil
+}
+
+func (c *watchContext) SendMessage(topic string, payload any, opts ...OptionProducerMsg) (RecordMetadata, error) {
+	return producer(c.producer, topic, payload, opts...)
+}
@@ -0,0 +1,74 @@
+package bootstrap
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	HeaderETag        = "ETag"
+	HeaderIfMatch     = "If-Match"
+	HeaderIfNoneMatch = "If-None-Match"
+)
+
+// ETag formats a strong entity tag from a resource version.
+func ETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// ParseETag returns the version of an entity tag built by ETag, weak tags are accepted.
+func ParseETag(etag string) (int64, bool) {
+	unquoted, err := strconv.Unquote(strings.TrimPrefix(strings.TrimSpace(etag), "W/"))
+	if err != nil {
+		return 0, false
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	return version, err == nil
+}
+
+func SetETag(ctx IContext, etag string) {
+	ctx.SetHeader(HeaderETag, etag)
+}
+
+// CheckPreconditions evaluates If-Match and If-None-Match against the current entity tag of the
+// resource. It returns 0 when the request may proceed, otherwise the status to respond with:
+// 304 for a read whose If-None-Match matches, 412 for any other failed precondition.
+func CheckPreconditions(ctx IContext, etag string, read bool) int {
+	if ifMatch := ctx.GetHeader(HeaderIfMatch); ifMatch != "" && !matchETag(ifMatch, etag, false) {
+		return http.StatusPreconditionFailed
+	}
+
+	if ifNoneMatch := ctx.GetHeader(HeaderIfNoneMatch); ifNoneMatch != "" && matchETag(ifNoneMatch, etag, true) {
+		if read {
+			return http.StatusNotModified
+		}
+		return http.StatusPreconditionFailed
+	}
+
+	return 0
+}
+
+// matchETag reports whether the comma separated header lists etag or "*".
+// If-Match uses the strong comparison and If-None-Match the weak one.
+func matchETag(header, etag string, weak bool) bool {
+	if weak {
+		etag = strings.TrimPrefix(etag, "W/")
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+		if weak {
+			tag = strings.TrimPrefix(tag, "W/")
+		} else if strings.HasPrefix(tag, "W/") {
+			continue
+		}
+		if tag == etag {
+			return true
+		}
+	}
+	return false
+}
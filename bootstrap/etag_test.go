@@ -0,0 +1,55 @@
+package bootstrap
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func newETagContext(headers map[string]string) IContext {
+	req := httptest.NewRequest(http.MethodGet, "/task/1", nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	return newMuxContext(httptest.NewRecorder(), req, &KafkaConfig{}, NewZapLogger(zap.NewNop()))
+}
+
+func TestETag(t *testing.T) {
+	assert.Equal(t, `"3"`, ETag(3))
+
+	version, ok := ParseETag(`W/"3"`)
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), version)
+
+	_, ok = ParseETag("3")
+	assert.False(t, ok)
+}
+
+func TestCheckPreconditions(t *testing.T) {
+	etag := ETag(2)
+
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		read     bool
+		expected int
+	}{
+		{name: "no headers", read: true, expected: 0},
+		{name: "if-match matches", headers: map[string]string{HeaderIfMatch: `"1", "2"`}, expected: 0},
+		{name: "if-match stale", headers: map[string]string{HeaderIfMatch: `"1"`}, expected: http.StatusPreconditionFailed},
+		{name: "if-match weak", headers: map[string]string{HeaderIfMatch: `W/"2"`}, expected: http.StatusPreconditionFailed},
+		{name: "if-match any", headers: map[string]string{HeaderIfMatch: "*"}, expected: 0},
+		{name: "if-none-match read", headers: map[string]string{HeaderIfNoneMatch: `W/"2"`}, read: true, expected: http.StatusNotModified},
+		{name: "if-none-match write", headers: map[string]string{HeaderIfNoneMatch: "*"}, expected: http.StatusPreconditionFailed},
+		{name: "if-none-match changed", headers: map[string]string{HeaderIfNoneMatch: `"1"`}, read: true, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CheckPreconditions(newETagContext(tt.headers), etag, tt.read))
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package bootstrap
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	HealthStatusUp   = "UP"
+	HealthStatusDown = "DOWN"
+
+	defaultHealthCheckTimeout = 3 * time.Second
+)
+
+type HealthCheck func(ctx context.Context) error
+
+type HealthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+type healthRegistry struct {
+	mutex  sync.RWMutex
+	checks map[string]HealthCheck
+}
+
+func newHealthRegistry() *healthRegistry {
+	return &healthRegistry{checks: make(map[string]HealthCheck)}
+}
+
+func (h *healthRegistry) add(name string, check HealthCheck) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.checks[name] = check
+}
+
+func (h *healthRegistry) check(ctx context.Context) HealthResponse {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	res := HealthResponse{Status: HealthStatusUp, Checks: make(map[string]string, len(h.checks))}
+	for name, check := range h.checks {
+		if err := check(ctx); err != nil {
+			res.Status = HealthStatusDown
+			res.Checks[name] = HealthStatusDown + ": " + err.Error()
+			continue
+		}
+		res.Checks[name] = HealthStatusUp
+	}
+	return res
+}
+
+func (h *healthRegistry) handler(ctx IContext) error {
+	c, cancel := context.WithTimeout(ctx.Context(), defaultHealthCheckTimeout)
+	defer cancel()
+
+	res := h.check(c)
+	if res.Status != HealthStatusUp {
+		return ctx.Response(http.StatusServiceUnavailable, res)
+	}
+	return ctx.Response(http.StatusOK, res)
+}
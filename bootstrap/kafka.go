@@ -2,21 +2,28 @@ package bootstrap
 
 import (
 	"context"
-
+	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/IBM/sarama"
 )
 
+const (
+	defaultHandlerRetries = 3
+	defaultRetryBackoff   = 100 * time.Millisecond
+)
+
 type KafkaServer struct {
-	client   sarama.ConsumerGroup
-	producer sarama.SyncProducer
-	options  *KafkaConfig
-	mutex    sync.Mutex
-	handlers map[string]ServiceHandleFunc
-	topics   []string
-	log      ILogger
+	client      sarama.ConsumerGroup
+	producer    sarama.SyncProducer
+	options     *KafkaConfig
+	mutex       sync.Mutex
+	handlers    map[string]ServiceHandleFunc
+	middlewares []Middleware
+	topics      []string
+	log         ILogger
 }
 
 func NewKafkaServer(producer sarama.SyncProducer, client sarama.ConsumerGroup, options *KafkaConfig, log ILogger) (*KafkaServer, error) {
@@ -51,6 +58,11 @@ func (s *KafkaServer) StartConsumer(ctx context.Context) error {
 
 }
 func (s *KafkaServer) Shutdown() {
+	s.closeConsumer()
+	s.closeProducer()
+}
+
+func (s *KafkaServer) closeConsumer() {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
@@ -62,6 +74,16 @@ func (s *KafkaServer) Shutdown() {
 	if err := s.client.Close(); err != nil {
 		s.log.Printf("Error closing Kafka consumer: %v", err)
 	}
+	s.client = nil
+}
+
+func (s *KafkaServer) closeProducer() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.producer == nil {
+		return
+	}
 
 	s.log.Println("Closing Kafka producer...")
 	if err := s.producer.Close(); err != nil {
@@ -80,6 +102,10 @@ func (s *KafkaServer) Consume(topic string, handler ServiceHandleFunc) {
 	s.handlers[topic] = handler
 }
 
+func (s *KafkaServer) Use(middlewares ...Middleware) {
+	s.middlewares = append(s.middlewares, middlewares...)
+}
+
 func (s *KafkaServer) Setup(_ sarama.ConsumerGroupSession) error {
 	return nil
 }
@@ -88,21 +114,99 @@ func (s *KafkaServer) Cleanup(_ sarama.ConsumerGroupSession) error {
 	return nil
 }
 
+// ConsumeClaim commits a message once its handler succeeded, retries included, or it was sent to the
+// dead-letter topic. Otherwise the claim ends without committing it, the next session consumes it again.
 func (s *KafkaServer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
 	for message := range claim.Messages() {
-		ctx := NewConsumerContext(message.Topic, string(message.Value), s.producer, s.log)
-
 		handler, exists := s.handlers[message.Topic]
 		if !exists {
 			s.log.Printf("No handler for topic: %s", message.Topic)
 			continue
 		}
 
-		if err := handler(ctx); err != nil {
+		if err := s.handle(session.Context(), message, handler); err != nil {
 			s.log.Printf("Handler error: %v", err)
+			if err := s.deadLetter(message, err); err != nil {
+				return fmt.Errorf("message %s/%d/%d not committed: %w", message.Topic, message.Partition, message.Offset, err)
+			}
 		}
 
 		session.MarkMessage(message, "")
 	}
 	return nil
 }
+
+// handle runs handler on message and runs it again after a failure, up to HandlerRetries times with a
+// doubling backoff. It returns the error of the last attempt, or the last error when ctx ends first.
+func (s *KafkaServer) handle(ctx context.Context, message *sarama.ConsumerMessage, handler ServiceHandleFunc) error {
+	retries, backoff := defaultHandlerRetries, defaultRetryBackoff
+	if s.options != nil {
+		if s.options.HandlerRetries != 0 {
+			retries = max(s.options.HandlerRetries, 0)
+		}
+		if s.options.RetryBackoff > 0 {
+			backoff = s.options.RetryBackoff
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		c := NewConsumerContext(message.Topic, string(message.Value), s.producer, s.log)
+		err := preHandle(HandleFunc(handler), s.middlewares...)(c)
+		if err == nil || attempt == retries {
+			return err
+		}
+
+		s.log.Printf("Handler error on %s/%d/%d, retrying in %s: %v", message.Topic, message.Partition, message.Offset, backoff, err)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// Dead-letter headers record where a failed message came from and why it failed.
+const (
+	HeaderDeadLetterTopic     = "x-original-topic"
+	HeaderDeadLetterPartition = "x-original-partition"
+	HeaderDeadLetterOffset    = "x-original-offset"
+	HeaderDeadLetterError     = "x-error"
+)
+
+// deadLetter forwards the message unchanged to the dead-letter topic, it returns cause when there is none.
+func (s *KafkaServer) deadLetter(message *sarama.ConsumerMessage, cause error) error {
+	if s.options == nil || s.options.DeadLetterTopic == "" {
+		return cause
+	}
+	if s.producer == nil {
+		return ErrNoProducer
+	}
+
+	msg := &sarama.ProducerMessage{
+		Topic:     s.options.DeadLetterTopic,
+		Value:     sarama.ByteEncoder(message.Value),
+		Timestamp: time.Now(),
+	}
+	if message.Key != nil {
+		msg.Key = sarama.ByteEncoder(message.Key)
+	}
+	for _, header := range message.Headers {
+		if header != nil {
+			msg.Headers = append(msg.Headers, *header)
+		}
+	}
+	msg.Headers = append(msg.Headers,
+		sarama.RecordHeader{Key: []byte(HeaderDeadLetterTopic), Value: []byte(message.Topic)},
+		sarama.RecordHeader{Key: []byte(HeaderDeadLetterPartition), Value: []byte(strconv.Itoa(int(message.Partition)))},
+		sarama.RecordHeader{Key: []byte(HeaderDeadLetterOffset), Value: []byte(strconv.FormatInt(message.Offset, 10))},
+		sarama.RecordHeader{Key: []byte(HeaderDeadLetterError), Value: []byte(cause.Error())},
+	)
+
+	if _, _, err := s.producer.SendMessage(msg); err != nil {
+		return fmt.Errorf("dead letter: %w", err)
+	}
+	return nil
+}
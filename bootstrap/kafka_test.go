@@ -227,51 +227,93 @@ func TestConsumeClaim(t *testing.T) {
 }
 
 func TestConsumeClaimHandlerError(t *testing.T) {
-	// Setup
-	mockSession := new(MockConsumerGroupSession)
-	mockClaim := new(MockConsumerGroupClaim)
-	producer := mocks.NewSyncProducer(t, nil)
-	logger := NewZapLogger(zap.NewNop())
-
-	server := &KafkaServer{
-		producer: producer,
-		log:      logger,
-		handlers: map[string]ServiceHandleFunc{
-			topic: func(ctx IContext) error {
-				return errors.New("handler error") // Simulating an error
-			},
-		},
-	}
-
-	// Create mock message
 	message := &sarama.ConsumerMessage{
-		Topic: topic,
-		Value: []byte("message value"),
+		Topic:     topic,
+		Partition: 2,
+		Offset:    7,
+		Key:       []byte("key"),
+		Value:     []byte("message value"),
 	}
 
-	// Mock session method calls
-	mockSession.On("MarkMessage", message, "")
-	mockSession.On("Claims").Return(map[string][]int32{topic: {0}})
-	mockSession.On("Commit")
-	mockSession.On("GenerationID").Return(int32(1)) // Mock GenerationID
-
-	mockMessageChannel := make(chan *sarama.ConsumerMessage, 1)
-
-	// Setup mock claim expectations
-	mockClaim.On("Messages").Return(mockMessageChannel).Once()
-	go func() {
-		// Simulate sending a message to the channel
+	consume := func(producer sarama.SyncProducer, options *KafkaConfig, handler ServiceHandleFunc) (*MockConsumerGroupSession, error) {
+		mockSession := new(MockConsumerGroupSession)
+		mockSession.On("MarkMessage", message, "").Maybe()
+		mockSession.On("Context").Return(context.Background()).Maybe()
+		mockClaim := new(MockConsumerGroupClaim)
+		mockMessageChannel := make(chan *sarama.ConsumerMessage, 1)
 		mockMessageChannel <- message
 		close(mockMessageChannel)
-	}()
-
-	// Run test
-	err := server.ConsumeClaim(mockSession, mockClaim)
+		mockClaim.On("Messages").Return(mockMessageChannel).Once()
+
+		server := &KafkaServer{
+			producer: producer,
+			options:  options,
+			log:      NewZapLogger(zap.NewNop()),
+			handlers: map[string]ServiceHandleFunc{topic: handler},
+		}
+		return mockSession, server.ConsumeClaim(mockSession, mockClaim)
+	}
+	var attempts int
+	failing := func(ctx IContext) error {
+		attempts++
+		return errors.New("handler error")
+	}
 
-	// Asserts
-	assert.Nil(t, err)
-	// mockSession.AssertExpectations(t)
-	mockClaim.AssertExpectations(t)
+	t.Run("not committed without a dead-letter topic", func(t *testing.T) {
+		attempts = 0
+		mockSession, err := consume(mocks.NewSyncProducer(t, nil), &KafkaConfig{HandlerRetries: 2, RetryBackoff: time.Millisecond}, failing)
+
+		assert.ErrorContains(t, err, "handler error")
+		assert.Equal(t, 3, attempts)
+		mockSession.AssertNotCalled(t, "MarkMessage", message, "")
+	})
+
+	t.Run("sent to the dead-letter topic", func(t *testing.T) {
+		producer := mocks.NewSyncProducer(t, nil)
+		producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+			value, _ := msg.Value.Encode()
+			headers := map[string]string{}
+			for _, header := range msg.Headers {
+				headers[string(header.Key)] = string(header.Value)
+			}
+			if msg.Topic != "dead-letters" || string(value) != "message value" {
+				return errors.New("unexpected message")
+			}
+			if headers[HeaderDeadLetterTopic] != topic || headers[HeaderDeadLetterOffset] != "7" || headers[HeaderDeadLetterError] != "handler error" {
+				return errors.New("unexpected headers")
+			}
+			return nil
+		})
+
+		mockSession, err := consume(producer, &KafkaConfig{DeadLetterTopic: "dead-letters", RetryBackoff: time.Millisecond}, failing)
+
+		assert.NoError(t, err)
+		mockSession.AssertCalled(t, "MarkMessage", message, "")
+	})
+
+	t.Run("not committed when the dead letter fails", func(t *testing.T) {
+		producer := mocks.NewSyncProducer(t, nil)
+		producer.ExpectSendMessageAndFail(sarama.ErrOutOfBrokers)
+
+		mockSession, err := consume(producer, &KafkaConfig{DeadLetterTopic: "dead-letters", HandlerRetries: -1}, failing)
+
+		assert.ErrorIs(t, err, sarama.ErrOutOfBrokers)
+		mockSession.AssertNotCalled(t, "MarkMessage", message, "")
+	})
+
+	t.Run("committed when a retry succeeds", func(t *testing.T) {
+		attempts = 0
+		mockSession, err := consume(mocks.NewSyncProducer(t, nil), &KafkaConfig{RetryBackoff: time.Millisecond}, func(ctx IContext) error {
+			if attempts++; attempts < 3 {
+				return errors.New("handler error")
+			}
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+		mockSession.AssertCalled(t, "MarkMessage", message, "")
+	})
 }
 
 // SendMessage tests
@@ -4,8 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/textproto"
 	"net/url"
 
 	"github.com/sing3demons/go-backend-clean-architecture/bootstrap"
@@ -24,6 +28,15 @@ type Option struct {
 	Query  map[string]string
 	Params map[string]string
 	Header map[string]string
+	// Files makes the request a multipart/form-data upload, Body is ignored.
+	Files []File
+}
+
+type File struct {
+	Field       string
+	Filename    string
+	ContentType string
+	Content     []byte
 }
 
 func NewMockMuxContext(opts ...Option) *FakeHttpContext {
@@ -34,7 +47,11 @@ func NewMockMuxContext(opts ...Option) *FakeHttpContext {
 
 	// Create request
 	var buf *bytes.Buffer
-	if opt.Body != nil {
+	contentType := "application/json"
+	if opt.Files != nil {
+		buf = &bytes.Buffer{}
+		contentType = writeMultipart(buf, opt.Files)
+	} else if opt.Body != nil {
 		jsonData, _ := json.Marshal(opt.Body)
 		buf = bytes.NewBuffer(jsonData)
 	} else {
@@ -64,7 +81,7 @@ func NewMockMuxContext(opts ...Option) *FakeHttpContext {
 			req.Header.Set(k, v)
 		}
 	} else {
-		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Type", contentType)
 	}
 
 	// Create response recorder
@@ -82,6 +99,21 @@ func NewMockMuxContext(opts ...Option) *FakeHttpContext {
 	}
 }
 
+func writeMultipart(buf *bytes.Buffer, files []File) string {
+	w := multipart.NewWriter(buf)
+	for _, file := range files {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name=%q; filename=%q`, file.Field, file.Filename))
+		if file.ContentType != "" {
+			header.Set("Content-Type", file.ContentType)
+		}
+		part, _ := w.CreatePart(header)
+		part.Write(file.Content)
+	}
+	w.Close()
+	return w.FormDataContentType()
+}
+
 func (c *FakeHttpContext) Code() int {
 	return c.Res.Code
 }
@@ -124,10 +156,36 @@ func (c *FakeHttpContext) ReadInput(data any) error {
 	return json.NewDecoder(c.Req.Body).Decode(data)
 }
 
+func (c *FakeHttpContext) FormFile(name string, maxSize int64) (*multipart.FileHeader, error) {
+	c.Req.Body = http.MaxBytesReader(c.Res, c.Req.Body, maxSize+64<<10)
+	if err := c.Req.ParseMultipartForm(8 << 20); err != nil {
+		return nil, err
+	}
+
+	files := c.Req.MultipartForm.File[name]
+	if len(files) == 0 {
+		return nil, http.ErrMissingFile
+	}
+	if files[0].Size > maxSize {
+		return nil, &http.MaxBytesError{Limit: maxSize}
+	}
+	return files[0], nil
+}
+
+func (c *FakeHttpContext) Stream(code int, contentType string, body io.Reader) error {
+	c.Res.Header().Set("Content-Type", contentType)
+	c.Res.WriteHeader(code)
+	_, err := io.Copy(c.Res, body)
+	return err
+}
+
 func (c *FakeHttpContext) Response(responseCode int, responseData any) error {
 	c.Res.Header().Set("Content-type", "application/json; charset=UTF8")
 
 	c.Res.WriteHeader(responseCode)
+	if responseCode == http.StatusNoContent || responseCode == http.StatusNotModified {
+		return nil
+	}
 
 	err := json.NewEncoder(c.Res).Encode(responseData)
 	return err
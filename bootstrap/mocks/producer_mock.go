@@ -24,6 +24,38 @@ func (m *MockSyncProducer) Close() error {
 	return args.Error(0)
 }
 
+// The transactional methods behave like a producer without a TransactionalID.
+
+func (m *MockSyncProducer) TxnStatus() sarama.ProducerTxnStatusFlag {
+	return sarama.ProducerTxnFlagReady
+}
+
+func (m *MockSyncProducer) IsTransactional() bool {
+	return false
+}
+
+func (m *MockSyncProducer) BeginTxn() error {
+	return sarama.ErrNonTransactedProducer
+}
+
+func (m *MockSyncProducer) CommitTxn() error {
+	return sarama.ErrNonTransactedProducer
+}
+
+func (m *MockSyncProducer) AbortTxn() error {
+	return sarama.ErrNonTransactedProducer
+}
+
+func (m *MockSyncProducer) AddOffsetsToTxn(offsets map[string][]*sarama.PartitionOffsetMetadata, groupId string) error {
+	return sarama.ErrNonTransactedProducer
+}
+
+func (m *MockSyncProducer) AddMessageToTxn(msg *sarama.ConsumerMessage, groupId string, metadata *string) error {
+	return sarama.ErrNonTransactedProducer
+}
+
+var _ sarama.SyncProducer = (*MockSyncProducer)(nil)
+
 func NewMockSyncProducer() *MockSyncProducer {
 	return &MockSyncProducer{}
 }
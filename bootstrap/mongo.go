@@ -0,0 +1,145 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+const (
+	defaultMongoConnectRetries = 3
+	defaultMongoRetryBackoff   = time.Second
+	defaultMongoPingTimeout    = 5 * time.Second
+)
+
+type MongoConfig struct {
+	URI      string
+	Database string
+
+	MinPoolSize            uint64
+	MaxPoolSize            uint64
+	ConnectTimeout         time.Duration
+	ServerSelectionTimeout time.Duration
+	SocketTimeout          time.Duration
+
+	// ReadPreference is one of primary, primaryPreferred, secondary, secondaryPreferred or nearest.
+	ReadPreference string
+	// WriteConcern is "majority", a tag set name or the number of acknowledging nodes.
+	WriteConcern string
+	Journal      bool
+
+	// ConnectRetries is the number of ping attempts at startup, RetryBackoff doubles after each failure.
+	ConnectRetries int
+	RetryBackoff   time.Duration
+	// Required makes the application exit when Mongo cannot be reached at startup.
+	Required bool
+
+	// ResumeTokenCollection stores change stream resume tokens (default "change_stream_tokens").
+	ResumeTokenCollection string
+
+	client mongo.Client
+}
+
+func (cfg *MongoConfig) clientOptions() (*options.ClientOptions, error) {
+	opts := options.Client().ApplyURI(cfg.URI)
+
+	if cfg.MinPoolSize > 0 {
+		opts.SetMinPoolSize(cfg.MinPoolSize)
+	}
+	if cfg.MaxPoolSize > 0 {
+		opts.SetMaxPoolSize(cfg.MaxPoolSize)
+	}
+	if cfg.ConnectTimeout > 0 {
+		opts.SetConnectTimeout(cfg.ConnectTimeout)
+	}
+	if cfg.ServerSelectionTimeout > 0 {
+		opts.SetServerSelectionTimeout(cfg.ServerSelectionTimeout)
+	}
+	if cfg.SocketTimeout > 0 {
+		opts.SetSocketTimeout(cfg.SocketTimeout)
+	}
+
+	if cfg.ReadPreference != "" {
+		mode, err := readpref.ModeFromString(cfg.ReadPreference)
+		if err != nil {
+			return nil, err
+		}
+		rp, err := readpref.New(mode)
+		if err != nil {
+			return nil, err
+		}
+		opts.SetReadPreference(rp)
+	}
+
+	if cfg.WriteConcern != "" || cfg.Journal {
+		wc := &writeconcern.WriteConcern{}
+		if cfg.WriteConcern != "" {
+			if w, err := strconv.Atoi(cfg.WriteConcern); err == nil {
+				wc.W = w
+			} else {
+				wc.W = cfg.WriteConcern
+			}
+		}
+		if cfg.Journal {
+			wc.Journal = &cfg.Journal
+		}
+		opts.SetWriteConcern(wc)
+	}
+
+	return opts, nil
+}
+
+func newMongoClient(cfg *MongoConfig) (mongo.Client, error) {
+	if cfg.client != nil {
+		return cfg.client, nil
+	}
+
+	opts, err := cfg.clientOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := mongo.NewClientWithOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.Connect(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// pingMongo pings the server until it answers or the retries are exhausted, backing off between attempts.
+func pingMongo(client mongo.Client, cfg *MongoConfig, log ILogger) error {
+	retries := cfg.ConnectRetries
+	if retries <= 0 {
+		retries = defaultMongoConnectRetries
+	}
+	backoff := timeoutOrDefault(cfg.RetryBackoff, defaultMongoRetryBackoff)
+
+	var err error
+	for attempt := 1; attempt <= retries; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultMongoPingTimeout)
+		err = client.Ping(ctx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+
+		log.Printf("Failed to ping MongoDB (attempt %d/%d): %v", attempt, retries, err)
+		if attempt < retries {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+
+	return fmt.Errorf("mongo: unable to connect after %d attempts: %w", retries, err)
+}
@@ -0,0 +1,45 @@
+package bootstrap
+
+import (
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+const (
+	// multipartOverhead allows for the boundary and part headers around an uploaded file.
+	multipartOverhead = 64 << 10
+	// multipartMemory is the part of a multipart body kept in memory, the rest spills to temporary files.
+	multipartMemory = 8 << 20
+)
+
+// formFile parses a multipart body of at most maxSize bytes of file content and returns the first file
+// of the field name. A larger body fails with *http.MaxBytesError, a missing file with http.ErrMissingFile.
+// The server removes the temporary files of the form when the request ends.
+func formFile(w http.ResponseWriter, r *http.Request, name string, maxSize int64) (*multipart.FileHeader, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxSize+multipartOverhead)
+	if err := r.ParseMultipartForm(multipartMemory); err != nil {
+		return nil, err
+	}
+
+	files := r.MultipartForm.File[name]
+	if len(files) == 0 {
+		return nil, http.ErrMissingFile
+	}
+	if files[0].Size > maxSize {
+		return nil, &http.MaxBytesError{Limit: maxSize}
+	}
+	return files[0], nil
+}
+
+// streamResponse copies body to w as is, the caller sets Content-Length when it is known.
+func streamResponse(w http.ResponseWriter, code int, contentType string, body io.Reader) error {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(code)
+	if !bodyAllowed(code) {
+		return nil
+	}
+
+	_, err := io.Copy(w, body)
+	return err
+}
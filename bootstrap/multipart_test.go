@@ -0,0 +1,86 @@
+package bootstrap
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newMultipartRequest(t *testing.T, field string, content []byte) *http.Request {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	part, err := w.CreateFormFile(field, "notes.txt")
+	assert.NoError(t, err)
+	_, err = part.Write(content)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+func TestFormFile(t *testing.T) {
+	t.Run("reads the file", func(t *testing.T) {
+		req := newMultipartRequest(t, "file", []byte("hello"))
+
+		header, err := formFile(httptest.NewRecorder(), req, "file", 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "notes.txt", header.Filename)
+		assert.Equal(t, int64(5), header.Size)
+	})
+
+	t.Run("file over the limit", func(t *testing.T) {
+		req := newMultipartRequest(t, "file", []byte("hello world"))
+
+		_, err := formFile(httptest.NewRecorder(), req, "file", 10)
+
+		var tooLarge *http.MaxBytesError
+		assert.True(t, errors.As(err, &tooLarge))
+	})
+
+	t.Run("body over the limit", func(t *testing.T) {
+		req := newMultipartRequest(t, "file", bytes.Repeat([]byte("a"), multipartOverhead+100))
+
+		_, err := formFile(httptest.NewRecorder(), req, "file", 10)
+
+		var tooLarge *http.MaxBytesError
+		assert.True(t, errors.As(err, &tooLarge))
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		req := newMultipartRequest(t, "other", []byte("hello"))
+
+		_, err := formFile(httptest.NewRecorder(), req, "file", 10)
+
+		assert.ErrorIs(t, err, http.ErrMissingFile)
+	})
+
+	t.Run("not multipart", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("{}"))
+		req.Header.Set("Content-Type", "application/json")
+
+		_, err := formFile(httptest.NewRecorder(), req, "file", 10)
+
+		assert.ErrorIs(t, err, http.ErrNotMultipart)
+	})
+}
+
+func TestStreamResponse(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	err := streamResponse(rec, 206, "text/plain", io.LimitReader(strings.NewReader("hello world"), 5))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 206, rec.Code)
+	assert.Equal(t, "text/plain", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "hello", rec.Body.String())
+}
@@ -2,6 +2,7 @@ package bootstrap
 
 import (
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/IBM/sarama"
@@ -37,7 +38,14 @@ func newProducer(option *KafkaConfig) (sarama.SyncProducer, error) {
 	return sarama.NewSyncProducer(option.Brokers, config)
 }
 
+// ErrNoProducer is returned when sending a message while Kafka is not configured.
+var ErrNoProducer = errors.New("kafka producer is not configured")
+
 func producer(producer sarama.SyncProducer, topic string, payload any, opts ...OptionProducerMsg) (RecordMetadata, error) {
+	if producer == nil {
+		return RecordMetadata{}, ErrNoProducer
+	}
+
 	timestamp := time.Now()
 
 	data, err := json.Marshal(payload)
@@ -3,6 +3,7 @@ package bootstrap
 import (
 	"testing"
 
+	"github.com/IBM/sarama"
 	"github.com/IBM/sarama/mocks"
 	"github.com/stretchr/testify/assert"
 )
@@ -24,3 +25,24 @@ func TestProducer(t *testing.T) {
 	assert.GreaterOrEqual(t, recordMetadata.Partition, int32(0))
 	assert.GreaterOrEqual(t, recordMetadata.Offset, int64(0))
 }
+
+func TestProducerWithKey(t *testing.T) {
+	mockProducer := mocks.NewSyncProducer(t, nil)
+
+	mockProducer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+		key, _ := msg.Key.Encode()
+		assert.Equal(t, "task-1", string(key))
+		assert.Equal(t, []sarama.RecordHeader{{Key: []byte("source"), Value: []byte("test")}}, msg.Headers)
+		return nil
+	})
+
+	_, err := producer(mockProducer, "test-topic", "hello", WithKey("task-1"), WithHeaders(map[string]string{"source": "test"}))
+
+	assert.NoError(t, err)
+}
+
+func TestProducerNotConfigured(t *testing.T) {
+	_, err := producer(nil, "test-topic", "hello")
+
+	assert.ErrorIs(t, err, ErrNoProducer)
+}
@@ -0,0 +1,85 @@
+package bootstrap
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	HeaderRange         = "Range"
+	HeaderIfRange       = "If-Range"
+	HeaderAcceptRanges  = "Accept-Ranges"
+	HeaderContentRange  = "Content-Range"
+	HeaderContentLength = "Content-Length"
+)
+
+// ErrRangeNotSatisfiable is returned by ParseRange for a range that starts past the end of the content.
+var ErrRangeNotSatisfiable = errors.New("range not satisfiable")
+
+// ByteRange is the inclusive range of bytes Start to End.
+type ByteRange struct {
+	Start int64
+	End   int64
+}
+
+func (r ByteRange) Length() int64 {
+	return r.End - r.Start + 1
+}
+
+// ContentRange formats the Content-Range header of the range within content of size bytes.
+func (r ByteRange) ContentRange(size int64) string {
+	return fmt.Sprintf("bytes %d-%d/%d", r.Start, r.End, size)
+}
+
+// ParseRange parses a Range header for content of size bytes. It returns false when the whole content
+// should be sent: no header, a malformed header or several ranges, which servers may ignore.
+func ParseRange(header string, size int64) (ByteRange, bool, error) {
+	spec, ok := strings.CutPrefix(strings.TrimSpace(header), "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return ByteRange{}, false, nil
+	}
+
+	first, last, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return ByteRange{}, false, nil
+	}
+
+	if first == "" {
+		// A suffix range selects the last bytes
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n < 0 {
+			return ByteRange{}, false, nil
+		}
+		if n == 0 || size == 0 {
+			return ByteRange{}, false, ErrRangeNotSatisfiable
+		}
+		return ByteRange{Start: max(size-n, 0), End: size - 1}, true, nil
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return ByteRange{}, false, nil
+	}
+	end := size - 1
+	if last != "" {
+		if end, err = strconv.ParseInt(last, 10, 64); err != nil || end < start {
+			return ByteRange{}, false, nil
+		}
+	}
+
+	if start >= size {
+		return ByteRange{}, false, ErrRangeNotSatisfiable
+	}
+	return ByteRange{Start: start, End: min(end, size-1)}, true, nil
+}
+
+// RequestRange returns the range requested by ctx for content of size bytes with the entity tag etag,
+// an If-Range header that does not match etag asks for the whole content.
+func RequestRange(ctx IContext, etag string, size int64) (ByteRange, bool, error) {
+	if ifRange := ctx.GetHeader(HeaderIfRange); ifRange != "" && ifRange != etag {
+		return ByteRange{}, false, nil
+	}
+	return ParseRange(ctx.GetHeader(HeaderRange), size)
+}
@@ -0,0 +1,44 @@
+package bootstrap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		header string
+		want   ByteRange
+		ok     bool
+		err    error
+	}{
+		{header: "", ok: false},
+		{header: "bytes=0-99", want: ByteRange{Start: 0, End: 99}, ok: true},
+		{header: "bytes=100-", want: ByteRange{Start: 100, End: 999}, ok: true},
+		{header: "bytes=900-5000", want: ByteRange{Start: 900, End: 999}, ok: true},
+		{header: "bytes=-100", want: ByteRange{Start: 900, End: 999}, ok: true},
+		{header: "bytes=-5000", want: ByteRange{Start: 0, End: 999}, ok: true},
+		{header: "bytes=0-1,5-6", ok: false},
+		{header: "bytes=9-1", ok: false},
+		{header: "items=0-1", ok: false},
+		{header: "bytes=abc", ok: false},
+		{header: "bytes=1000-", err: ErrRangeNotSatisfiable},
+		{header: "bytes=-0", err: ErrRangeNotSatisfiable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			got, ok, err := ParseRange(tt.header, 1000)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestByteRangeContentRange(t *testing.T) {
+	r := ByteRange{Start: 10, End: 19}
+	assert.Equal(t, int64(10), r.Length())
+	assert.Equal(t, "bytes 10-19/1000", r.ContentRange(1000))
+}
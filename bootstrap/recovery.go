@@ -0,0 +1,81 @@
+package bootstrap
+
+import (
+	"fmt"
+	"net/http"
+	"runtime"
+)
+
+const defaultStackSize = 4 << 10
+
+type RecoveryConfig struct {
+	// Disable turns off the built-in recovery layer for HTTP handlers and Kafka consumers.
+	Disable bool
+	// StackSize is the maximum number of bytes of stack trace logged per panic (default 4KB).
+	StackSize int
+	// DisableStackAll logs only the stack of the panicking goroutine.
+	DisableStackAll bool
+}
+
+type ProblemDetail struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+// PanicError is returned by a handler wrapped with Recovery when it panicked.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic recovered: %v", e.Value)
+}
+
+// Recovery converts a panic in the handler chain into a 500 problem response and a *PanicError.
+func Recovery(cfg RecoveryConfig) Middleware {
+	stackSize := cfg.StackSize
+	if stackSize <= 0 {
+		stackSize = defaultStackSize
+	}
+
+	return func(next HandleFunc) HandleFunc {
+		return func(ctx IContext) (err error) {
+			defer func() {
+				r := recover()
+				if r == nil {
+					return
+				}
+
+				if r == http.ErrAbortHandler {
+					panic(r)
+				}
+
+				stack := make([]byte, stackSize)
+				stack = stack[:runtime.Stack(stack, !cfg.DisableStackAll)]
+
+				ctx.Log().Errorf("[PANIC RECOVER] %v\n%s", r, stack)
+
+				ctx.Response(http.StatusInternalServerError, ProblemDetail{
+					Type:   "about:blank",
+					Title:  http.StatusText(http.StatusInternalServerError),
+					Status: http.StatusInternalServerError,
+				})
+
+				err = &PanicError{Value: r, Stack: stack}
+			}()
+
+			return next(ctx)
+		}
+	}
+}
+
+func recoveryMiddlewares(cfg *Config) []Middleware {
+	if cfg.RecoveryConfig.Disable {
+		return nil
+	}
+	return []Middleware{Recovery(cfg.RecoveryConfig)}
+}
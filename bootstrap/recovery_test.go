@@ -0,0 +1,144 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRecoveryHTTP(t *testing.T) {
+	routers := map[string]Router{
+		"mux":  Mux,
+		"gin":  Gin,
+		"echo": Echo,
+	}
+
+	for name, router := range routers {
+		t.Run(name, func(t *testing.T) {
+			app := NewApplication(&Config{
+				AppConfig: AppConfig{
+					Port:   "3000",
+					Router: router,
+				},
+			}, NewZapLogger(zap.NewNop()))
+
+			app.Get("/panic", func(ctx IContext) error {
+				panic("boom")
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+			problem := ProblemDetail{}
+			err := json.Unmarshal(rec.Body.Bytes(), &problem)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusInternalServerError, problem.Status)
+		})
+	}
+}
+
+func TestRecoveryMiddlewarePanicInMiddleware(t *testing.T) {
+	app := NewApplication(&Config{
+		AppConfig: AppConfig{
+			Port: "3000",
+		},
+	}, NewZapLogger(zap.NewNop()))
+
+	app.Use(func(next HandleFunc) HandleFunc {
+		return func(ctx IContext) error {
+			panic("middleware boom")
+		}
+	})
+
+	app.Get("/test", func(ctx IContext) error {
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestRecoveryDisabled(t *testing.T) {
+	app := NewApplication(&Config{
+		AppConfig: AppConfig{
+			Port: "3000",
+		},
+		RecoveryConfig: RecoveryConfig{
+			Disable: true,
+		},
+	}, NewZapLogger(zap.NewNop()))
+
+	app.Get("/panic", func(ctx IContext) error {
+		panic("boom")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	rec := httptest.NewRecorder()
+
+	assert.Panics(t, func() {
+		app.ServeHTTP(rec, req)
+	})
+}
+
+func TestRecoveryReturnsPanicError(t *testing.T) {
+	handler := Recovery(RecoveryConfig{StackSize: 256})(func(ctx IContext) error {
+		panic("boom")
+	})
+
+	ctx := NewConsumerContext(topic, "", mocks.NewSyncProducer(t, nil), NewZapLogger(zap.NewNop()))
+	err := handler(ctx)
+
+	var panicErr *PanicError
+	assert.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "boom", panicErr.Value)
+	assert.LessOrEqual(t, len(panicErr.Stack), 256)
+}
+
+func TestConsumeClaimHandlerPanic(t *testing.T) {
+	mockSession := new(MockConsumerGroupSession)
+	mockClaim := new(MockConsumerGroupClaim)
+	logger := NewZapLogger(zap.NewNop())
+
+	server, err := NewKafkaServer(mocks.NewSyncProducer(t, nil), &MockConsumerGroup{}, &KafkaConfig{RetryBackoff: time.Millisecond}, logger)
+	assert.NoError(t, err)
+	server.Use(Recovery(RecoveryConfig{}))
+	server.Consume(topic, func(ctx IContext) error {
+		panic("boom")
+	})
+
+	message := &sarama.ConsumerMessage{
+		Topic: topic,
+		Value: []byte("message value"),
+	}
+
+	mockSession.On("Context").Return(context.Background())
+
+	mockMessageChannel := make(chan *sarama.ConsumerMessage, 1)
+	mockClaim.On("Messages").Return(mockMessageChannel).Once()
+	mockMessageChannel <- message
+	close(mockMessageChannel)
+
+	assert.NotPanics(t, func() {
+		err = server.ConsumeClaim(mockSession, mockClaim)
+	})
+
+	// the panicked message is not committed, the claim ends with the panic
+	var panicErr *PanicError
+	assert.ErrorAs(t, err, &panicErr)
+	mockSession.AssertNotCalled(t, "MarkMessage", message, "")
+	mockClaim.AssertExpectations(t)
+}
@@ -16,9 +16,10 @@ func newServer(cfg *Config, log ILogger) IRouter {
 	app := http.NewServeMux()
 
 	return &httpApplication{
-		mux: app,
-		cfg: cfg,
-		log: log,
+		mux:         app,
+		middlewares: recoveryMiddlewares(cfg),
+		cfg:         cfg,
+		log:         log,
 	}
 }
 
@@ -27,32 +28,37 @@ func (app *httpApplication) Use(middlewares ...Middleware) {
 }
 
 func (app *httpApplication) Get(path string, handler HandleFunc, middlewares ...Middleware) {
+	path = bracePath(path)
 	app.mux.HandleFunc(http.MethodGet+" "+path, func(w http.ResponseWriter, r *http.Request) {
 		preHandle(handler, preMiddleware(app.middlewares, middlewares)...)(newMuxContext(w, setParam(path, r), &app.cfg.KafkaConfig, app.log))
 	})
 }
 
 func (app *httpApplication) Post(path string, handler HandleFunc, middlewares ...Middleware) {
+	path = bracePath(path)
 	app.mux.HandleFunc(http.MethodPost+" "+path, func(w http.ResponseWriter, r *http.Request) {
-		preHandle(handler, preMiddleware(app.middlewares, middlewares)...)(newMuxContext(w, r, &app.cfg.KafkaConfig, app.log))
+		preHandle(handler, preMiddleware(app.middlewares, middlewares)...)(newMuxContext(w, setParam(path, r), &app.cfg.KafkaConfig, app.log))
 	})
 }
 
 func (app *httpApplication) Put(path string, handler HandleFunc, middlewares ...Middleware) {
+	path = bracePath(path)
 	app.mux.HandleFunc(http.MethodPut+" "+path, func(w http.ResponseWriter, r *http.Request) {
-		preHandle(handler, preMiddleware(app.middlewares, middlewares)...)(newMuxContext(w, r, &app.cfg.KafkaConfig, app.log))
+		preHandle(handler, preMiddleware(app.middlewares, middlewares)...)(newMuxContext(w, setParam(path, r), &app.cfg.KafkaConfig, app.log))
 	})
 }
 
 func (app *httpApplication) Delete(path string, handler HandleFunc, middlewares ...Middleware) {
+	path = bracePath(path)
 	app.mux.HandleFunc(http.MethodDelete+" "+path, func(w http.ResponseWriter, r *http.Request) {
-		preHandle(handler, preMiddleware(app.middlewares, middlewares)...)(newMuxContext(w, r, &app.cfg.KafkaConfig, app.log))
+		preHandle(handler, preMiddleware(app.middlewares, middlewares)...)(newMuxContext(w, setParam(path, r), &app.cfg.KafkaConfig, app.log))
 	})
 }
 
 func (app *httpApplication) Patch(path string, handler HandleFunc, middlewares ...Middleware) {
+	path = bracePath(path)
 	app.mux.HandleFunc(http.MethodPatch+" "+path, func(w http.ResponseWriter, r *http.Request) {
-		preHandle(handler, preMiddleware(app.middlewares, middlewares)...)(newMuxContext(w, r, &app.cfg.KafkaConfig, app.log))
+		preHandle(handler, preMiddleware(app.middlewares, middlewares)...)(newMuxContext(w, setParam(path, r), &app.cfg.KafkaConfig, app.log))
 	})
 }
 
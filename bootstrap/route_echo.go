@@ -17,38 +17,39 @@ func newEchoServer(cfg *Config, log ILogger) IRouter {
 	app := echo.New()
 
 	return &echoApplication{
-		router: app,
-		cfg:    cfg,
-		log:    log,
+		router:      app,
+		middlewares: recoveryMiddlewares(cfg),
+		cfg:         cfg,
+		log:         log,
 	}
 }
 
 func (app *echoApplication) Get(path string, handler HandleFunc, middlewares ...Middleware) {
-	app.router.GET(path, func(c echo.Context) error {
+	app.router.GET(colonPath(path), func(c echo.Context) error {
 		return preHandle(handler, preMiddleware(app.middlewares, middlewares)...)(newEchoContext(c, &app.cfg.KafkaConfig, app.log))
 	})
 }
 
 func (app *echoApplication) Post(path string, handler HandleFunc, middlewares ...Middleware) {
-	app.router.POST(path, func(c echo.Context) error {
+	app.router.POST(colonPath(path), func(c echo.Context) error {
 		return preHandle(handler, preMiddleware(app.middlewares, middlewares)...)(newEchoContext(c, &app.cfg.KafkaConfig, app.log))
 	})
 }
 
 func (app *echoApplication) Put(path string, handler HandleFunc, middlewares ...Middleware) {
-	app.router.PUT(path, func(c echo.Context) error {
+	app.router.PUT(colonPath(path), func(c echo.Context) error {
 		return preHandle(handler, preMiddleware(app.middlewares, middlewares)...)(newEchoContext(c, &app.cfg.KafkaConfig, app.log))
 	})
 }
 
 func (app *echoApplication) Delete(path string, handler HandleFunc, middlewares ...Middleware) {
-	app.router.DELETE(path, func(c echo.Context) error {
+	app.router.DELETE(colonPath(path), func(c echo.Context) error {
 		return preHandle(handler, preMiddleware(app.middlewares, middlewares)...)(newEchoContext(c, &app.cfg.KafkaConfig, app.log))
 	})
 }
 
 func (app *echoApplication) Patch(path string, handler HandleFunc, middlewares ...Middleware) {
-	app.router.PATCH(path, func(c echo.Context) error {
+	app.router.PATCH(colonPath(path), func(c echo.Context) error {
 		return preHandle(handler, preMiddleware(app.middlewares, middlewares)...)(newEchoContext(c, &app.cfg.KafkaConfig, app.log))
 	})
 }
@@ -15,41 +15,41 @@ type ginApplication struct {
 
 func newGinServer(cfg *Config, log ILogger) IRouter {
 	app := gin.New()
-	app.Use(gin.Recovery())
 
 	return &ginApplication{
-		router: app,
-		cfg:    cfg,
-		log:    log,
+		router:      app,
+		middlewares: recoveryMiddlewares(cfg),
+		cfg:         cfg,
+		log:         log,
 	}
 }
 
 func (app *ginApplication) Get(path string, handler HandleFunc, middlewares ...Middleware) {
-	app.router.GET(path, func(c *gin.Context) {
+	app.router.GET(colonPath(path), func(c *gin.Context) {
 		preHandle(handler, preMiddleware(app.middlewares, middlewares)...)(newGinContext(c, &app.cfg.KafkaConfig, app.log))
 	})
 }
 
 func (app *ginApplication) Post(path string, handler HandleFunc, middlewares ...Middleware) {
-	app.router.POST(path, func(c *gin.Context) {
+	app.router.POST(colonPath(path), func(c *gin.Context) {
 		preHandle(handler, preMiddleware(app.middlewares, middlewares)...)(newGinContext(c, &app.cfg.KafkaConfig, app.log))
 	})
 }
 
 func (app *ginApplication) Put(path string, handler HandleFunc, middlewares ...Middleware) {
-	app.router.PUT(path, func(c *gin.Context) {
+	app.router.PUT(colonPath(path), func(c *gin.Context) {
 		preHandle(handler, preMiddleware(app.middlewares, middlewares)...)(newGinContext(c, &app.cfg.KafkaConfig, app.log))
 	})
 }
 
 func (app *ginApplication) Delete(path string, handler HandleFunc, middlewares ...Middleware) {
-	app.router.DELETE(path, func(c *gin.Context) {
+	app.router.DELETE(colonPath(path), func(c *gin.Context) {
 		preHandle(handler, preMiddleware(app.middlewares, middlewares)...)(newGinContext(c, &app.cfg.KafkaConfig, app.log))
 	})
 }
 
 func (app *ginApplication) Patch(path string, handler HandleFunc, middlewares ...Middleware) {
-	app.router.PATCH(path, func(c *gin.Context) {
+	app.router.PATCH(colonPath(path), func(c *gin.Context) {
 		preHandle(handler, preMiddleware(app.middlewares, middlewares)...)(newGinContext(c, &app.cfg.KafkaConfig, app.log))
 	})
 }
@@ -238,3 +238,29 @@ func TestGinApplicationParam(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rec.Code, printErr(http.StatusOK, rec.Code))
 	assert.True(t, handlerCalled, handlerCalledErr)
 }
+
+func TestGinApplicationBraceParam(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	log := NewZapLogger(zap.NewNop())
+
+	cfg := &Config{
+		AppConfig: AppConfig{
+			Port: "8888",
+		},
+	}
+	app := newGinServer(cfg, log).(*ginApplication)
+
+	var id string
+	app.Post("/test/{id}/restore", func(ctx IContext) error {
+		id = ctx.Param("id")
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/test/123/restore", nil)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code, printErr(http.StatusOK, rec.Code))
+	assert.Equal(t, "123", id)
+}
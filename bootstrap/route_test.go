@@ -163,6 +163,35 @@ func TestHttpApplicationUse(t *testing.T) {
 	assert.True(t, handlerCalled, handlerCalledErr)
 }
 
+func TestHttpApplicationParam(t *testing.T) {
+	log := NewZapLogger(zap.NewNop())
+
+	cfg := &Config{
+		AppConfig: AppConfig{
+			Port: "8888",
+		},
+	}
+
+	for _, path := range []string{"/test/{id}/restore", "/test/:id/restore"} {
+		t.Run(path, func(t *testing.T) {
+			app := newServer(cfg, log).(*httpApplication)
+
+			var id string
+			app.Post(path, func(ctx IContext) error {
+				id = ctx.Param("id")
+				return nil
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/test/123/restore", nil)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, "123", id)
+		})
+	}
+}
+
 // Register
 func TestHttpApplicationRegister(t *testing.T) {
 	log := NewZapLogger(zap.NewNop())
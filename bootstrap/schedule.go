@@ -0,0 +1,216 @@
+package bootstrap
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	DefaultSchedulerLockCollection = "scheduler_locks"
+
+	// lockMargin releases a leader lock this long before the next activation so that clock skew
+	// between replicas does not make the next activation miss it.
+	lockMargin = time.Second
+)
+
+type SchedulerConfig struct {
+	// LockCollection stores the leader locks of jobs scheduled with LeaderLock (default "scheduler_locks").
+	LockCollection string
+	// Location evaluates cron expressions, UTC when nil.
+	Location *time.Location
+}
+
+type ScheduleOptions struct {
+	// Name identifies the job in logs and in its leader lock, it defaults to the spec.
+	Name string
+	// Jitter delays each activation by a random duration up to Jitter.
+	Jitter time.Duration
+	// LeaderLock runs each activation on a single replica, it requires MongoConfig.
+	LeaderLock bool
+}
+
+type scheduledJob struct {
+	name        string
+	schedule    schedule
+	handler     ServiceHandleFunc
+	middlewares []Middleware
+	jitter      time.Duration
+	lock        *leaderLock
+	producer    sarama.SyncProducer
+	log         ILogger
+	running     atomic.Bool
+}
+
+type scheduleGroup struct {
+	mutex sync.Mutex
+	jobs  []*scheduledJob
+	// cancel stops the activations, cancelHandlers aborts the running handlers
+	cancel         context.CancelFunc
+	cancelHandlers context.CancelFunc
+	done           chan struct{}
+}
+
+func (g *scheduleGroup) add(j *scheduledJob) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.jobs = append(g.jobs, j)
+}
+
+func (g *scheduleGroup) start() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if len(g.jobs) == 0 || g.done != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	handlers, cancelHandlers := context.WithCancel(context.Background())
+	g.cancel, g.cancelHandlers = cancel, cancelHandlers
+	g.done = make(chan struct{})
+
+	var wg sync.WaitGroup
+	for _, j := range g.jobs {
+		wg.Add(1)
+		go func(j *scheduledJob) {
+			defer wg.Done()
+			j.run(ctx, handlers, &wg)
+		}(j)
+	}
+
+	go func() {
+		wg.Wait()
+		close(g.done)
+	}()
+}
+
+// stop cancels the pending activations and waits for running handlers to finish. The handlers still
+// running when the wait ends have their context cancelled.
+func (g *scheduleGroup) stop(ctx context.Context, timeout time.Duration) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.cancel == nil {
+		return nil
+	}
+	g.cancel()
+	defer g.cancelHandlers()
+	return wait(ctx, g.done, timeout)
+}
+
+// run waits for each activation until ctx is done and starts the handler on handlers unless the previous
+// activation is still running. Handlers are tracked by wg so that stop waits for them.
+func (j *scheduledJob) run(ctx, handlers context.Context, wg *sync.WaitGroup) {
+	j.log.Println("Starting scheduled job " + j.name)
+	defer j.log.Println("Stopping scheduled job " + j.name)
+
+	for {
+		next := j.schedule.Next(time.Now())
+		if next.IsZero() {
+			j.log.Printf("Scheduled job %s has no further activations", j.name)
+			return
+		}
+
+		delay := time.Until(next)
+		if j.jitter > 0 {
+			delay += rand.N(j.jitter)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		// select picks at random when the group stopped as the timer fired
+		if ctx.Err() != nil {
+			return
+		}
+
+		if !j.running.CompareAndSwap(false, true) {
+			j.log.Printf("Scheduled job %s is still running, skipping the activation at %s", j.name, next.Format(time.RFC3339))
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer j.running.Store(false)
+			j.activate(handlers, next)
+		}()
+	}
+}
+
+func (j *scheduledJob) activate(ctx context.Context, scheduledAt time.Time) {
+	if j.lock != nil {
+		// Hold the lock until just before the following activation
+		until := j.schedule.Next(scheduledAt)
+		if until.IsZero() || until.Sub(scheduledAt) <= 2*lockMargin {
+			until = scheduledAt.Add(lockMargin)
+		} else {
+			until = until.Add(-lockMargin)
+		}
+
+		acquired, err := j.lock.acquire(ctx, j.name, until)
+		if err != nil {
+			j.log.Printf("Scheduled job %s lock error: %v", j.name, err)
+			return
+		}
+		if !acquired {
+			return
+		}
+	}
+
+	c := newScheduleContext(ctx, j.name, scheduledAt, j.producer, j.log)
+	if err := preHandle(HandleFunc(j.handler), j.middlewares...)(c); err != nil {
+		c.Log().Printf("Scheduled job %s error: %v", j.name, err)
+	}
+}
+
+// leaderLock elects the replica running an activation with one document per job. The upsert of a
+// replica that does not own an unexpired lock fails with a duplicate key error.
+type leaderLock struct {
+	database   mongo.Database
+	collection string
+	owner      string
+}
+
+func newLeaderLock(db mongo.Database, collection string) *leaderLock {
+	return &leaderLock{
+		database:   db,
+		collection: collection,
+		owner:      primitive.NewObjectID().Hex(),
+	}
+}
+
+func (l *leaderLock) acquire(ctx context.Context, job string, until time.Time) (bool, error) {
+	now := time.Now()
+	filter := bson.M{
+		"_id": job,
+		"$or": []bson.M{
+			{"expiresAt": bson.M{"$lte": now}},
+			{"owner": l.owner},
+		},
+	}
+	update := bson.M{"$set": bson.M{"owner": l.owner, "acquiredAt": now, "expiresAt": until}}
+
+	_, err := l.database.Collection(l.collection).UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if driver.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
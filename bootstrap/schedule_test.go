@@ -0,0 +1,213 @@
+package bootstrap
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	mongomocks "github.com/sing3demons/go-backend-clean-architecture/mongo/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+func newTestJob(spec string, handler ServiceHandleFunc) *scheduledJob {
+	s, err := parseSchedule(spec, nil)
+	if err != nil {
+		panic(err)
+	}
+	return &scheduledJob{
+		name:        "test-job",
+		schedule:    s,
+		handler:     handler,
+		middlewares: []Middleware{Recovery(RecoveryConfig{})},
+		log:         NewZapLogger(zap.NewNop()),
+	}
+}
+
+func TestScheduleGroupRunsJobs(t *testing.T) {
+	var runs atomic.Int32
+	var job, scheduledAt atomic.Value
+
+	group := &scheduleGroup{}
+	group.add(newTestJob("@every 10ms", func(ctx IContext) error {
+		job.Store(ctx.Param("job"))
+		scheduledAt.Store(ctx.Param("scheduledAt"))
+		runs.Add(1)
+		return nil
+	}))
+	group.start()
+
+	assert.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, 5*time.Millisecond)
+	assert.NoError(t, group.stop(context.Background(), time.Second))
+
+	assert.Equal(t, "test-job", job.Load())
+	_, err := time.Parse(time.RFC3339, scheduledAt.Load().(string))
+	assert.NoError(t, err)
+
+	stopped := runs.Load()
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, stopped, runs.Load())
+}
+
+func TestScheduleGroupSkipsOverlappingActivations(t *testing.T) {
+	var runs atomic.Int32
+	release := make(chan struct{})
+
+	group := &scheduleGroup{}
+	group.add(newTestJob("@every 5ms", func(ctx IContext) error {
+		runs.Add(1)
+		<-release
+		return nil
+	}))
+	group.start()
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(1), runs.Load())
+
+	close(release)
+	assert.NoError(t, group.stop(context.Background(), time.Second))
+}
+
+func TestScheduleGroupStopWaitsForHandlers(t *testing.T) {
+	started := make(chan struct{})
+	var finished atomic.Bool
+
+	group := &scheduleGroup{}
+	group.add(newTestJob("@every 5ms", func(ctx IContext) error {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		time.Sleep(50 * time.Millisecond)
+		finished.Store(true)
+		return nil
+	}))
+	group.start()
+
+	<-started
+	assert.NoError(t, group.stop(context.Background(), time.Second))
+	assert.True(t, finished.Load())
+}
+
+func TestScheduleGroupStopTimeout(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	defer close(release)
+
+	group := &scheduleGroup{}
+	group.add(newTestJob("@every 5ms", func(ctx IContext) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}))
+	group.start()
+
+	<-started
+	assert.ErrorIs(t, group.stop(context.Background(), 10*time.Millisecond), context.DeadlineExceeded)
+}
+
+func TestScheduleGroupStopKeepsHandlerContext(t *testing.T) {
+	started := make(chan struct{}, 1)
+	var cancelled atomic.Bool
+
+	group := &scheduleGroup{}
+	group.add(newTestJob("@every 5ms", func(ctx IContext) error {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		time.Sleep(50 * time.Millisecond)
+		cancelled.Store(ctx.Context().Err() != nil)
+		return nil
+	}))
+	group.start()
+
+	<-started
+	assert.NoError(t, group.stop(context.Background(), time.Second))
+	assert.False(t, cancelled.Load())
+}
+
+func TestScheduleGroupStopTimeoutCancelsHandlers(t *testing.T) {
+	started := make(chan struct{}, 1)
+	var cancelled atomic.Bool
+
+	group := &scheduleGroup{}
+	group.add(newTestJob("@every 5ms", func(ctx IContext) error {
+		started <- struct{}{}
+		<-ctx.Context().Done()
+		cancelled.Store(true)
+		return nil
+	}))
+	group.start()
+
+	<-started
+	assert.ErrorIs(t, group.stop(context.Background(), 10*time.Millisecond), context.DeadlineExceeded)
+	assert.Eventually(t, cancelled.Load, time.Second, 5*time.Millisecond)
+}
+
+func TestScheduleRecoversPanics(t *testing.T) {
+	var runs atomic.Int32
+
+	group := &scheduleGroup{}
+	group.add(newTestJob("@every 5ms", func(ctx IContext) error {
+		runs.Add(1)
+		panic("boom")
+	}))
+	group.start()
+
+	assert.Eventually(t, func() bool { return runs.Load() >= 2 }, time.Second, 5*time.Millisecond)
+	assert.NoError(t, group.stop(context.Background(), time.Second))
+}
+
+func TestLeaderLock(t *testing.T) {
+	until := time.Now().Add(time.Minute)
+
+	t.Run("acquired", func(t *testing.T) {
+		database := &mongomocks.Database{}
+		collection := &mongomocks.Collection{}
+		database.On("Collection", DefaultSchedulerLockCollection).Return(collection).Once()
+		// mocks.Collection.UpdateOne delegates to UpdateMany
+		collection.On("UpdateMany", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&driver.UpdateResult{UpsertedCount: 1}, nil).Once()
+
+		acquired, err := newLeaderLock(database, DefaultSchedulerLockCollection).acquire(context.Background(), "purge", until)
+
+		assert.NoError(t, err)
+		assert.True(t, acquired)
+		database.AssertExpectations(t)
+		collection.AssertExpectations(t)
+	})
+
+	t.Run("held by another replica", func(t *testing.T) {
+		database := &mongomocks.Database{}
+		collection := &mongomocks.Collection{}
+		database.On("Collection", DefaultSchedulerLockCollection).Return(collection).Once()
+		duplicate := driver.WriteException{WriteErrors: driver.WriteErrors{{Code: 11000, Message: "duplicate key"}}}
+		collection.On("UpdateMany", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, duplicate).Once()
+
+		acquired, err := newLeaderLock(database, DefaultSchedulerLockCollection).acquire(context.Background(), "purge", until)
+
+		assert.NoError(t, err)
+		assert.False(t, acquired)
+	})
+
+	t.Run("skips the activation", func(t *testing.T) {
+		database := &mongomocks.Database{}
+		collection := &mongomocks.Collection{}
+		database.On("Collection", DefaultSchedulerLockCollection).Return(collection).Once()
+		duplicate := driver.WriteException{WriteErrors: driver.WriteErrors{{Code: 11000, Message: "duplicate key"}}}
+		collection.On("UpdateMany", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, duplicate).Once()
+
+		var runs atomic.Int32
+		job := newTestJob("@hourly", func(ctx IContext) error {
+			runs.Add(1)
+			return nil
+		})
+		job.lock = newLeaderLock(database, DefaultSchedulerLockCollection)
+		job.activate(context.Background(), time.Now())
+
+		assert.Equal(t, int32(0), runs.Load())
+	})
+}
@@ -12,6 +12,33 @@ func removeBraces(str string) string {
 
 type ContextKey string
 
+// bodyAllowed reports whether a response with the status code may carry a body.
+func bodyAllowed(code int) bool {
+	return code >= 200 && code != http.StatusNoContent && code != http.StatusNotModified
+}
+
+// colonPath rewrites "{name}" path segments to the ":name" form used by gin and echo.
+func colonPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			segments[i] = ":" + removeBraces(segment)
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// bracePath rewrites ":name" path segments to the "{name}" form used by http.ServeMux.
+func bracePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
 func setParam(path string, r *http.Request) *http.Request {
 	subPath := strings.Split(path, "/")
 	sss := strings.Split(r.URL.Path, "/")
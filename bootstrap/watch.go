@@ -0,0 +1,223 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	DefaultResumeTokenCollection = "change_stream_tokens"
+
+	defaultWatchRetryDelay    = time.Second
+	defaultWatchMaxRetryDelay = time.Minute
+
+	// changeStreamHistoryLost is the server error of a resume token that is no longer in the oplog.
+	changeStreamHistoryLost = 286
+)
+
+type resumeToken struct {
+	ID        string    `bson:"_id"`
+	Token     bson.Raw  `bson:"token"`
+	UpdatedAt time.Time `bson:"updatedAt"`
+}
+
+type changeStreamWatcher struct {
+	name        string
+	collection  string
+	pipeline    any
+	handler     ServiceHandleFunc
+	middlewares []Middleware
+	database    mongo.Database
+	tokens      string
+	producer    sarama.SyncProducer
+	log         ILogger
+	retryDelay  time.Duration
+}
+
+type watchGroup struct {
+	mutex    sync.Mutex
+	watchers []*changeStreamWatcher
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+func (g *watchGroup) add(w *changeStreamWatcher) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.watchers = append(g.watchers, w)
+}
+
+func (g *watchGroup) count(collection string) int {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	n := 0
+	for _, w := range g.watchers {
+		if w.collection == collection {
+			n++
+		}
+	}
+	return n
+}
+
+func (g *watchGroup) start() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if len(g.watchers) == 0 || g.done != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	g.cancel = cancel
+	g.done = make(chan struct{})
+
+	var wg sync.WaitGroup
+	for _, w := range g.watchers {
+		wg.Add(1)
+		go func(w *changeStreamWatcher) {
+			defer wg.Done()
+			w.run(ctx)
+		}(w)
+	}
+
+	go func() {
+		wg.Wait()
+		close(g.done)
+	}()
+}
+
+// stop cancels the change streams and waits for in-flight handlers to finish.
+func (g *watchGroup) stop(ctx context.Context, timeout time.Duration) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.cancel == nil {
+		return nil
+	}
+	g.cancel()
+	return wait(ctx, g.done, timeout)
+}
+
+// run restarts the change stream after an error, waiting twice as long after each error in a row up to
+// defaultWatchMaxRetryDelay. A stream that handled an event starts over from retryDelay.
+func (w *changeStreamWatcher) run(ctx context.Context) {
+	w.log.Println("Starting change stream on " + w.collection)
+	delay := w.retryDelay
+	for ctx.Err() == nil {
+		handled, err := w.watch(ctx)
+		if err == nil || ctx.Err() != nil {
+			continue
+		}
+		if handled > 0 {
+			delay = w.retryDelay
+		}
+
+		if isHistoryLost(err) {
+			w.log.Warnf("Change stream %s cannot resume, the events since its last token are skipped: %v", w.name, err)
+			if err = w.deleteToken(ctx); err == nil {
+				continue
+			}
+		}
+
+		w.log.Printf("Change stream %s error, retrying in %s: %v", w.name, delay, err)
+		select {
+		case <-ctx.Done():
+		case <-time.After(delay):
+		}
+		delay = min(2*delay, defaultWatchMaxRetryDelay)
+	}
+	w.log.Println("Stopping change stream on " + w.collection)
+}
+
+// watch handles the events of the stream and returns how many were handled. The resume token is saved
+// once the handler succeeds, an error of the handler ends the stream so that the event is delivered again
+// when the stream resumes.
+func (w *changeStreamWatcher) watch(ctx context.Context) (int, error) {
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+
+	token, err := w.loadToken(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if token != nil {
+		opts.SetResumeAfter(token)
+	}
+
+	stream, err := w.database.Collection(w.collection).Watch(ctx, w.pipeline, opts)
+	if err != nil {
+		return 0, err
+	}
+	defer stream.Close(context.Background())
+
+	handled := 0
+	handler := preHandle(HandleFunc(w.handler), w.middlewares...)
+	for stream.Next(ctx) {
+		var event bson.Raw
+		if err := stream.Decode(&event); err != nil {
+			return handled, err
+		}
+
+		c := newWatchContext(context.Background(), w.collection, event, w.producer, w.log)
+		if err := handler(c); err != nil {
+			return handled, fmt.Errorf("handler: %w", err)
+		}
+
+		if err := w.saveToken(stream.ResumeToken()); err != nil {
+			return handled, err
+		}
+		handled++
+	}
+
+	return handled, stream.Err()
+}
+
+// isHistoryLost reports that the stream cannot resume from its token, the oplog no longer holds it.
+func isHistoryLost(err error) bool {
+	var serverErr driver.ServerError
+	return errors.As(err, &serverErr) && serverErr.HasErrorCode(changeStreamHistoryLost)
+}
+
+func (w *changeStreamWatcher) loadToken(ctx context.Context) (bson.Raw, error) {
+	var doc resumeToken
+	err := w.database.Collection(w.tokens).FindOne(ctx, bson.M{"_id": w.name}).Decode(&doc)
+	if errors.Is(err, driver.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return doc.Token, nil
+}
+
+// deleteToken makes the next stream start from the current time.
+func (w *changeStreamWatcher) deleteToken(ctx context.Context) error {
+	_, err := w.database.Collection(w.tokens).DeleteOne(ctx, bson.M{"_id": w.name})
+	return err
+}
+
+// saveToken persists the resume token so a restart continues after the last handled event.
+func (w *changeStreamWatcher) saveToken(token bson.Raw) error {
+	if token == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultMongoPingTimeout)
+	defer cancel()
+
+	_, err := w.database.Collection(w.tokens).UpdateOne(ctx,
+		bson.M{"_id": w.name},
+		bson.M{"$set": bson.M{"token": token, "updatedAt": time.Now()}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
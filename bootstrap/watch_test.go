@@ -0,0 +1,197 @@
+package bootstrap
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mongomocks "github.com/sing3demons/go-backend-clean-architecture/mongo/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+type taskChange struct {
+	OperationType string `bson:"operationType"`
+	FullDocument  struct {
+		ID    primitive.ObjectID `bson:"_id"`
+		Title string             `bson:"title"`
+	} `bson:"fullDocument"`
+}
+
+func newTestWatcher(database *mongomocks.Database, handler ServiceHandleFunc) *changeStreamWatcher {
+	return &changeStreamWatcher{
+		name:        "tasks",
+		collection:  "tasks",
+		pipeline:    []any{},
+		handler:     handler,
+		middlewares: []Middleware{Recovery(RecoveryConfig{})},
+		database:    database,
+		tokens:      DefaultResumeTokenCollection,
+		log:         NewZapLogger(zap.NewNop()),
+		retryDelay:  time.Millisecond,
+	}
+}
+
+func TestChangeStreamWatcher(t *testing.T) {
+	id := primitive.NewObjectID()
+	event, _ := bson.Marshal(bson.M{
+		"operationType": "insert",
+		"fullDocument":  bson.M{"_id": id, "title": "title"},
+	})
+	token, _ := bson.Marshal(bson.M{"_data": "token-1"})
+
+	t.Run("handle event and save token", func(t *testing.T) {
+		database := &mongomocks.Database{}
+		tasks := &mongomocks.Collection{}
+		tokens := &mongomocks.Collection{}
+		stream := &mongomocks.ChangeStream{}
+
+		database.On("Collection", "tasks").Return(tasks)
+		database.On("Collection", DefaultResumeTokenCollection).Return(tokens)
+		tokens.On("FindOne", mock.Anything, bson.M{"_id": "tasks"}).Return(driver.NewSingleResultFromDocument(bson.M{"_id": "tasks", "token": bson.Raw(token)}, nil, nil)).Once()
+		tasks.On("Watch", mock.Anything, []any{}, mock.Anything).Return(stream, nil).Once()
+		stream.On("Next", mock.Anything).Return(true).Once()
+		stream.On("Next", mock.Anything).Return(false).Once()
+		stream.On("Decode", mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(0).(*bson.Raw) = event
+		}).Return(nil).Once()
+		stream.On("ResumeToken").Return(bson.Raw(token)).Once()
+		stream.On("Err").Return(nil).Once()
+		stream.On("Close", mock.Anything).Return(nil).Once()
+		// mocks.Collection.UpdateOne delegates to UpdateMany
+		tokens.On("UpdateMany", mock.Anything, bson.M{"_id": "tasks"}, mock.Anything, mock.Anything).Return(&driver.UpdateResult{UpsertedCount: 1}, nil).Once()
+
+		var change taskChange
+		var operationType string
+		w := newTestWatcher(database, func(ctx IContext) error {
+			operationType = ctx.Param("operationType")
+			return ctx.ReadInput(&change)
+		})
+
+		handled, err := w.watch(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, handled)
+		assert.Equal(t, "insert", operationType)
+		assert.Equal(t, id, change.FullDocument.ID)
+		assert.Equal(t, "title", change.FullDocument.Title)
+
+		stream.AssertExpectations(t)
+		tokens.AssertExpectations(t)
+	})
+
+	t.Run("handler error keeps the token", func(t *testing.T) {
+		database := &mongomocks.Database{}
+		tasks := &mongomocks.Collection{}
+		tokens := &mongomocks.Collection{}
+		stream := &mongomocks.ChangeStream{}
+		noToken := &mongomocks.SingleResult{}
+
+		database.On("Collection", "tasks").Return(tasks)
+		database.On("Collection", DefaultResumeTokenCollection).Return(tokens)
+		noToken.On("Decode", mock.Anything).Return(driver.ErrNoDocuments).Once()
+		tokens.On("FindOne", mock.Anything, bson.M{"_id": "tasks"}).Return(noToken).Once()
+		tasks.On("Watch", mock.Anything, []any{}, mock.Anything).Return(stream, nil).Once()
+		stream.On("Next", mock.Anything).Return(true).Once()
+		stream.On("Decode", mock.Anything).Return(nil).Once()
+		stream.On("Close", mock.Anything).Return(nil).Once()
+
+		w := newTestWatcher(database, func(ctx IContext) error {
+			panic("boom")
+		})
+
+		handled, err := w.watch(context.Background())
+		var panicErr *PanicError
+		assert.ErrorAs(t, err, &panicErr)
+		assert.Equal(t, 0, handled)
+		stream.AssertExpectations(t)
+		tokens.AssertNotCalled(t, "UpdateMany", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("history lost deletes the token", func(t *testing.T) {
+		database := &mongomocks.Database{}
+		tasks := &mongomocks.Collection{}
+		tokens := &mongomocks.Collection{}
+		noToken := &mongomocks.SingleResult{}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		database.On("Collection", "tasks").Return(tasks)
+		database.On("Collection", DefaultResumeTokenCollection).Return(tokens)
+		tokens.On("FindOne", mock.Anything, bson.M{"_id": "tasks"}).Return(driver.NewSingleResultFromDocument(bson.M{"_id": "tasks", "token": bson.Raw(token)}, nil, nil)).Once()
+		tasks.On("Watch", mock.Anything, []any{}, mock.Anything).Return(nil, driver.CommandError{Code: 286, Name: "ChangeStreamHistoryLost"}).Once()
+		tokens.On("DeleteOne", mock.Anything, bson.M{"_id": "tasks"}).Return(int64(1), nil).Once()
+		// the restarted stream starts without a token
+		noToken.On("Decode", mock.Anything).Return(driver.ErrNoDocuments).Once()
+		tokens.On("FindOne", mock.Anything, bson.M{"_id": "tasks"}).Return(noToken).Once()
+		tasks.On("Watch", mock.Anything, []any{}, mock.Anything).Run(func(mock.Arguments) {
+			cancel()
+		}).Return(nil, context.Canceled).Once()
+
+		w := newTestWatcher(database, func(ctx IContext) error { return nil })
+		w.retryDelay = time.Hour
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			w.run(ctx)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("the stream did not restart at once")
+		}
+		tokens.AssertExpectations(t)
+	})
+
+	t.Run("watch error", func(t *testing.T) {
+		database := &mongomocks.Database{}
+		tasks := &mongomocks.Collection{}
+		tokens := &mongomocks.Collection{}
+		noToken := &mongomocks.SingleResult{}
+
+		database.On("Collection", "tasks").Return(tasks)
+		database.On("Collection", DefaultResumeTokenCollection).Return(tokens)
+		noToken.On("Decode", mock.Anything).Return(driver.ErrNoDocuments)
+		tokens.On("FindOne", mock.Anything, bson.M{"_id": "tasks"}).Return(noToken)
+		tasks.On("Watch", mock.Anything, []any{}, mock.Anything).Return(nil, assert.AnError)
+
+		w := newTestWatcher(database, func(ctx IContext) error { return nil })
+
+		_, err := w.watch(context.Background())
+		assert.ErrorIs(t, err, assert.AnError)
+
+		group := &watchGroup{}
+		group.add(w)
+		group.start()
+		time.Sleep(10 * time.Millisecond)
+		assert.NoError(t, group.stop(context.Background(), time.Second))
+	})
+}
+
+func TestApplicationWatch(t *testing.T) {
+	client := &mongomocks.Client{}
+	client.On("Ping", mock.Anything).Return(nil)
+	client.On("Database", "test").Return(&mongomocks.Database{})
+
+	app := NewApplication(&Config{
+		MongoConfig: MongoConfig{
+			Database: "test",
+			client:   client,
+		},
+	}, NewZapLogger(zap.NewNop()))
+
+	handler := func(ctx IContext) error { return nil }
+	app.Watch("tasks", nil, handler)
+	app.Watch("tasks", nil, handler)
+
+	watchers := app.(*Server).watchers.watchers
+	assert.Len(t, watchers, 2)
+	assert.Equal(t, "tasks", watchers[0].name)
+	assert.Equal(t, "tasks#2", watchers[1].name)
+	assert.Equal(t, DefaultResumeTokenCollection, watchers[0].tokens)
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CollectionTaskActivity = "task_activity"
+)
+
+type ActivityAction string
+
+const (
+	ActivityCreated       ActivityAction = "created"
+	ActivityUpdated       ActivityAction = "updated"
+	ActivityStatusChanged ActivityAction = "status_changed"
+	ActivityDeleted       ActivityAction = "deleted"
+	ActivityRestored      ActivityAction = "restored"
+)
+
+// TaskActivity is one entry of the append-only history of a task, entries are never updated or removed.
+type TaskActivity struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	TaskID primitive.ObjectID `bson:"taskID" json:"taskId"`
+	Action ActivityAction     `bson:"action" json:"action"`
+	// Actor and At are set from the request context and the clock when the entry is appended.
+	Actor string    `bson:"actor,omitempty" json:"actor,omitempty"`
+	At    time.Time `bson:"at" json:"at"`
+	// Version is the version of the task after the change, zero when the change does not bump it.
+	Version int64         `bson:"version,omitempty" json:"version,omitempty"`
+	Changes []FieldChange `bson:"changes,omitempty" json:"changes,omitempty"`
+}
+
+func (a *TaskActivity) GetID() primitive.ObjectID {
+	return a.ID
+}
+
+func (a *TaskActivity) SetID(id primitive.ObjectID) {
+	a.ID = id
+}
+
+func (a *TaskActivity) SetCreated(at time.Time, by string) {
+	a.At, a.Actor = at, by
+}
+
+// FieldChange is a task field before and after a change, formatted as text. An empty value is an unset
+// field.
+type FieldChange struct {
+	Field string `bson:"field" json:"field"`
+	From  string `bson:"from,omitempty" json:"from"`
+	To    string `bson:"to,omitempty" json:"to"`
+}
+
+// HistoryFields are the task fields tracked by the history, in the order their changes are listed.
+var HistoryFields = []string{
+	"title", "description", "status", "priority", "dueDate", "recurrence", "timeZone", "parentId",
+	"blockedBy", "completedAt", "projectId",
+}
+
+// TaskChanges lists the tracked fields that differ between before and after, a created task is diffed
+// against the zero Task.
+func TaskChanges(before, after Task) []FieldChange {
+	from, to := historyValues(before), historyValues(after)
+
+	var changes []FieldChange
+	for i, field := range HistoryFields {
+		if from[i] != to[i] {
+			changes = append(changes, FieldChange{Field: field, From: from[i], To: to[i]})
+		}
+	}
+	return changes
+}
+
+// historyValues formats the fields of task in the order of HistoryFields.
+func historyValues(task Task) []string {
+	blockedBy := make([]string, len(task.BlockedBy))
+	for i, id := range task.BlockedBy {
+		blockedBy[i] = id.Hex()
+	}
+
+	return []string{
+		task.Title,
+		task.Description,
+		string(task.Status),
+		task.Priority.String(),
+		historyTime(task.DueDate),
+		task.Recurrence,
+		task.TimeZone,
+		historyID(task.ParentID),
+		strings.Join(blockedBy, " "),
+		historyTime(task.CompletedAt),
+		historyID(task.ProjectID),
+	}
+}
+
+func historyTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func historyID(id primitive.ObjectID) string {
+	if id.IsZero() {
+		return ""
+	}
+	return id.Hex()
+}
+
+type TaskActivityRepository interface {
+	// Append adds activities to the history, in one write.
+	Append(c context.Context, activities ...TaskActivity) error
+	// FetchByTaskID lists the history of the task newest first, with the total number of entries.
+	FetchByTaskID(c context.Context, taskID primitive.ObjectID, page PageRequest) ([]TaskActivity, int64, error)
+}
+
+type TaskActivityUsecase interface {
+	// FetchByTaskID lists the history of a live or deleted task, newest first.
+	FetchByTaskID(c context.Context, taskID string, page PageRequest) (Page[TaskActivity], error)
+}
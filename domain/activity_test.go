@@ -0,0 +1,38 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTaskChanges(t *testing.T) {
+	due := time.Date(2025, 3, 1, 9, 0, 0, 0, time.FixedZone("ICT", 7*60*60))
+	blocker := primitive.NewObjectID()
+
+	before := domain.Task{Title: "Draft", Description: "notes", Status: domain.StatusTodo, Version: 1}
+	after := domain.Task{
+		Title:     "Draft",
+		Status:    domain.StatusTodo,
+		Priority:  domain.PriorityHigh,
+		DueDate:   &due,
+		BlockedBy: []primitive.ObjectID{blocker},
+		Version:   2,
+	}
+
+	assert.Equal(t, []domain.FieldChange{
+		{Field: "description", From: "notes"},
+		{Field: "priority", To: "high"},
+		{Field: "dueDate", To: "2025-03-01T02:00:00Z"},
+		{Field: "blockedBy", To: blocker.Hex()},
+	}, domain.TaskChanges(before, after))
+
+	assert.Empty(t, domain.TaskChanges(after, after))
+	assert.Equal(t, []domain.FieldChange{
+		{Field: "title", To: "Draft"},
+		{Field: "status", To: "todo"},
+	}, domain.TaskChanges(domain.Task{}, domain.Task{Title: "Draft", Status: domain.StatusTodo}))
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"mime"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// BucketAttachment is the GridFS bucket of attachment contents, the attachments are the
+	// documents of its files collection.
+	BucketAttachment = "attachments"
+
+	// MaxAttachmentFilename bounds the file name of an attachment in bytes.
+	MaxAttachmentFilename = 255
+)
+
+// AttachmentPolicy limits the uploads accepted as attachments.
+type AttachmentPolicy struct {
+	// MaxSize is the largest attachment in bytes.
+	MaxSize int64
+	// ContentTypes lists the accepted media types without parameters.
+	ContentTypes []string
+}
+
+var DefaultAttachmentPolicy = AttachmentPolicy{
+	MaxSize: 10 << 20,
+	ContentTypes: []string{
+		"application/pdf",
+		"application/zip",
+		"image/gif",
+		"image/jpeg",
+		"image/png",
+		"image/webp",
+		"text/csv",
+		"text/plain",
+	},
+}
+
+// Allows reports whether the media type of contentType is accepted, parameters such as charset are ignored.
+func (p AttachmentPolicy) Allows(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range p.ContentTypes {
+		if mediaType == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// Attachment is the GridFS file document of an uploaded file.
+type Attachment struct {
+	ID                 primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Filename           string             `bson:"filename" json:"filename"`
+	Size               int64              `bson:"length" json:"size"`
+	UploadedAt         time.Time          `bson:"uploadDate" json:"uploadedAt"`
+	AttachmentMetadata `bson:"metadata"`
+}
+
+type AttachmentMetadata struct {
+	TaskID      primitive.ObjectID `bson:"taskID" json:"taskId"`
+	ContentType string             `bson:"contentType" json:"contentType"`
+	// UploadedBy is the authenticated user who uploaded the file.
+	UploadedBy string `bson:"uploadedBy" json:"uploadedBy"`
+}
+
+// AttachmentContent streams the content of an attachment, Skip serves range requests.
+type AttachmentContent interface {
+	io.ReadCloser
+	Skip(n int64) (int64, error)
+}
+
+type AttachmentRepository interface {
+	// Upload stores content and sets the ID, Size and UploadedAt of the attachment.
+	Upload(c context.Context, attachment *Attachment, content io.Reader) error
+	// FetchByTaskID lists the attachments of the task oldest first.
+	FetchByTaskID(c context.Context, taskID primitive.ObjectID) ([]Attachment, error)
+	FetchByID(c context.Context, taskID primitive.ObjectID, attachmentID string) (Attachment, error)
+	Open(c context.Context, attachmentID primitive.ObjectID) (AttachmentContent, error)
+	Delete(c context.Context, attachmentID primitive.ObjectID) error
+}
+
+type AttachmentUsecase interface {
+	// Upload attaches content to the task as the actor of c. Uploads over the size limit fail with
+	// ErrTooLarge and uploads of other content types with ErrUnsupportedMediaType.
+	Upload(c context.Context, taskID string, attachment *Attachment, content io.Reader) error
+	FetchByTaskID(c context.Context, taskID string) ([]Attachment, error)
+	// Open returns the attachment with its content, the caller closes the content.
+	Open(c context.Context, taskID, attachmentID string) (Attachment, AttachmentContent, error)
+	// Delete removes an attachment, only its uploader may delete it.
+	Delete(c context.Context, taskID, attachmentID string) error
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DefaultMaxBulkOperations caps the operations of one bulk request unless configured otherwise.
+const DefaultMaxBulkOperations = 100
+
+type BulkAction string
+
+const (
+	BulkCreate BulkAction = "create"
+	BulkUpdate BulkAction = "update"
+	BulkDelete BulkAction = "delete"
+)
+
+func (a BulkAction) Valid() bool {
+	switch a {
+	case BulkCreate, BulkUpdate, BulkDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// BulkOperation is one write of a bulk request. Create and update carry the task, update and delete
+// the id of the task they apply to. An update is rejected with ErrConflict unless Task.Version is current.
+type BulkOperation struct {
+	Action BulkAction `json:"action"`
+	ID     string     `json:"id,omitempty"`
+	Task   *Task      `json:"task,omitempty"`
+}
+
+// BulkResult reports the outcome of the operation at Index, Err is nil when it was applied.
+type BulkResult struct {
+	Index   int
+	Action  BulkAction
+	ID      primitive.ObjectID
+	Version int64
+	Err     error
+}
+
+// BulkOptions controls how a bulk request runs. An ordered request stops at the first failed operation
+// and reports the rest with ErrSkipped, an unordered one attempts every operation.
+type BulkOptions struct {
+	Ordered bool
+}
+
+type TaskBulkRepository interface {
+	// BulkWrite applies operations in a single round trip and returns one result per operation, in order.
+	BulkWrite(c context.Context, operations []BulkOperation, opts BulkOptions) ([]BulkResult, error)
+}
+
+type TaskBulkUsecase interface {
+	// Bulk validates every operation and writes the valid ones, the error is reserved for failures of
+	// the whole request such as too many operations.
+	Bulk(c context.Context, operations []BulkOperation, opts BulkOptions) ([]BulkResult, error)
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// Clock abstracts the current time so timestamps are deterministic in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CollectionComment = "comments"
+
+	// MaxCommentLength bounds the body of a comment in characters.
+	MaxCommentLength = 10000
+)
+
+type Comment struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	TaskID primitive.ObjectID `bson:"taskID" json:"taskId"`
+	// Author is the authenticated user who wrote the comment, it is not read from the request body.
+	Author    string    `bson:"author" json:"author"`
+	Body      string    `bson:"body" json:"body"`
+	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
+}
+
+func (c *Comment) GetID() primitive.ObjectID {
+	return c.ID
+}
+
+func (c *Comment) SetID(id primitive.ObjectID) {
+	c.ID = id
+}
+
+func (c *Comment) SetCreated(at time.Time, by string) {
+	c.CreatedAt, c.Author = at, by
+}
+
+type CommentRepository interface {
+	Create(c context.Context, comment *Comment) error
+	// FetchByTaskID lists the comments of the task oldest first, with the total number of comments.
+	FetchByTaskID(c context.Context, taskID primitive.ObjectID, page PageRequest) ([]Comment, int64, error)
+	FetchByID(c context.Context, taskID primitive.ObjectID, commentID string) (Comment, error)
+	Delete(c context.Context, commentID string) error
+}
+
+type CommentUsecase interface {
+	// Create adds a comment by the actor of c to the task and publishes TopicTaskCommented.
+	Create(c context.Context, taskID string, comment *Comment) error
+	FetchByTaskID(c context.Context, taskID string, page PageRequest) (Page[Comment], error)
+	// Delete removes a comment, only its author may delete it.
+	Delete(c context.Context, taskID, commentID string) error
+}
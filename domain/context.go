@@ -0,0 +1,15 @@
+package domain
+
+import "context"
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx carrying the id of the user performing the request.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
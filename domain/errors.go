@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNotFound  = errors.New("not found")
+	ErrInvalidID = errors.New("invalid id")
+	// ErrUnauthenticated reports a request without a user where one is required.
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrForbidden reports a user acting on a resource they do not own.
+	ErrForbidden = errors.New("forbidden")
+	// ErrConflict reports a write against a stale version of a document.
+	ErrConflict = errors.New("version conflict")
+	// ErrValidation matches every ValidationError with errors.Is.
+	ErrValidation = errors.New("validation failed")
+	// ErrEventNotPublished reports a change that was saved but whose event could not be published.
+	ErrEventNotPublished = errors.New("event not published")
+	// ErrDuplicate reports an insert of a document that already exists.
+	ErrDuplicate = errors.New("already exists")
+	// ErrTooLarge reports an upload over the size limit.
+	ErrTooLarge = errors.New("too large")
+	// ErrUnsupportedMediaType reports an upload whose content type is not accepted.
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	// ErrSkipped reports a bulk operation that did not run because an earlier one failed in ordered mode.
+	ErrSkipped = errors.New("skipped after an earlier failure")
+)
+
+// ValidationError reports input that breaks a domain rule.
+type ValidationError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	TopicTaskStatusChanged = "task.status_changed"
+	TopicTaskReminder      = "task.reminder"
+	TopicTaskCommented     = "task.commented"
+)
+
+// EventPublisher publishes domain events, key orders the events of one aggregate.
+type EventPublisher interface {
+	Publish(c context.Context, topic, key string, payload any) error
+}
+
+type TaskStatusChanged struct {
+	TaskID string     `json:"taskId"`
+	From   TaskStatus `json:"from"`
+	To     TaskStatus `json:"to"`
+	Actor  string     `json:"actor,omitempty"`
+	At     time.Time  `json:"at"`
+}
+
+type TaskReminder struct {
+	TaskID   string       `json:"taskId"`
+	Title    string       `json:"title"`
+	UserID   string       `json:"userId,omitempty"`
+	Priority TaskPriority `json:"priority,omitempty"`
+	DueDate  time.Time    `json:"dueDate"`
+	At       time.Time    `json:"at"`
+}
+
+type TaskCommented struct {
+	TaskID    string    `json:"taskId"`
+	CommentID string    `json:"commentId"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	At        time.Time `json:"at"`
+}
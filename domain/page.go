@@ -0,0 +1,31 @@
+package domain
+
+const (
+	DefaultPageSize int64 = 20
+	MaxPageSize     int64 = 100
+)
+
+// PageRequest selects a 1-based page of a listing.
+type PageRequest struct {
+	Page int64
+	Size int64
+}
+
+// Normalize applies the defaults to a zero page or size and caps the size at MaxPageSize.
+func (p PageRequest) Normalize() PageRequest {
+	if p.Page < 1 {
+		p.Page = 1
+	}
+	if p.Size < 1 {
+		p.Size = DefaultPageSize
+	}
+	p.Size = min(p.Size, MaxPageSize)
+	return p
+}
+
+type Page[T any] struct {
+	Items []T   `json:"items"`
+	Page  int64 `json:"page"`
+	Size  int64 `json:"size"`
+	Total int64 `json:"total"`
+}
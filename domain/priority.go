@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TaskPriority is stored as a number so that tasks sort by urgency, it reads and writes as its name in JSON.
+type TaskPriority int
+
+const (
+	PriorityNone TaskPriority = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+	PriorityUrgent
+)
+
+var priorityNames = map[TaskPriority]string{
+	PriorityLow:    "low",
+	PriorityMedium: "medium",
+	PriorityHigh:   "high",
+	PriorityUrgent: "urgent",
+}
+
+func ParseTaskPriority(name string) (TaskPriority, error) {
+	for p, n := range priorityNames {
+		if strings.EqualFold(n, name) {
+			return p, nil
+		}
+	}
+	return PriorityNone, &ValidationError{Field: "priority", Message: fmt.Sprintf("unknown priority %q", name)}
+}
+
+func (p TaskPriority) String() string {
+	return priorityNames[p]
+}
+
+func (p TaskPriority) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *TaskPriority) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*p = PriorityNone
+		return nil
+	}
+
+	priority, err := ParseTaskPriority(string(text))
+	if err != nil {
+		return err
+	}
+	*p = priority
+	return nil
+}
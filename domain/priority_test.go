@@ -0,0 +1,31 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestTaskPriorityJSON(t *testing.T) {
+	data, err := json.Marshal(domain.Task{Title: "title", Priority: domain.PriorityHigh})
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"priority":"high"`)
+
+	var task domain.Task
+	assert.NoError(t, json.Unmarshal([]byte(`{"title":"title","priority":"Urgent"}`), &task))
+	assert.Equal(t, domain.PriorityUrgent, task.Priority)
+
+	err = json.Unmarshal([]byte(`{"priority":"critical"}`), &task)
+	assert.ErrorIs(t, err, domain.ErrValidation)
+}
+
+func TestTaskPriorityBSON(t *testing.T) {
+	data, err := bson.Marshal(domain.Task{Priority: domain.PriorityMedium})
+	assert.NoError(t, err)
+
+	value := bson.Raw(data).Lookup("priority")
+	assert.Equal(t, int32(domain.PriorityMedium), value.Int32())
+}
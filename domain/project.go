@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CollectionProject = "projects"
+)
+
+// Project groups tasks within a workspace, the members of the workspace see the project and its tasks.
+// To other users a task of the project is not found, listings and searches leave it out.
+//
+// Archiving a project archives its open tasks. An archived project takes no task writes: a task cannot
+// be created in it, moved to it or edited, and its tasks cannot leave StatusArchived. Unarchiving the
+// project leaves its tasks archived, they are reopened one by one.
+type Project struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	WorkspaceID primitive.ObjectID `bson:"workspaceID" json:"workspaceId"`
+	Name        string             `bson:"name" json:"name"`
+	Description string             `bson:"description,omitempty" json:"description,omitempty"`
+	// Version is incremented by every update, a write with a stale version fails with ErrConflict.
+	Version    int64      `bson:"version,omitempty" json:"version"`
+	ArchivedAt *time.Time `bson:"archivedAt,omitempty" json:"archivedAt,omitempty"`
+
+	CreatedAt time.Time `bson:"createdAt,omitempty" json:"createdAt"`
+	UpdatedAt time.Time `bson:"updatedAt,omitempty" json:"updatedAt"`
+	CreatedBy string    `bson:"createdBy,omitempty" json:"createdBy,omitempty"`
+	UpdatedBy string    `bson:"updatedBy,omitempty" json:"updatedBy,omitempty"`
+}
+
+func (p *Project) GetID() primitive.ObjectID {
+	return p.ID
+}
+
+func (p *Project) SetID(id primitive.ObjectID) {
+	p.ID = id
+}
+
+func (p *Project) SetCreated(at time.Time, by string) {
+	p.CreatedAt, p.CreatedBy = at, by
+	p.UpdatedAt, p.UpdatedBy = at, by
+}
+
+func (p Project) Archived() bool {
+	return p.ArchivedAt != nil
+}
+
+// TaskScope is the part of the tasks a user reads and writes: the tasks without a project and the tasks
+// of ProjectIDs, the projects of the workspaces of the user. AllProjects lifts the limit.
+type TaskScope struct {
+	AllProjects bool
+	ProjectIDs  []primitive.ObjectID
+}
+
+func (s TaskScope) Includes(task Task) bool {
+	return s.AllProjects || task.ProjectID.IsZero() || slices.Contains(s.ProjectIDs, task.ProjectID)
+}
+
+type ProjectRepository interface {
+	Create(c context.Context, project *Project) error
+	FetchByID(c context.Context, projectID string) (Project, error)
+	// FetchByWorkspaceID lists the projects of the workspace by name, archived ones only when asked.
+	FetchByWorkspaceID(c context.Context, workspaceID primitive.ObjectID, archived bool) ([]Project, error)
+	// FetchByWorkspaceIDs lists the projects of the workspaces, archived or not.
+	FetchByWorkspaceIDs(c context.Context, workspaceIDs []primitive.ObjectID) ([]Project, error)
+	// Update writes the name and description of project when project.Version matches and reloads
+	// project from the result.
+	Update(c context.Context, project *Project) error
+	// SetArchived archives or unarchives project when project.Version matches and reloads project from
+	// the result.
+	SetArchived(c context.Context, project *Project, archived bool) error
+}
+
+type TaskProjectRepository interface {
+	// FetchByProjectID lists the live tasks of the project oldest first, with the total number of tasks.
+	FetchByProjectID(c context.Context, projectID primitive.ObjectID, page PageRequest) ([]Task, int64, error)
+	// FetchOpenByProjectID lists the live tasks of the project that are not closed, oldest first.
+	FetchOpenByProjectID(c context.Context, projectID primitive.ObjectID) ([]Task, error)
+}
+
+type ProjectUsecase interface {
+	// Create adds a project to a workspace of the actor of c.
+	Create(c context.Context, workspaceID string, project *Project) error
+	// FetchByID reports ErrNotFound for a project outside the workspaces of the actor of c.
+	FetchByID(c context.Context, projectID string) (Project, error)
+	FetchByWorkspaceID(c context.Context, workspaceID string, archived bool) ([]Project, error)
+	Update(c context.Context, project *Project) error
+	// Archive archives the project and its open tasks in one unit of work, only workspace owners archive
+	// projects. Each archived task has its history recorded and a TaskStatusChanged event published.
+	Archive(c context.Context, projectID string) (Project, error)
+	Unarchive(c context.Context, projectID string) (Project, error)
+	// FetchTasks lists the live tasks of the project oldest first.
+	FetchTasks(c context.Context, projectID string, page PageRequest) (Page[Task], error)
+}
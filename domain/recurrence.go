@@ -0,0 +1,507 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+	FrequencyYearly  Frequency = "YEARLY"
+)
+
+// maxRecurrencePeriods bounds the search for the next occurrence of rules that rarely or never match,
+// such as the 29th of February or the 31st of a month limited to BYMONTH=2.
+const maxRecurrencePeriods = 4000
+
+// RecurrenceWeekday is a BYDAY entry, N selects the Nth (or Nth last when negative) weekday of the
+// month or year and is zero for every weekday.
+type RecurrenceWeekday struct {
+	N       int
+	Weekday time.Weekday
+}
+
+// RecurrenceRule is the subset of an RFC 5545 RRULE supported for tasks: FREQ of DAILY to YEARLY,
+// INTERVAL, COUNT or UNTIL, BYDAY, BYMONTHDAY, BYMONTH and WKST.
+type RecurrenceRule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []RecurrenceWeekday
+	ByMonthDay []int
+	ByMonth    []time.Month
+	WeekStart  time.Weekday
+
+	// untilFloating marks an UNTIL without the UTC designator, it is read in the time zone of the task.
+	untilFloating bool
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+func recurrenceError(format string, args ...any) error {
+	return &ValidationError{Field: "recurrence", Message: fmt.Sprintf(format, args...)}
+}
+
+// ParseRecurrenceRule parses rule with or without the "RRULE:" prefix, errors are ValidationErrors.
+func ParseRecurrenceRule(rule string) (RecurrenceRule, error) {
+	r := RecurrenceRule{Interval: 1, WeekStart: time.Monday}
+
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	if rule == "" {
+		return r, recurrenceError("rule is empty")
+	}
+
+	seen := map[string]bool{}
+	for _, part := range strings.Split(rule, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(name)
+		if !ok || value == "" {
+			return r, recurrenceError("invalid rule part %q", part)
+		}
+		if seen[name] {
+			return r, recurrenceError("%s is repeated", name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			r.Freq = Frequency(strings.ToUpper(value))
+			switch r.Freq {
+			case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+			default:
+				return r, recurrenceError("unsupported FREQ %q", value)
+			}
+		case "INTERVAL":
+			r.Interval, err = parseRecurrenceInt(name, value, 1, 1000)
+		case "COUNT":
+			r.Count, err = parseRecurrenceInt(name, value, 1, 100000)
+		case "UNTIL":
+			r.Until, r.untilFloating, err = parseRecurrenceUntil(value)
+		case "BYDAY":
+			r.ByDay, err = parseRecurrenceWeekdays(value)
+		case "BYMONTHDAY":
+			for _, v := range strings.Split(value, ",") {
+				day, err := parseRecurrenceInt(name, v, -31, 31)
+				if err != nil {
+					return r, err
+				}
+				if day == 0 {
+					return r, recurrenceError("BYMONTHDAY must not be 0")
+				}
+				r.ByMonthDay = append(r.ByMonthDay, day)
+			}
+		case "BYMONTH":
+			for _, v := range strings.Split(value, ",") {
+				month, err := parseRecurrenceInt(name, v, 1, 12)
+				if err != nil {
+					return r, err
+				}
+				r.ByMonth = append(r.ByMonth, time.Month(month))
+			}
+		case "WKST":
+			weekday, ok := rruleWeekdays[strings.ToUpper(value)]
+			if !ok {
+				return r, recurrenceError("invalid WKST %q", value)
+			}
+			r.WeekStart = weekday
+		default:
+			return r, recurrenceError("unsupported rule part %s", name)
+		}
+		if err != nil {
+			return r, err
+		}
+	}
+
+	if r.Freq == "" {
+		return r, recurrenceError("FREQ is required")
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return r, recurrenceError("COUNT and UNTIL must not both be set")
+	}
+	if r.Freq == FrequencyWeekly && len(r.ByMonthDay) > 0 {
+		return r, recurrenceError("BYMONTHDAY is not allowed with FREQ=WEEKLY")
+	}
+	for _, day := range r.ByDay {
+		if day.N != 0 && r.Freq != FrequencyMonthly && r.Freq != FrequencyYearly {
+			return r, recurrenceError("BYDAY ordinals are only allowed with FREQ=MONTHLY or FREQ=YEARLY")
+		}
+		if day.N < -5 || day.N > 5 {
+			if r.Freq == FrequencyMonthly || len(r.ByMonth) > 0 {
+				return r, recurrenceError("BYDAY ordinal %d is out of range", day.N)
+			}
+		}
+	}
+
+	return r, nil
+}
+
+func parseRecurrenceInt(name, value string, min, max int) (int, error) {
+	v, err := strconv.Atoi(value)
+	if err != nil || v < min || v > max {
+		return 0, recurrenceError("%s %q is not between %d and %d", name, value, min, max)
+	}
+	return v, nil
+}
+
+func parseRecurrenceUntil(value string) (time.Time, bool, error) {
+	if until, err := time.Parse("20060102T150405Z", value); err == nil {
+		return until, false, nil
+	}
+	if until, err := time.Parse("20060102T150405", value); err == nil {
+		return until, true, nil
+	}
+	if until, err := time.Parse("20060102", value); err == nil {
+		// A date includes the whole day
+		return until.Add(24*time.Hour - time.Second), true, nil
+	}
+	return time.Time{}, false, recurrenceError("invalid UNTIL %q", value)
+}
+
+func parseRecurrenceWeekdays(value string) ([]RecurrenceWeekday, error) {
+	var days []RecurrenceWeekday
+	for _, v := range strings.Split(strings.ToUpper(value), ",") {
+		if len(v) < 2 {
+			return nil, recurrenceError("invalid BYDAY %q", v)
+		}
+
+		weekday, ok := rruleWeekdays[v[len(v)-2:]]
+		if !ok {
+			return nil, recurrenceError("invalid BYDAY %q", v)
+		}
+
+		n := 0
+		if ordinal := v[:len(v)-2]; ordinal != "" {
+			var err error
+			if n, err = strconv.Atoi(ordinal); err != nil || n == 0 || n < -53 || n > 53 {
+				return nil, recurrenceError("invalid BYDAY %q", v)
+			}
+		}
+		days = append(days, RecurrenceWeekday{N: n, Weekday: weekday})
+	}
+	return days, nil
+}
+
+// Next returns the first occurrence after the time after of the series starting at start, the DTSTART
+// of the rule. The wall clock time of start in location is kept across DST changes, start also supplies
+// the day when the rule has no BYDAY or BYMONTHDAY and aligns INTERVAL. A wall clock time skipped by
+// DST resolves to the same time after the shift. Next reports false when UNTIL ends the series, COUNT
+// is tracked by the caller.
+func (r RecurrenceRule) Next(start, after time.Time, location *time.Location) (time.Time, bool) {
+	if location == nil {
+		location = time.UTC
+	}
+	start = start.In(location)
+
+	until := r.Until
+	if r.untilFloating {
+		until = time.Date(until.Year(), until.Month(), until.Day(), until.Hour(), until.Minute(), until.Second(), 0, location)
+	}
+
+	// Dates are computed in UTC so that a DST change does not move them, then placed in location.
+	anchor := civilDate(start)
+	period := r.periodStart(anchor)
+	if from := civilDate(after.In(location)); from.After(anchor) {
+		skip := r.periodsBetween(period, r.periodStart(from))
+		period = r.advance(period, skip-skip%r.Interval)
+	}
+
+	for i := 0; i < maxRecurrencePeriods; i++ {
+		for _, day := range r.expand(period, anchor) {
+			occurrence := wallClockTime(day, start, location)
+			if !occurrence.After(after) {
+				continue
+			}
+			if !until.IsZero() && occurrence.After(until) {
+				return time.Time{}, false
+			}
+			return occurrence, true
+		}
+		period = r.advance(period, r.Interval)
+	}
+
+	return time.Time{}, false
+}
+
+// wallClockTime places the time of day of clock on day in location. A time repeated when DST ends is
+// its first occurrence and a time skipped when DST starts uses the UTC offset before the gap, as in
+// RFC 5545, which moves it forward by the length of the gap.
+func wallClockTime(day, clock time.Time, location *time.Location) time.Time {
+	t := time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, location)
+	if t.Hour() == clock.Hour() && t.Minute() == clock.Minute() {
+		return t
+	}
+
+	_, before := t.Add(-24 * time.Hour).Zone()
+	wall := time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, time.UTC)
+	return wall.Add(-time.Duration(before) * time.Second).In(location)
+}
+
+func civilDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (r RecurrenceRule) periodStart(day time.Time) time.Time {
+	switch r.Freq {
+	case FrequencyWeekly:
+		offset := (int(day.Weekday()) - int(r.WeekStart) + 7) % 7
+		return day.AddDate(0, 0, -offset)
+	case FrequencyMonthly:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case FrequencyYearly:
+		return time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return day
+}
+
+func (r RecurrenceRule) advance(period time.Time, n int) time.Time {
+	switch r.Freq {
+	case FrequencyWeekly:
+		return period.AddDate(0, 0, 7*n)
+	case FrequencyMonthly:
+		return period.AddDate(0, n, 0)
+	case FrequencyYearly:
+		return period.AddDate(n, 0, 0)
+	}
+	return period.AddDate(0, 0, n)
+}
+
+func (r RecurrenceRule) periodsBetween(from, to time.Time) int {
+	switch r.Freq {
+	case FrequencyWeekly:
+		return int(to.Sub(from).Hours()) / (7 * 24)
+	case FrequencyMonthly:
+		return (to.Year()-from.Year())*12 + int(to.Month()-from.Month())
+	case FrequencyYearly:
+		return to.Year() - from.Year()
+	}
+	return int(to.Sub(from).Hours()) / 24
+}
+
+// expand lists the days of the period matching the rule in ascending order.
+func (r RecurrenceRule) expand(period, anchor time.Time) []time.Time {
+	var days []time.Time
+
+	switch r.Freq {
+	case FrequencyDaily:
+		days = []time.Time{period}
+	case FrequencyWeekly:
+		if len(r.ByDay) == 0 {
+			days = []time.Time{period.AddDate(0, 0, (int(anchor.Weekday())-int(period.Weekday())+7)%7)}
+		}
+		for i := 0; i < 7 && len(r.ByDay) > 0; i++ {
+			days = append(days, period.AddDate(0, 0, i))
+		}
+	case FrequencyMonthly:
+		days = r.expandMonth(period.Year(), period.Month(), anchor)
+	case FrequencyYearly:
+		switch {
+		case len(r.ByMonth) > 0:
+			for _, month := range r.sortedMonths() {
+				days = append(days, r.expandMonth(period.Year(), month, anchor)...)
+			}
+		case len(r.ByDay) > 0 && len(r.ByMonthDay) == 0:
+			days = expandWeekdays(period, period.AddDate(1, 0, 0), r.ByDay)
+		case len(r.ByMonthDay) > 0:
+			for month := time.January; month <= time.December; month++ {
+				days = append(days, r.expandMonth(period.Year(), month, anchor)...)
+			}
+		default:
+			days = r.expandMonth(period.Year(), anchor.Month(), anchor)
+		}
+	}
+
+	matched := days[:0]
+	for _, day := range days {
+		if r.matches(day) {
+			matched = append(matched, day)
+		}
+	}
+	slices.SortFunc(matched, func(a, b time.Time) int { return a.Compare(b) })
+	return slices.CompactFunc(matched, time.Time.Equal)
+}
+
+// expandMonth lists the days of the month selected by BYMONTHDAY and BYDAY, or the day of the anchor.
+func (r RecurrenceRule) expandMonth(year int, month time.Month, anchor time.Time) []time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	next := first.AddDate(0, 1, 0)
+	last := next.AddDate(0, 0, -1).Day()
+
+	switch {
+	case len(r.ByMonthDay) > 0:
+		var days []time.Time
+		for _, d := range r.ByMonthDay {
+			if d < 0 {
+				d = last + d + 1
+			}
+			// Days the month does not have are skipped, as in RFC 5545
+			if d >= 1 && d <= last {
+				days = append(days, time.Date(year, month, d, 0, 0, 0, 0, time.UTC))
+			}
+		}
+		return days
+	case len(r.ByDay) > 0:
+		return expandWeekdays(first, next, r.ByDay)
+	case anchor.Day() <= last:
+		return []time.Time{time.Date(year, month, anchor.Day(), 0, 0, 0, 0, time.UTC)}
+	}
+	return nil
+}
+
+// expandWeekdays lists the days in [from, to) selected by weekdays, ordinals count from either end.
+func expandWeekdays(from, to time.Time, weekdays []RecurrenceWeekday) []time.Time {
+	var days []time.Time
+	for _, wd := range weekdays {
+		var matching []time.Time
+		for day := from.AddDate(0, 0, (int(wd.Weekday)-int(from.Weekday())+7)%7); day.Before(to); day = day.AddDate(0, 0, 7) {
+			matching = append(matching, day)
+		}
+
+		switch {
+		case wd.N == 0:
+			days = append(days, matching...)
+		case wd.N > 0 && wd.N <= len(matching):
+			days = append(days, matching[wd.N-1])
+		case wd.N < 0 && -wd.N <= len(matching):
+			days = append(days, matching[len(matching)+wd.N])
+		}
+	}
+	return days
+}
+
+// matches applies the filters that limit rather than expand the period.
+func (r RecurrenceRule) matches(day time.Time) bool {
+	if len(r.ByMonth) > 0 && !slices.Contains(r.ByMonth, day.Month()) {
+		return false
+	}
+
+	switch r.Freq {
+	case FrequencyDaily:
+		if len(r.ByMonthDay) > 0 && !r.matchesMonthDay(day) {
+			return false
+		}
+		return len(r.ByDay) == 0 || r.matchesWeekday(day)
+	case FrequencyWeekly:
+		return len(r.ByDay) == 0 || r.matchesWeekday(day)
+	default:
+		// BYDAY limits BYMONTHDAY when both are set
+		return len(r.ByMonthDay) == 0 || len(r.ByDay) == 0 || r.matchesWeekday(day)
+	}
+}
+
+func (r RecurrenceRule) matchesWeekday(day time.Time) bool {
+	for _, wd := range r.ByDay {
+		if wd.Weekday == day.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r RecurrenceRule) matchesMonthDay(day time.Time) bool {
+	last := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, d := range r.ByMonthDay {
+		if d == day.Day() || last+d+1 == day.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r RecurrenceRule) sortedMonths() []time.Month {
+	months := slices.Clone(r.ByMonth)
+	slices.Sort(months)
+	return slices.Compact(months)
+}
+
+// ValidateRecurrence checks the recurrence rule and time zone of a recurring task, the due date of the
+// task is the first occurrence.
+func (t *Task) ValidateRecurrence() error {
+	if t.Recurrence == "" {
+		if t.TimeZone != "" {
+			if _, err := time.LoadLocation(t.TimeZone); err != nil {
+				return &ValidationError{Field: "timeZone", Message: fmt.Sprintf("unknown time zone %q", t.TimeZone)}
+			}
+		}
+		return nil
+	}
+
+	if _, err := ParseRecurrenceRule(t.Recurrence); err != nil {
+		return err
+	}
+	if _, err := time.LoadLocation(t.TimeZone); err != nil {
+		return &ValidationError{Field: "timeZone", Message: fmt.Sprintf("unknown time zone %q", t.TimeZone)}
+	}
+	if t.DueDate == nil {
+		return &ValidationError{Field: "dueDate", Message: "a recurring task needs a due date"}
+	}
+	return nil
+}
+
+// NextOccurrence returns the open task following t in its recurring series, false when t does not recur
+// or its series has ended.
+func (t *Task) NextOccurrence() (Task, bool, error) {
+	if t.Recurrence == "" || t.DueDate == nil {
+		return Task{}, false, nil
+	}
+
+	rule, err := ParseRecurrenceRule(t.Recurrence)
+	if err != nil {
+		return Task{}, false, err
+	}
+	location, err := time.LoadLocation(t.TimeZone)
+	if err != nil {
+		return Task{}, false, &ValidationError{Field: "timeZone", Message: fmt.Sprintf("unknown time zone %q", t.TimeZone)}
+	}
+
+	occurrence := max(t.Occurrence, 1)
+	if rule.Count > 0 && occurrence >= rule.Count {
+		return Task{}, false, nil
+	}
+
+	start := t.DueDate
+	if t.SeriesStart != nil {
+		start = t.SeriesStart
+	}
+	due, ok := rule.Next(*start, *t.DueDate, location)
+	if !ok {
+		return Task{}, false, nil
+	}
+
+	seriesID := t.SeriesID
+	if seriesID.IsZero() {
+		seriesID = t.ID
+	}
+
+	return Task{
+		Title:       t.Title,
+		UserID:      t.UserID,
+		ParentID:    t.ParentID,
+		ProjectID:   t.ProjectID,
+		Status:      StatusTodo,
+		Description: t.Description,
+		DueDate:     &due,
+		Priority:    t.Priority,
+		Recurrence:  t.Recurrence,
+		TimeZone:    t.TimeZone,
+		SeriesID:    seriesID,
+		SeriesStart: start,
+		Occurrence:  occurrence + 1,
+	}, true, nil
+}
@@ -0,0 +1,203 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestParseRecurrenceRule(t *testing.T) {
+	rule, err := domain.ParseRecurrenceRule("RRULE:FREQ=MONTHLY;INTERVAL=2;BYDAY=MO,-1FR;COUNT=5")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.FrequencyMonthly, rule.Freq)
+	assert.Equal(t, 2, rule.Interval)
+	assert.Equal(t, 5, rule.Count)
+	assert.Equal(t, []domain.RecurrenceWeekday{{Weekday: time.Monday}, {N: -1, Weekday: time.Friday}}, rule.ByDay)
+
+	for _, invalid := range []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=3;UNTIL=20250101T000000Z",
+		"FREQ=DAILY;BYDAY=1MO",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=MONTHLY;BYDAY=6MO",
+		"FREQ=MONTHLY;BYDAY=XX",
+		"FREQ=DAILY;BYSETPOS=1",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;UNTIL=tomorrow",
+	} {
+		_, err := domain.ParseRecurrenceRule(invalid)
+		assert.ErrorIs(t, err, domain.ErrValidation, invalid)
+	}
+}
+
+func TestRecurrenceRuleNext(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		rule     string
+		start    time.Time
+		after    time.Time
+		expected time.Time
+	}{
+		{"daily", "FREQ=DAILY", date(2025, 2, 1), date(2025, 2, 1), date(2025, 2, 2)},
+		{"interval aligned to start", "FREQ=DAILY;INTERVAL=3", date(2025, 2, 1), date(2025, 2, 5), date(2025, 2, 7)},
+		{"weekdays", "FREQ=WEEKLY;BYDAY=MO,WE,FR", date(2025, 2, 3), date(2025, 2, 7), date(2025, 2, 10)},
+		{"every other week", "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH", date(2025, 2, 4), date(2025, 2, 6), date(2025, 2, 18)},
+		{"weekly on the start day", "FREQ=WEEKLY", date(2025, 2, 5), date(2025, 2, 5), date(2025, 2, 12)},
+		{"monthly skips short months", "FREQ=MONTHLY", date(2025, 1, 31), date(2025, 1, 31), date(2025, 3, 31)},
+		{"last day of month", "FREQ=MONTHLY;BYMONTHDAY=-1", date(2025, 1, 31), date(2025, 1, 31), date(2025, 2, 28)},
+		{"last friday", "FREQ=MONTHLY;BYDAY=-1FR", date(2025, 1, 31), date(2025, 1, 31), date(2025, 2, 28)},
+		{"second tuesday", "FREQ=MONTHLY;BYDAY=2TU", date(2025, 2, 11), date(2025, 2, 11), date(2025, 3, 11)},
+		{"friday the 13th", "FREQ=MONTHLY;BYMONTHDAY=13;BYDAY=FR", date(2024, 12, 13), date(2024, 12, 13), date(2025, 6, 13)},
+		{"leap day", "FREQ=YEARLY", date(2024, 2, 29), date(2024, 2, 29), date(2028, 2, 29)},
+		{"second sunday of march", "FREQ=YEARLY;BYMONTH=3;BYDAY=2SU", date(2025, 3, 9), date(2025, 3, 9), date(2026, 3, 8)},
+		{"quarterly", "FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=1", date(2025, 1, 1), date(2025, 2, 15), date(2025, 4, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := domain.ParseRecurrenceRule(tt.rule)
+			assert.NoError(t, err)
+
+			next, ok := rule.Next(tt.start, tt.after, time.UTC)
+			assert.True(t, ok)
+			assert.Equal(t, tt.expected, next)
+		})
+	}
+}
+
+func TestRecurrenceRuleUntil(t *testing.T) {
+	start := time.Date(2025, 2, 1, 9, 0, 0, 0, time.UTC)
+
+	rule, err := domain.ParseRecurrenceRule("FREQ=DAILY;UNTIL=20250203T090000Z")
+	assert.NoError(t, err)
+
+	next, ok := rule.Next(start, start.AddDate(0, 0, 1), time.UTC)
+	assert.True(t, ok)
+	assert.Equal(t, start.AddDate(0, 0, 2), next)
+
+	_, ok = rule.Next(start, next, time.UTC)
+	assert.False(t, ok)
+
+	// A date UNTIL includes the whole day in the time zone of the task
+	rule, err = domain.ParseRecurrenceRule("FREQ=DAILY;UNTIL=20250203")
+	assert.NoError(t, err)
+	bangkok := time.FixedZone("ICT", 7*3600)
+	next, ok = rule.Next(time.Date(2025, 2, 2, 23, 0, 0, 0, bangkok), time.Date(2025, 2, 2, 23, 0, 0, 0, bangkok), bangkok)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2025, 2, 3, 23, 0, 0, 0, bangkok), next)
+}
+
+func TestRecurrenceRuleDST(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+
+	next := func(rule string, start, after time.Time) string {
+		r, err := domain.ParseRecurrenceRule(rule)
+		assert.NoError(t, err)
+		occurrence, ok := r.Next(start, after, newYork)
+		assert.True(t, ok)
+		return occurrence.Format(time.RFC3339)
+	}
+
+	t.Run("wall clock kept across spring forward", func(t *testing.T) {
+		start := time.Date(2025, 3, 8, 9, 0, 0, 0, newYork)
+		assert.Equal(t, "2025-03-09T09:00:00-04:00", next("FREQ=DAILY", start, start))
+	})
+
+	t.Run("wall clock kept across fall back", func(t *testing.T) {
+		start := time.Date(2025, 10, 27, 9, 0, 0, 0, newYork)
+		assert.Equal(t, "2025-11-03T09:00:00-05:00", next("FREQ=WEEKLY", start, start))
+	})
+
+	t.Run("skipped time moves past the gap", func(t *testing.T) {
+		start := time.Date(2025, 3, 8, 2, 30, 0, 0, newYork)
+		assert.Equal(t, "2025-03-09T03:30:00-04:00", next("FREQ=DAILY", start, start))
+
+		// The following day is back at the wall clock time of the series
+		gap := time.Date(2025, 3, 9, 7, 30, 0, 0, time.UTC)
+		assert.Equal(t, "2025-03-10T02:30:00-04:00", next("FREQ=DAILY", start, gap))
+	})
+
+	t.Run("repeated time is the first occurrence", func(t *testing.T) {
+		start := time.Date(2025, 11, 1, 1, 30, 0, 0, newYork)
+		first := next("FREQ=DAILY", start, start)
+		assert.Equal(t, "2025-11-02T01:30:00-04:00", first)
+
+		after, _ := time.Parse(time.RFC3339, first)
+		assert.Equal(t, "2025-11-03T01:30:00-05:00", next("FREQ=DAILY", start, after))
+	})
+
+	t.Run("utc instant of a monthly task moves with the offset", func(t *testing.T) {
+		start := time.Date(2025, 2, 15, 9, 0, 0, 0, newYork)
+		assert.Equal(t, "2025-03-15T09:00:00-04:00", next("FREQ=MONTHLY", start, start))
+	})
+}
+
+func TestTaskNextOccurrence(t *testing.T) {
+	id := primitive.NewObjectID()
+	due := time.Date(2025, 2, 3, 9, 0, 0, 0, time.UTC)
+
+	t.Run("first occurrence starts the series", func(t *testing.T) {
+		task := domain.Task{ID: id, Title: "standup", Status: domain.StatusDone, Recurrence: "FREQ=WEEKLY;BYDAY=MO,WE,FR", DueDate: &due, Priority: domain.PriorityHigh}
+
+		next, ok, err := task.NextOccurrence()
+
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, due.AddDate(0, 0, 2), *next.DueDate)
+		assert.Equal(t, id, next.SeriesID)
+		assert.Equal(t, due, *next.SeriesStart)
+		assert.Equal(t, 2, next.Occurrence)
+		assert.Equal(t, domain.StatusTodo, next.Status)
+		assert.Equal(t, domain.PriorityHigh, next.Priority)
+		assert.True(t, next.ID.IsZero())
+	})
+
+	t.Run("count ends the series", func(t *testing.T) {
+		task := domain.Task{ID: id, Recurrence: "FREQ=DAILY;COUNT=3", DueDate: &due, SeriesID: id, SeriesStart: &due, Occurrence: 3}
+
+		_, ok, err := task.NextOccurrence()
+
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("not recurring", func(t *testing.T) {
+		task := domain.Task{ID: id, DueDate: &due}
+
+		_, ok, err := task.NextOccurrence()
+
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
+
+func TestTaskValidateRecurrence(t *testing.T) {
+	due := time.Date(2025, 2, 3, 9, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, (&domain.Task{}).ValidateRecurrence())
+	assert.NoError(t, (&domain.Task{Recurrence: "FREQ=DAILY", TimeZone: "Asia/Bangkok", DueDate: &due}).ValidateRecurrence())
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, (&domain.Task{Recurrence: "FREQ=DAILY"}).ValidateRecurrence(), &validationErr)
+	assert.Equal(t, "dueDate", validationErr.Field)
+
+	assert.ErrorAs(t, (&domain.Task{Recurrence: "FREQ=DAILY", TimeZone: "Mars/Olympus", DueDate: &due}).ValidateRecurrence(), &validationErr)
+	assert.Equal(t, "timeZone", validationErr.Field)
+
+	assert.ErrorAs(t, (&domain.Task{Recurrence: "FREQ=SOMETIMES", DueDate: &due}).ValidateRecurrence(), &validationErr)
+	assert.Equal(t, "recurrence", validationErr.Field)
+}
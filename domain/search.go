@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	// MaxSearchQueryLength bounds a search query in characters.
+	MaxSearchQueryLength = 256
+	// MaxSearchTerms bounds the words of a search query, further words are ignored.
+	MaxSearchTerms = 10
+)
+
+// TaskMatch is a task found by a search. Score is the text relevance and Highlights holds the searched
+// fields that matched, HTML escaped with the matched words wrapped in <mark>.
+type TaskMatch struct {
+	Task       `bson:",inline"`
+	Score      float64           `bson:"score" json:"score"`
+	Highlights map[string]string `bson:"-" json:"highlights,omitempty"`
+}
+
+// SearchTerms splits a query into its words, anything but letters, digits and combining marks
+// separates words.
+func SearchTerms(query string) []string {
+	return strings.FieldsFunc(query, isSearchSeparator)
+}
+
+// Highlight escapes text for HTML and wraps every word starting with one of terms in <mark>, compared
+// case-insensitively so that words the text index stems to a term are marked too. It reports whether
+// a word matched.
+func Highlight(text string, terms []string) (string, bool) {
+	var (
+		b       strings.Builder
+		matched bool
+	)
+
+	for len(text) > 0 {
+		// Copy separators up to the next word
+		end := strings.IndexFunc(text, func(r rune) bool { return !isSearchSeparator(r) })
+		if end < 0 {
+			end = len(text)
+		}
+		b.WriteString(html.EscapeString(text[:end]))
+		text = text[end:]
+
+		end = strings.IndexFunc(text, isSearchSeparator)
+		if end < 0 {
+			end = len(text)
+		}
+		word := text[:end]
+		text = text[end:]
+		if word == "" {
+			continue
+		}
+
+		if hasTermPrefix(word, terms) {
+			matched = true
+			b.WriteString("<mark>" + html.EscapeString(word) + "</mark>")
+		} else {
+			b.WriteString(html.EscapeString(word))
+		}
+	}
+
+	return b.String(), matched
+}
+
+func hasTermPrefix(word string, terms []string) bool {
+	word = strings.ToLower(word)
+	for _, term := range terms {
+		if strings.HasPrefix(word, strings.ToLower(term)) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSearchSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+}
@@ -0,0 +1,37 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchTerms(t *testing.T) {
+	assert.Equal(t, []string{"fix", "login", "bug", "where", "ข้อผิดพลาด"}, domain.SearchTerms(`"fix login" -bug $where ข้อผิดพลาด`))
+	assert.Empty(t, domain.SearchTerms(` "-" `))
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		terms   []string
+		want    string
+		matched bool
+	}{
+		{name: "word", text: "Fix login bug", terms: []string{"login"}, want: "Fix <mark>login</mark> bug", matched: true},
+		{name: "case and prefix", text: "Logins are failing", terms: []string{"LOGIN", "fail"}, want: "<mark>Logins</mark> are <mark>failing</mark>", matched: true},
+		{name: "inside a word", text: "relogin", terms: []string{"login"}, want: "relogin", matched: false},
+		{name: "escapes html", text: `<b>login</b> & "more"`, terms: []string{"login"}, want: "&lt;b&gt;<mark>login</mark>&lt;/b&gt; &amp; &#34;more&#34;", matched: true},
+		{name: "empty", text: "", terms: []string{"login"}, want: "", matched: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, matched := domain.Highlight(tt.text, tt.terms)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.matched, matched)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// StatsInterval is the length of the periods of a task report.
+type StatsInterval string
+
+const (
+	IntervalDay   StatsInterval = "day"
+	IntervalWeek  StatsInterval = "week"
+	IntervalMonth StatsInterval = "month"
+)
+
+const (
+	// DefaultStatsRange is the range reported when the request does not give one.
+	DefaultStatsRange = 12 * 7 * 24 * time.Hour
+	// MaxStatsRange bounds the range of a report.
+	MaxStatsRange = 366 * 24 * time.Hour
+)
+
+func (i StatsInterval) Valid() bool {
+	switch i {
+	case IntervalDay, IntervalWeek, IntervalMonth:
+		return true
+	}
+	return false
+}
+
+// TaskStatsQuery selects the tasks created or completed in [From, To). Periods start at midnight in
+// TimeZone, weeks on Monday.
+type TaskStatsQuery struct {
+	From     time.Time
+	To       time.Time
+	Interval StatsInterval
+	// TimeZone is an IANA time zone name, UTC when empty.
+	TimeZone string
+}
+
+// TaskStats reports on the tasks created in the range: how many there are by status and by creator, and
+// how many of them are done. Periods count the tasks created and completed in each period of the range,
+// periods without either are left out.
+type TaskStats struct {
+	From           time.Time            `json:"from"`
+	To             time.Time            `json:"to"`
+	Interval       StatsInterval        `json:"interval"`
+	Total          int64                `json:"total"`
+	Completed      int64                `json:"completed"`
+	CompletionRate float64              `json:"completionRate"`
+	ByStatus       map[TaskStatus]int64 `json:"byStatus"`
+	ByUser         []UserTaskStats      `json:"byUser"`
+	Periods        []PeriodTaskStats    `json:"periods"`
+}
+
+// UserTaskStats counts the tasks created by one user, tasks created without a user have an empty User.
+type UserTaskStats struct {
+	User           string  `bson:"_id" json:"user"`
+	Total          int64   `bson:"total" json:"total"`
+	Completed      int64   `bson:"completed" json:"completed"`
+	CompletionRate float64 `bson:"-" json:"completionRate"`
+}
+
+// PeriodTaskStats counts the tasks created and completed in the period starting at Start.
+// AvgLeadTimeHours is the mean time from creation to completion of the tasks completed in the period.
+type PeriodTaskStats struct {
+	Start            time.Time `json:"start"`
+	Created          int64     `json:"created"`
+	Completed        int64     `json:"completed"`
+	AvgLeadTimeHours float64   `json:"avgLeadTimeHours"`
+}
+
+// CompletionRate is the share of total that is completed, 0 without tasks.
+func CompletionRate(completed, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(completed) / float64(total)
+}
+
+type TaskStatsRepository interface {
+	// Stats reports on the tasks of scope.
+	Stats(c context.Context, scope TaskScope, query TaskStatsQuery) (TaskStats, error)
+}
+
+type TaskStatsUsecase interface {
+	// Stats applies the defaults of the query and reports a ValidationError for an invalid one. Only the
+	// tasks the actor of c may read are counted.
+	Stats(c context.Context, query TaskStatsQuery) (TaskStats, error)
+}
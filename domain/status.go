@@ -0,0 +1,60 @@
+package domain
+
+import "fmt"
+
+type TaskStatus string
+
+const (
+	StatusTodo       TaskStatus = "todo"
+	StatusInProgress TaskStatus = "in_progress"
+	StatusBlocked    TaskStatus = "blocked"
+	StatusDone       TaskStatus = "done"
+	StatusArchived   TaskStatus = "archived"
+)
+
+// taskTransitions lists the statuses each status may move to.
+var taskTransitions = map[TaskStatus][]TaskStatus{
+	StatusTodo:       {StatusInProgress, StatusBlocked, StatusDone, StatusArchived},
+	StatusInProgress: {StatusTodo, StatusBlocked, StatusDone, StatusArchived},
+	StatusBlocked:    {StatusTodo, StatusInProgress, StatusArchived},
+	StatusDone:       {StatusTodo, StatusArchived},
+	StatusArchived:   {StatusTodo},
+}
+
+func (s TaskStatus) Valid() bool {
+	_, ok := taskTransitions[s]
+	return ok
+}
+
+// OrDefault maps the empty status of tasks created before statuses existed to StatusTodo.
+func (s TaskStatus) OrDefault() TaskStatus {
+	if s == "" {
+		return StatusTodo
+	}
+	return s
+}
+
+func (s TaskStatus) CanTransitionTo(to TaskStatus) bool {
+	for _, allowed := range taskTransitions[s.OrDefault()] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTransition returns a ValidationError when moving from s to the status to is not allowed.
+func (s TaskStatus) ValidateTransition(to TaskStatus) error {
+	if !to.Valid() {
+		return &ValidationError{Field: "status", Message: fmt.Sprintf("unknown status %q", to)}
+	}
+	if !s.CanTransitionTo(to) {
+		return &ValidationError{Field: "status", Message: fmt.Sprintf("cannot move from %s to %s", s.OrDefault(), to)}
+	}
+	return nil
+}
+
+// Closed statuses no longer block other tasks and are left out of due date queries.
+func (s TaskStatus) Closed() bool {
+	return s == StatusDone || s == StatusArchived
+}
@@ -0,0 +1,43 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskStatusTransitions(t *testing.T) {
+	tests := []struct {
+		from, to domain.TaskStatus
+		allowed  bool
+	}{
+		{from: domain.StatusTodo, to: domain.StatusInProgress, allowed: true},
+		{from: "", to: domain.StatusInProgress, allowed: true},
+		{from: domain.StatusInProgress, to: domain.StatusDone, allowed: true},
+		{from: domain.StatusBlocked, to: domain.StatusDone, allowed: false},
+		{from: domain.StatusDone, to: domain.StatusTodo, allowed: true},
+		{from: domain.StatusDone, to: domain.StatusInProgress, allowed: false},
+		{from: domain.StatusArchived, to: domain.StatusDone, allowed: false},
+		{from: domain.StatusTodo, to: domain.StatusTodo, allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			err := tt.from.ValidateTransition(tt.to)
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, domain.ErrValidation)
+			}
+		})
+	}
+}
+
+func TestTaskStatusUnknown(t *testing.T) {
+	err := domain.StatusTodo.ValidateTransition("started")
+
+	var validation *domain.ValidationError
+	assert.ErrorAs(t, err, &validation)
+	assert.Equal(t, "status", validation.Field)
+}
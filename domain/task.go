@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -11,9 +12,88 @@ const (
 )
 
 type Task struct {
-	ID     primitive.ObjectID `bson:"_id" json:"id"`
-	Title  string             `bson:"title" form:"title" binding:"required" json:"title"`
-	UserID primitive.ObjectID `bson:"userID" json:"-"`
+	ID     primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Title  string             `bson:"title,omitempty" form:"title" binding:"required" json:"title"`
+	UserID primitive.ObjectID `bson:"userID,omitempty" json:"-"`
+	// Version is incremented by every update, a write with a stale version fails with ErrConflict.
+	Version int64      `bson:"version,omitempty" json:"version"`
+	Status  TaskStatus `bson:"status,omitempty" json:"status"`
+
+	Description string       `bson:"description,omitempty" json:"description,omitempty"`
+	DueDate     *time.Time   `bson:"dueDate,omitempty" json:"dueDate,omitempty"`
+	Priority    TaskPriority `bson:"priority,omitempty" json:"priority,omitempty"`
+	// CompletedAt is set when the task moves to done and cleared when it leaves done.
+	CompletedAt *time.Time `bson:"completedAt,omitempty" json:"completedAt,omitempty"`
+
+	CreatedAt time.Time `bson:"createdAt,omitempty" json:"createdAt"`
+	UpdatedAt time.Time `bson:"updatedAt,omitempty" json:"updatedAt"`
+	CreatedBy string    `bson:"createdBy,omitempty" json:"createdBy,omitempty"`
+	UpdatedBy string    `bson:"updatedBy,omitempty" json:"updatedBy,omitempty"`
+
+	// ParentID makes the task a subtask, BlockedBy lists the tasks that must be done before it.
+	ParentID  primitive.ObjectID   `bson:"parentID,omitempty" json:"parentId,omitempty"`
+	BlockedBy []primitive.ObjectID `bson:"blockedBy,omitempty" json:"blockedBy,omitempty"`
+
+	// ProjectID files the task under a project, only the members of its workspace may write the task.
+	ProjectID primitive.ObjectID `bson:"projectID,omitempty" json:"projectId,omitempty"`
+
+	// Recurrence is an RFC 5545 RRULE evaluated in TimeZone (UTC when empty), completing a recurring
+	// task creates the next occurrence of its series. SeriesStart is the due date of the first occurrence
+	// and Occurrence the 1-based position of the task in the series.
+	Recurrence  string             `bson:"recurrence,omitempty" json:"recurrence,omitempty"`
+	TimeZone    string             `bson:"timeZone,omitempty" json:"timeZone,omitempty"`
+	SeriesID    primitive.ObjectID `bson:"seriesID,omitempty" json:"seriesId,omitempty"`
+	SeriesStart *time.Time         `bson:"seriesStart,omitempty" json:"seriesStart,omitempty"`
+	Occurrence  int                `bson:"occurrence,omitempty" json:"occurrence,omitempty"`
+
+	// RemindedAt records the due date reminder, it is cleared when the due date is rewritten.
+	RemindedAt *time.Time `bson:"remindedAt,omitempty" json:"-"`
+
+	// DeletedAt marks a soft-deleted task, it is excluded from reads until restored or purged.
+	DeletedAt *time.Time `bson:"deletedAt,omitempty" json:"deletedAt,omitempty"`
+}
+
+func (t *Task) GetID() primitive.ObjectID {
+	return t.ID
+}
+
+func (t *Task) SetID(id primitive.ObjectID) {
+	t.ID = id
+}
+
+func (t *Task) SetCreated(at time.Time, by string) {
+	t.CreatedAt, t.CreatedBy = at, by
+	t.UpdatedAt, t.UpdatedBy = at, by
+}
+
+// ClearServerFields drops the fields a client cannot set on a new task, the server maintains them.
+func (t *Task) ClearServerFields() {
+	t.CompletedAt, t.RemindedAt, t.DeletedAt = nil, nil, nil
+	t.SeriesID, t.SeriesStart, t.Occurrence = primitive.NilObjectID, nil, 0
+}
+
+// TaskTree is a task with its live subtasks, recursively.
+type TaskTree struct {
+	Task
+	Subtasks []*TaskTree `json:"subtasks"`
+}
+
+// TaskFilter narrows task listings, zero fields do not filter.
+type TaskFilter struct {
+	Status   TaskStatus
+	Priority TaskPriority
+	// Overdue keeps open tasks whose due date has passed.
+	Overdue bool
+	// DueWithin keeps open tasks due between now and now+DueWithin.
+	DueWithin time.Duration
+	// UserID keeps the tasks of the user.
+	UserID string
+	// Scope keeps the tasks of its projects, nil keeps the tasks of every project.
+	Scope *TaskScope
+}
+
+func (f TaskFilter) IsZero() bool {
+	return f == TaskFilter{}
 }
 
 type TaskRepository interface {
@@ -21,11 +101,62 @@ type TaskRepository interface {
 	FetchByUserID(c context.Context, userID string) ([]Task, error)
 	FetchByTaskID(c context.Context, taskID string) (Task, error)
 	FetchAll(c context.Context) ([]Task, error)
+	FetchByFilter(c context.Context, filter TaskFilter) ([]Task, error)
+	FetchOverdue(c context.Context) ([]Task, error)
+	FetchDueWithin(c context.Context, window time.Duration) ([]Task, error)
+	FetchByPriority(c context.Context, priority TaskPriority) ([]Task, error)
+	// Update writes the mutable fields of task when task.Version matches and reloads task from the result.
+	Update(c context.Context, task *Task) error
+	// UpdateStatus writes task.Status when task.Version matches and reloads task from the result.
+	UpdateStatus(c context.Context, task *Task) error
+	Delete(c context.Context, taskID string) error
+	Restore(c context.Context, taskID string) error
+	FetchDeleted(c context.Context) ([]Task, error)
+	// FetchDeletedByTaskID reads a soft-deleted task, a live task is reported as ErrNotFound.
+	FetchDeletedByTaskID(c context.Context, taskID string) (Task, error)
+	// PurgeDeleted removes tasks soft-deleted more than retention ago and returns how many were removed.
+	PurgeDeleted(c context.Context, retention time.Duration) (int64, error)
+	FetchByIDs(c context.Context, ids []primitive.ObjectID) ([]Task, error)
+	FetchTree(c context.Context, taskID string) (TaskTree, error)
+	// FetchAncestorIDs lists the parent chain of the task, ErrNotFound when the task does not exist.
+	FetchAncestorIDs(c context.Context, taskID primitive.ObjectID) ([]primitive.ObjectID, error)
+	// FetchBlockerIDs lists the existing tasks among ids together with every task blocking them, directly
+	// or not.
+	FetchBlockerIDs(c context.Context, ids []primitive.ObjectID) ([]primitive.ObjectID, error)
+	// Search finds live tasks of scope whose title or description contain one of the words of query, most
+	// relevant first, with the total number of matches.
+	Search(c context.Context, scope TaskScope, query string, page PageRequest) ([]TaskMatch, int64, error)
+}
+
+type TaskReminderRepository interface {
+	// FetchDueForReminder lists open tasks due within window that have not been reminded yet.
+	FetchDueForReminder(c context.Context, window time.Duration) ([]Task, error)
+	// MarkReminded records that the reminder of the task was sent.
+	MarkReminded(c context.Context, taskID string) error
 }
 
 type TaskUsecase interface {
+	// Create accepts only StatusTodo, other statuses are reached with Transition.
 	Create(c context.Context, task *Task) error
 	FetchByUserID(c context.Context, userID string) ([]Task, error)
 	FetchByTaskID(c context.Context, taskID string) (Task, error)
 	FetchAll(c context.Context) ([]Task, error)
+	FetchByFilter(c context.Context, filter TaskFilter) ([]Task, error)
+	Update(c context.Context, task *Task) error
+	// Transition moves the task to status when the workflow allows it and publishes TopicTaskStatusChanged.
+	// A task cannot be done while one of its blockers is open.
+	Transition(c context.Context, taskID string, status TaskStatus) (Task, error)
+	Delete(c context.Context, taskID string) error
+	Restore(c context.Context, taskID string) (Task, error)
+	FetchTree(c context.Context, taskID string) (TaskTree, error)
+	// Search sanitizes query to plain words and highlights them in the matched tasks, among the tasks the
+	// actor of c may read.
+	Search(c context.Context, query string, page PageRequest) (Page[TaskMatch], error)
+	FetchDeleted(c context.Context) ([]Task, error)
+	PurgeDeleted(c context.Context, retention time.Duration) (int64, error)
+}
+
+type TaskReminderUsecase interface {
+	// SendReminders publishes TopicTaskReminder for every task due soon and returns how many were sent.
+	SendReminders(c context.Context, publisher EventPublisher) (int, error)
 }
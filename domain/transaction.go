@@ -0,0 +1,9 @@
+package domain
+
+import "context"
+
+// UnitOfWork runs fn atomically, the repository calls made with the context passed to fn take part in
+// it. A call made inside fn joins the running unit of work.
+type UnitOfWork interface {
+	WithTransaction(c context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"context"
+	"io"
+)
+
+// TaskFormat is a file format of task export and import.
+type TaskFormat string
+
+const (
+	FormatCSV    TaskFormat = "csv"
+	FormatNDJSON TaskFormat = "ndjson"
+)
+
+const (
+	// MaxImportSize caps the size of an imported file.
+	MaxImportSize int64 = 10 << 20
+	// MaxImportRows caps the tasks of an imported file, they are validated and written in one batch.
+	MaxImportRows = 10000
+)
+
+func (f TaskFormat) Valid() bool {
+	return f == FormatCSV || f == FormatNDJSON
+}
+
+func (f TaskFormat) ContentType() string {
+	if f == FormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/x-ndjson"
+}
+
+// TaskColumns are the CSV columns of an exported task. An import reads the columns by header name in any
+// order and ignores the audit columns, an imported task is created by the importing user.
+var TaskColumns = []string{
+	"id", "title", "description", "status", "priority", "dueDate", "recurrence", "timeZone", "parentId",
+	"projectId", "blockedBy", "completedAt", "createdAt", "createdBy", "updatedAt", "updatedBy",
+}
+
+// ImportRowError reports a row that was not imported. Row is the line of the row in the file.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type ImportResult struct {
+	DryRun bool `json:"dryRun"`
+	Total  int  `json:"total"`
+	// Valid counts the rows that passed validation, Imported those written, which is none on a dry run.
+	Valid    int              `json:"valid"`
+	Imported int              `json:"imported"`
+	Errors   []ImportRowError `json:"errors"`
+}
+
+type TaskTransferRepository interface {
+	// Each calls fn with every live task of scope in id order, stopping at the first error of fn.
+	Each(c context.Context, scope TaskScope, fn func(Task) error) error
+	// Import inserts tasks keeping the ids they have and returns the error of each task, nil for the
+	// inserted ones. A failed task does not stop the others.
+	Import(c context.Context, tasks []Task) ([]error, error)
+}
+
+type TaskTransferUsecase interface {
+	// Export writes every live task the actor of c may read to w in format.
+	Export(c context.Context, format TaskFormat, w io.Writer) error
+	// Import creates the valid tasks of r, or only validates them on a dry run. A row error does not fail
+	// the import, the error is reserved for a file that cannot be read as a whole.
+	Import(c context.Context, format TaskFormat, r io.Reader, dryRun bool) (ImportResult, error)
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CollectionWorkspace = "workspaces"
+
+	// MaxNameLength bounds the name of a workspace or project in characters.
+	MaxNameLength = 200
+)
+
+type WorkspaceRole string
+
+const (
+	// RoleOwner members manage the membership of the workspace and archive its projects.
+	RoleOwner WorkspaceRole = "owner"
+	// RoleMember members see the projects of the workspace and write their tasks.
+	RoleMember WorkspaceRole = "member"
+)
+
+func (r WorkspaceRole) Valid() bool {
+	return r == RoleOwner || r == RoleMember
+}
+
+type Member struct {
+	UserID string        `bson:"userID" json:"userId"`
+	Role   WorkspaceRole `bson:"role" json:"role"`
+}
+
+// Workspace groups projects, only its members see it and its projects.
+type Workspace struct {
+	ID      primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name    string             `bson:"name" json:"name"`
+	Members []Member           `bson:"members" json:"members"`
+	// Version is incremented by every update, a write with a stale version fails with ErrConflict.
+	Version int64 `bson:"version,omitempty" json:"version"`
+
+	CreatedAt time.Time `bson:"createdAt,omitempty" json:"createdAt"`
+	UpdatedAt time.Time `bson:"updatedAt,omitempty" json:"updatedAt"`
+	CreatedBy string    `bson:"createdBy,omitempty" json:"createdBy,omitempty"`
+	UpdatedBy string    `bson:"updatedBy,omitempty" json:"updatedBy,omitempty"`
+}
+
+func (w *Workspace) GetID() primitive.ObjectID {
+	return w.ID
+}
+
+func (w *Workspace) SetID(id primitive.ObjectID) {
+	w.ID = id
+}
+
+func (w *Workspace) SetCreated(at time.Time, by string) {
+	w.CreatedAt, w.CreatedBy = at, by
+	w.UpdatedAt, w.UpdatedBy = at, by
+}
+
+// Role returns the role of the user in the workspace, false when the user is not a member.
+func (w Workspace) Role(userID string) (WorkspaceRole, bool) {
+	for _, member := range w.Members {
+		if member.UserID == userID {
+			return member.Role, true
+		}
+	}
+	return "", false
+}
+
+type WorkspaceRepository interface {
+	Create(c context.Context, workspace *Workspace) error
+	FetchByID(c context.Context, workspaceID string) (Workspace, error)
+	// FetchByMember lists the workspaces of which the user is a member, by name.
+	FetchByMember(c context.Context, userID string) ([]Workspace, error)
+	// Update writes the name and members of workspace when workspace.Version matches and reloads
+	// workspace from the result.
+	Update(c context.Context, workspace *Workspace) error
+}
+
+type WorkspaceUsecase interface {
+	// Create makes the actor of c the owner of the new workspace.
+	Create(c context.Context, workspace *Workspace) error
+	// FetchByID reports ErrNotFound for a workspace the actor of c is not a member of.
+	FetchByID(c context.Context, workspaceID string) (Workspace, error)
+	// FetchMine lists the workspaces of the actor of c.
+	FetchMine(c context.Context) ([]Workspace, error)
+	// SetMember adds a member or changes their role, only owners manage members. A workspace keeps at
+	// least one owner.
+	SetMember(c context.Context, workspaceID string, member Member) (Workspace, error)
+	RemoveMember(c context.Context, workspaceID, userID string) (Workspace, error)
+}
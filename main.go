@@ -4,45 +4,52 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os"
 
 	"github.com/sing3demons/go-backend-clean-architecture/api/route"
 	"github.com/sing3demons/go-backend-clean-architecture/bootstrap"
 	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
 )
 
 func main() {
 	logger := bootstrap.NewZapLogger(bootstrap.NewAppLogger())
+
+	mongoConfig := bootstrap.MongoConfig{
+		URI:      "mongodb://localhost:27017/?replicaSet=rs0",
+		Database: "test",
+		Required: true,
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		app := bootstrap.NewApplication(&bootstrap.Config{MongoConfig: mongoConfig}, logger)
+
+		migrator, err := mongo.NewMigrator(app.Database(), mongo.DefaultMigrationCollection, repository.Migrations("task")...)
+		if err == nil {
+			err = migrate(context.Background(), migrator, os.Args[2:])
+		}
+
+		app.Stop(context.Background())
+		if err != nil {
+			logger.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
+
 	server := bootstrap.NewApplication(&bootstrap.Config{
 		AppConfig: bootstrap.AppConfig{
-			Port:   "3000",
-			Router: bootstrap.Gin,
+			Port:       "3000",
+			Router:     bootstrap.Gin,
+			HealthPath: "/health",
 		},
 		KafkaConfig: bootstrap.KafkaConfig{
 			Brokers: []string{"localhost:29092"},
 			GroupID: "my-group",
 		},
+		MongoConfig: mongoConfig,
 	}, logger)
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	client, err := mongo.NewClient("mongodb://localhost:27017")
-	if err != nil {
-		logger.Errorf("Failed to create MongoDB client: %v", err)
-	}
-
-	err = client.Connect(ctx)
-	if err != nil {
-		logger.Errorf("Failed to connect to MongoDB: %v", err)
-	}
-
-	err = client.Ping(ctx)
-	if err != nil {
-		logger.Errorf("Failed to ping to MongoDB: %v", err)
-	}
-	db := client.Database("test")
-
-	route.Setup(db, "task", server)
+	route.Setup(server.Database(), "task", server)
 
 	server.Get("/", func(ctx bootstrap.IContext) error {
 		log := ctx.Log()
@@ -73,6 +80,11 @@ func main() {
 		return nil
 	})
 
+	server.Watch("task", nil, func(ctx bootstrap.IContext) error {
+		ctx.Log().Info(fmt.Sprintf("task change: %s", ctx.Param("operationType")))
+		return nil
+	})
+
 	// server.Consume("test", func(ctx bootstrap.IContext) error {
 	// 	var body any
 	// 	if err := ctx.ReadInput(&body); err != nil {
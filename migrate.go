@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+)
+
+const migrateUsage = "usage: migrate up [version] | down [steps] | status"
+
+func migrate(ctx context.Context, migrator *mongo.Migrator, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		var target int64
+		if len(args) > 1 {
+			v, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid version %q: %w", args[1], err)
+			}
+			target = v
+		}
+
+		applied, err := migrator.Up(ctx, target)
+		for _, m := range applied {
+			fmt.Printf("applied %d %s\n", m.Version, m.Description)
+		}
+		return err
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid steps %q: %w", args[1], err)
+			}
+			steps = n
+		}
+
+		reverted, err := migrator.Down(ctx, steps)
+		for _, m := range reverted {
+			fmt.Printf("reverted %d %s\n", m.Version, m.Description)
+		}
+		return err
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range status {
+			appliedAt := "pending"
+			if s.AppliedAt != nil {
+				appliedAt = s.AppliedAt.Format("2006-01-02T15:04:05Z07:00")
+			}
+			fmt.Printf("%d\t%s\t%s\n", s.Version, appliedAt, s.Description)
+		}
+		return nil
+	default:
+		return errors.New(migrateUsage)
+	}
+}
@@ -0,0 +1,87 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrFileNotFound is returned by Bucket.OpenDownloadStream and Bucket.Delete for a missing file.
+var ErrFileNotFound = gridfs.ErrFileNotFound
+
+// Bucket stores files in GridFS. The file documents live in the "<name>.files" collection,
+// so they can be queried like any other collection.
+type Bucket interface {
+	UploadFromStream(ctx context.Context, filename string, source io.Reader, opts ...*options.UploadOptions) (primitive.ObjectID, error)
+	// OpenDownloadStream only bounds opening the file by the deadline of ctx, reading the stream is not.
+	OpenDownloadStream(ctx context.Context, fileID interface{}) (DownloadStream, error)
+	Delete(ctx context.Context, fileID interface{}) error
+}
+
+type DownloadStream interface {
+	io.ReadCloser
+	// Skip discards up to n bytes and returns the number skipped.
+	Skip(n int64) (int64, error)
+}
+
+type mongoBucket struct {
+	db   *mongo.Database
+	name string
+}
+
+// The driver bucket takes deadlines instead of contexts, a bucket is built for every call so
+// concurrent calls do not share a deadline.
+func (b *mongoBucket) bucket(ctx context.Context, write bool) (*gridfs.Bucket, error) {
+	bucket, err := gridfs.NewBucket(b.db, options.GridFSBucket().SetName(b.name))
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if write {
+			err = bucket.SetWriteDeadline(deadline)
+		} else {
+			err = bucket.SetReadDeadline(deadline)
+		}
+	}
+	return bucket, err
+}
+
+func (b *mongoBucket) UploadFromStream(ctx context.Context, filename string, source io.Reader, opts ...*options.UploadOptions) (primitive.ObjectID, error) {
+	bucket, err := b.bucket(ctx, true)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	return bucket.UploadFromStream(filename, source, opts...)
+}
+
+func (b *mongoBucket) OpenDownloadStream(ctx context.Context, fileID interface{}) (DownloadStream, error) {
+	bucket, err := b.bucket(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := bucket.OpenDownloadStream(fileID)
+	if err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+func (b *mongoBucket) Delete(ctx context.Context, fileID interface{}) error {
+	bucket, err := b.bucket(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	err = bucket.DeleteContext(ctx, fileID)
+	if errors.Is(err, gridfs.ErrFileNotFound) {
+		return ErrFileNotFound
+	}
+	return err
+}
@@ -0,0 +1,92 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Index declares a collection index. Use several keys for a compound index, the value
+// "text" for a text index and ExpireAfter for a TTL index.
+type Index struct {
+	Name          string
+	Keys          bson.D
+	Unique        bool
+	Sparse        bool
+	ExpireAfter   time.Duration
+	PartialFilter interface{}
+	Weights       bson.D
+}
+
+func (i Index) Model() mongo.IndexModel {
+	opts := options.Index()
+	if i.Name != "" {
+		opts.SetName(i.Name)
+	}
+	if i.Unique {
+		opts.SetUnique(true)
+	}
+	if i.Sparse {
+		opts.SetSparse(true)
+	}
+	if i.ExpireAfter > 0 {
+		opts.SetExpireAfterSeconds(int32(i.ExpireAfter / time.Second))
+	}
+	if i.PartialFilter != nil {
+		opts.SetPartialFilterExpression(i.PartialFilter)
+	}
+	if len(i.Weights) > 0 {
+		opts.SetWeights(i.Weights)
+	}
+
+	return mongo.IndexModel{Keys: i.Keys, Options: opts}
+}
+
+// IndexRegistry collects index declarations per collection and creates them in one pass.
+type IndexRegistry struct {
+	mutex       sync.Mutex
+	collections []string
+	indexes     map[string][]Index
+}
+
+func NewIndexRegistry() *IndexRegistry {
+	return &IndexRegistry{indexes: make(map[string][]Index)}
+}
+
+func (r *IndexRegistry) Register(collection string, indexes ...Index) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.indexes[collection]; !exists {
+		r.collections = append(r.collections, collection)
+	}
+	r.indexes[collection] = append(r.indexes[collection], indexes...)
+}
+
+// Apply creates every registered index, creating an index that already exists is a no-op.
+func (r *IndexRegistry) Apply(ctx context.Context, db Database) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, collection := range r.collections {
+		indexes := r.indexes[collection]
+		if len(indexes) == 0 {
+			continue
+		}
+
+		models := make([]mongo.IndexModel, 0, len(indexes))
+		for _, index := range indexes {
+			models = append(models, index.Model())
+		}
+
+		if _, err := db.Collection(collection).Indexes().CreateMany(ctx, models); err != nil {
+			return fmt.Errorf("create indexes on %s: %w", collection, err)
+		}
+	}
+	return nil
+}
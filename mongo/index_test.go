@@ -0,0 +1,57 @@
+package mongo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	driver "go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestIndexModel(t *testing.T) {
+	model := mongo.Index{
+		Name:        "expiresAt_1",
+		Keys:        bson.D{{Key: "expiresAt", Value: 1}},
+		Unique:      true,
+		ExpireAfter: time.Hour,
+	}.Model()
+
+	assert.Equal(t, "expiresAt_1", *model.Options.Name)
+	assert.True(t, *model.Options.Unique)
+	assert.Equal(t, int32(3600), *model.Options.ExpireAfterSeconds)
+}
+
+func TestIndexRegistryApply(t *testing.T) {
+	database := &mocks.Database{}
+	tasks := &mocks.Collection{}
+	indexView := &mocks.IndexView{}
+
+	database.On("Collection", "tasks").Return(tasks).Once()
+	tasks.On("Indexes").Return(indexView).Once()
+	indexView.On("CreateMany", mock.Anything, mock.MatchedBy(func(models []driver.IndexModel) bool {
+		return len(models) == 2
+	})).Return([]string{"userID_1", "title_text"}, nil).Once()
+
+	registry := mongo.NewIndexRegistry()
+	registry.Register("tasks", mongo.Index{Keys: bson.D{{Key: "userID", Value: 1}}})
+	registry.Register("tasks", mongo.Index{Keys: bson.D{{Key: "title", Value: "text"}}})
+	registry.Register("empty")
+
+	err := registry.Apply(context.Background(), database)
+	assert.NoError(t, err)
+	indexView.AssertExpectations(t)
+
+	t.Run("error", func(t *testing.T) {
+		database.On("Collection", "tasks").Return(tasks).Once()
+		tasks.On("Indexes").Return(indexView).Once()
+		indexView.On("CreateMany", mock.Anything, mock.Anything).Return(nil, assert.AnError).Once()
+
+		err := registry.Apply(context.Background(), database)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
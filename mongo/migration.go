@@ -0,0 +1,175 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const DefaultMigrationCollection = "migrations"
+
+type MigrationFunc func(ctx context.Context, db Database) error
+
+type Migration struct {
+	Version     int64
+	Description string
+	Up          MigrationFunc
+	Down        MigrationFunc
+}
+
+type MigrationRecord struct {
+	Version     int64     `bson:"_id" json:"version"`
+	Description string    `bson:"description" json:"description"`
+	AppliedAt   time.Time `bson:"appliedAt" json:"appliedAt"`
+}
+
+type MigrationStatus struct {
+	Version     int64      `json:"version"`
+	Description string     `json:"description"`
+	AppliedAt   *time.Time `json:"appliedAt,omitempty"`
+}
+
+// Migrator applies versioned migrations and records the applied ones in a collection.
+type Migrator struct {
+	db         Database
+	collection string
+	migrations []Migration
+}
+
+func NewMigrator(db Database, collection string, migrations ...Migration) (*Migrator, error) {
+	if collection == "" {
+		collection = DefaultMigrationCollection
+	}
+
+	sorted := append([]Migration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	for i, m := range sorted {
+		if m.Version <= 0 {
+			return nil, fmt.Errorf("migration %q: version must be positive", m.Description)
+		}
+		if m.Up == nil {
+			return nil, fmt.Errorf("migration %d: missing Up", m.Version)
+		}
+		if i > 0 && sorted[i-1].Version == m.Version {
+			return nil, fmt.Errorf("migration %d: duplicate version", m.Version)
+		}
+	}
+
+	return &Migrator{db: db, collection: collection, migrations: sorted}, nil
+}
+
+func (m *Migrator) Applied(ctx context.Context) ([]MigrationRecord, error) {
+	records := []MigrationRecord{}
+
+	cursor, err := m.db.Collection(m.collection).Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return records, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// Up applies pending migrations up to and including target, a target of 0 applies all of them.
+func (m *Migrator) Up(ctx context.Context, target int64) ([]Migration, error) {
+	records, err := m.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]bool, len(records))
+	for _, r := range records {
+		applied[r.Version] = true
+	}
+
+	col := m.db.Collection(m.collection)
+	done := []Migration{}
+	for _, migration := range m.migrations {
+		if target > 0 && migration.Version > target {
+			break
+		}
+		if applied[migration.Version] {
+			continue
+		}
+
+		if err := migration.Up(ctx, m.db); err != nil {
+			return done, fmt.Errorf("migration %d up: %w", migration.Version, err)
+		}
+
+		record := MigrationRecord{Version: migration.Version, Description: migration.Description, AppliedAt: time.Now()}
+		if _, err := col.InsertOne(ctx, record); err != nil {
+			return done, fmt.Errorf("migration %d record: %w", migration.Version, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Down reverts the last steps applied migrations, newest first.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	records, err := m.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	col := m.db.Collection(m.collection)
+	done := []Migration{}
+	for i := len(records) - 1; i >= 0 && len(done) < steps; i-- {
+		migration, ok := m.find(records[i].Version)
+		if !ok {
+			return done, fmt.Errorf("migration %d: not registered", records[i].Version)
+		}
+		if migration.Down == nil {
+			return done, fmt.Errorf("migration %d: missing Down", migration.Version)
+		}
+
+		if err := migration.Down(ctx, m.db); err != nil {
+			return done, fmt.Errorf("migration %d down: %w", migration.Version, err)
+		}
+
+		if _, err := col.DeleteOne(ctx, bson.M{"_id": migration.Version}); err != nil {
+			return done, fmt.Errorf("migration %d record: %w", migration.Version, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	records, err := m.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]time.Time, len(records))
+	for _, r := range records {
+		applied[r.Version] = r.AppliedAt
+	}
+
+	status := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		s := MigrationStatus{Version: migration.Version, Description: migration.Description}
+		if at, ok := applied[migration.Version]; ok {
+			s.AppliedAt = &at
+		}
+		status = append(status, s)
+	}
+	return status, nil
+}
+
+func (m *Migrator) find(version int64) (Migration, bool) {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration, true
+		}
+	}
+	return Migration{}, false
+}
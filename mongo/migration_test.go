@@ -0,0 +1,124 @@
+package mongo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	driver "go.mongodb.org/mongo-driver/mongo"
+)
+
+func appliedCursor(t *testing.T, versions ...int64) mongo.Cursor {
+	documents := []interface{}{}
+	for _, v := range versions {
+		documents = append(documents, mongo.MigrationRecord{Version: v, AppliedAt: time.Now()})
+	}
+	cursor, err := driver.NewCursorFromDocuments(documents, nil, nil)
+	assert.NoError(t, err)
+	return cursor
+}
+
+func newMigrations(calls *[]string) []mongo.Migration {
+	step := func(name string) mongo.MigrationFunc {
+		return func(ctx context.Context, db mongo.Database) error {
+			*calls = append(*calls, name)
+			return nil
+		}
+	}
+	return []mongo.Migration{
+		{Version: 2, Description: "second", Up: step("up-2"), Down: step("down-2")},
+		{Version: 1, Description: "first", Up: step("up-1"), Down: step("down-1")},
+		{Version: 3, Description: "third", Up: step("up-3")},
+	}
+}
+
+func TestNewMigratorValidation(t *testing.T) {
+	up := func(ctx context.Context, db mongo.Database) error { return nil }
+
+	_, err := mongo.NewMigrator(&mocks.Database{}, "", mongo.Migration{Version: 1, Up: up}, mongo.Migration{Version: 1, Up: up})
+	assert.Error(t, err)
+
+	_, err = mongo.NewMigrator(&mocks.Database{}, "", mongo.Migration{Version: 1})
+	assert.Error(t, err)
+
+	_, err = mongo.NewMigrator(&mocks.Database{}, "", mongo.Migration{Version: 0, Up: up})
+	assert.Error(t, err)
+}
+
+func TestMigratorUp(t *testing.T) {
+	calls := []string{}
+	database := &mocks.Database{}
+	collection := &mocks.Collection{}
+	database.On("Collection", mongo.DefaultMigrationCollection).Return(collection)
+	collection.On("Find", mock.Anything, bson.M{}, mock.Anything).Return(appliedCursor(t, 1), nil).Once()
+	collection.On("InsertOne", mock.Anything, mock.MatchedBy(func(r mongo.MigrationRecord) bool {
+		return r.Version == 2
+	})).Return(int64(2), nil).Once()
+
+	migrator, err := mongo.NewMigrator(database, "", newMigrations(&calls)...)
+	assert.NoError(t, err)
+
+	applied, err := migrator.Up(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Len(t, applied, 1)
+	assert.Equal(t, []string{"up-2"}, calls)
+	collection.AssertExpectations(t)
+}
+
+func TestMigratorDown(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		calls := []string{}
+		database := &mocks.Database{}
+		collection := &mocks.Collection{}
+		database.On("Collection", mongo.DefaultMigrationCollection).Return(collection)
+		collection.On("Find", mock.Anything, bson.M{}, mock.Anything).Return(appliedCursor(t, 1, 2), nil).Once()
+		collection.On("DeleteOne", mock.Anything, bson.M{"_id": int64(2)}).Return(int64(1), nil).Once()
+		collection.On("DeleteOne", mock.Anything, bson.M{"_id": int64(1)}).Return(int64(1), nil).Once()
+
+		migrator, err := mongo.NewMigrator(database, "", newMigrations(&calls)...)
+		assert.NoError(t, err)
+
+		reverted, err := migrator.Down(context.Background(), 5)
+		assert.NoError(t, err)
+		assert.Len(t, reverted, 2)
+		assert.Equal(t, []string{"down-2", "down-1"}, calls)
+		collection.AssertExpectations(t)
+	})
+
+	t.Run("missing down", func(t *testing.T) {
+		calls := []string{}
+		database := &mocks.Database{}
+		collection := &mocks.Collection{}
+		database.On("Collection", mongo.DefaultMigrationCollection).Return(collection)
+		collection.On("Find", mock.Anything, bson.M{}, mock.Anything).Return(appliedCursor(t, 1, 2, 3), nil).Once()
+
+		migrator, err := mongo.NewMigrator(database, "", newMigrations(&calls)...)
+		assert.NoError(t, err)
+
+		_, err = migrator.Down(context.Background(), 1)
+		assert.Error(t, err)
+		assert.Empty(t, calls)
+	})
+}
+
+func TestMigratorStatus(t *testing.T) {
+	calls := []string{}
+	database := &mocks.Database{}
+	collection := &mocks.Collection{}
+	database.On("Collection", mongo.DefaultMigrationCollection).Return(collection)
+	collection.On("Find", mock.Anything, bson.M{}, mock.Anything).Return(appliedCursor(t, 1), nil).Once()
+
+	migrator, err := mongo.NewMigrator(database, "", newMigrations(&calls)...)
+	assert.NoError(t, err)
+
+	status, err := migrator.Status(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, status, 3)
+	assert.NotNil(t, status[0].AppliedAt)
+	assert.Nil(t, status[1].AppliedAt)
+}
@@ -0,0 +1,98 @@
+package mocks
+
+import (
+	context "context"
+	io "io"
+
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	mock "github.com/stretchr/testify/mock"
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+	options "go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Bucket is an autogenerated mock type for the Bucket type
+type Bucket struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, fileID
+func (_m *Bucket) Delete(ctx context.Context, fileID interface{}) error {
+	ret := _m.Called(ctx, fileID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) error); ok {
+		r0 = rf(ctx, fileID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OpenDownloadStream provides a mock function with given fields: ctx, fileID
+func (_m *Bucket) OpenDownloadStream(ctx context.Context, fileID interface{}) (mongo.DownloadStream, error) {
+	ret := _m.Called(ctx, fileID)
+
+	var r0 mongo.DownloadStream
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) mongo.DownloadStream); ok {
+		r0 = rf(ctx, fileID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(mongo.DownloadStream)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}) error); ok {
+		r1 = rf(ctx, fileID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UploadFromStream provides a mock function with given fields: ctx, filename, source, opts
+func (_m *Bucket) UploadFromStream(ctx context.Context, filename string, source io.Reader, opts ...*options.UploadOptions) (primitive.ObjectID, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, filename, source)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 primitive.ObjectID
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader, ...*options.UploadOptions) primitive.ObjectID); ok {
+		r0 = rf(ctx, filename, source, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(primitive.ObjectID)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, io.Reader, ...*options.UploadOptions) error); ok {
+		r1 = rf(ctx, filename, source, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewBucket interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewBucket creates a new instance of Bucket. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewBucket(t mockConstructorTestingTNewBucket) *Bucket {
+	mock := &Bucket{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,114 @@
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	bson "go.mongodb.org/mongo-driver/bson"
+)
+
+// ChangeStream is an autogenerated mock type for the ChangeStream type
+type ChangeStream struct {
+	mock.Mock
+}
+
+// Close provides a mock function with given fields: a0
+func (_m *ChangeStream) Close(a0 context.Context) error {
+	ret := _m.Called(a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Decode provides a mock function with given fields: a0
+func (_m *ChangeStream) Decode(a0 interface{}) error {
+	ret := _m.Called(a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(interface{}) error); ok {
+		r0 = rf(a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Err provides a mock function with given fields:
+func (_m *ChangeStream) Err() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Next provides a mock function with given fields: a0
+func (_m *ChangeStream) Next(a0 context.Context) bool {
+	ret := _m.Called(a0)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context) bool); ok {
+		r0 = rf(a0)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// ResumeToken provides a mock function with given fields:
+func (_m *ChangeStream) ResumeToken() bson.Raw {
+	ret := _m.Called()
+
+	var r0 bson.Raw
+	if rf, ok := ret.Get(0).(func() bson.Raw); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(bson.Raw)
+		}
+	}
+
+	return r0
+}
+
+// TryNext provides a mock function with given fields: a0
+func (_m *ChangeStream) TryNext(a0 context.Context) bool {
+	ret := _m.Called(a0)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context) bool); ok {
+		r0 = rf(a0)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewChangeStream interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewChangeStream creates a new instance of ChangeStream. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewChangeStream(t mockConstructorTestingTNewChangeStream) *ChangeStream {
+	mock := &ChangeStream{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
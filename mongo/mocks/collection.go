@@ -85,6 +85,92 @@ func (_m *Collection) DeleteOne(a0 context.Context, a1 interface{}) (int64, erro
 	return r0, r1
 }
 
+// DeleteMany provides a mock function with given fields: a0, a1, a2
+func (_m *Collection) DeleteMany(a0 context.Context, a1 interface{}, a2 ...*options.DeleteOptions) (int64, error) {
+	va := make([]interface{}, len(a2))
+	for _i := range a2 {
+		va[_i] = a2[_i]
+	}
+	var ca []interface{}
+	ca = append(ca, a0, a1)
+	ca = append(ca, va...)
+	ret := _m.Called(ca...)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, ...*options.DeleteOptions) int64); ok {
+		r0 = rf(a0, a1, a2...)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, ...*options.DeleteOptions) error); ok {
+		r1 = rf(a0, a1, a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Distinct provides a mock function with given fields: a0, a1, a2, a3
+func (_m *Collection) Distinct(a0 context.Context, a1 string, a2 interface{}, a3 ...*options.DistinctOptions) ([]interface{}, error) {
+	va := make([]interface{}, len(a3))
+	for _i := range a3 {
+		va[_i] = a3[_i]
+	}
+	var ca []interface{}
+	ca = append(ca, a0, a1, a2)
+	ca = append(ca, va...)
+	ret := _m.Called(ca...)
+
+	var r0 []interface{}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, ...*options.DistinctOptions) []interface{}); ok {
+		r0 = rf(a0, a1, a2, a3...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]interface{})
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, ...*options.DistinctOptions) error); ok {
+		r1 = rf(a0, a1, a2, a3...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EstimatedDocumentCount provides a mock function with given fields: a0, a1
+func (_m *Collection) EstimatedDocumentCount(a0 context.Context, a1 ...*options.EstimatedDocumentCountOptions) (int64, error) {
+	va := make([]interface{}, len(a1))
+	for _i := range a1 {
+		va[_i] = a1[_i]
+	}
+	var ca []interface{}
+	ca = append(ca, a0)
+	ca = append(ca, va...)
+	ret := _m.Called(ca...)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, ...*options.EstimatedDocumentCountOptions) int64); ok {
+		r0 = rf(a0, a1...)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ...*options.EstimatedDocumentCountOptions) error); ok {
+		r1 = rf(a0, a1...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Find provides a mock function with given fields: a0, a1, a2
 func (_m *Collection) Find(a0 context.Context, a1 interface{}, a2 ...*options.FindOptions) (mongo.Cursor, error) {
 	va := make([]interface{}, len(a2))
@@ -115,13 +201,66 @@ func (_m *Collection) Find(a0 context.Context, a1 interface{}, a2 ...*options.Fi
 	return r0, r1
 }
 
-// FindOne provides a mock function with given fields: a0, a1
-func (_m *Collection) FindOne(a0 context.Context, a1 interface{}) mongo.SingleResult {
-	ret := _m.Called(a0, a1)
+// FindOne provides a mock function with given fields: a0, a1, a2
+func (_m *Collection) FindOne(a0 context.Context, a1 interface{}, a2 ...*options.FindOneOptions) mongo.SingleResult {
+	va := make([]interface{}, len(a2))
+	for _i := range a2 {
+		va[_i] = a2[_i]
+	}
+	var ca []interface{}
+	ca = append(ca, a0, a1)
+	ca = append(ca, va...)
+	ret := _m.Called(ca...)
 
 	var r0 mongo.SingleResult
-	if rf, ok := ret.Get(0).(func(context.Context, interface{}) mongo.SingleResult); ok {
-		r0 = rf(a0, a1)
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, ...*options.FindOneOptions) mongo.SingleResult); ok {
+		r0 = rf(a0, a1, a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(mongo.SingleResult)
+		}
+	}
+
+	return r0
+}
+
+// FindOneAndDelete provides a mock function with given fields: a0, a1, a2
+func (_m *Collection) FindOneAndDelete(a0 context.Context, a1 interface{}, a2 ...*options.FindOneAndDeleteOptions) mongo.SingleResult {
+	va := make([]interface{}, len(a2))
+	for _i := range a2 {
+		va[_i] = a2[_i]
+	}
+	var ca []interface{}
+	ca = append(ca, a0, a1)
+	ca = append(ca, va...)
+	ret := _m.Called(ca...)
+
+	var r0 mongo.SingleResult
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, ...*options.FindOneAndDeleteOptions) mongo.SingleResult); ok {
+		r0 = rf(a0, a1, a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(mongo.SingleResult)
+		}
+	}
+
+	return r0
+}
+
+// FindOneAndUpdate provides a mock function with given fields: a0, a1, a2, a3
+func (_m *Collection) FindOneAndUpdate(a0 context.Context, a1, a2 interface{}, a3 ...*options.FindOneAndUpdateOptions) mongo.SingleResult {
+	va := make([]interface{}, len(a3))
+	for _i := range a3 {
+		va[_i] = a3[_i]
+	}
+	var ca []interface{}
+	ca = append(ca, a0, a1, a2)
+	ca = append(ca, va...)
+	ret := _m.Called(ca...)
+
+	var r0 mongo.SingleResult
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, interface{}, ...*options.FindOneAndUpdateOptions) mongo.SingleResult); ok {
+		r0 = rf(a0, a1, a2, a3...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(mongo.SingleResult)
@@ -177,6 +316,36 @@ func (_m *Collection) InsertOne(a0 context.Context, a1 interface{}) (interface{}
 	return r0, r1
 }
 
+// ReplaceOne provides a mock function with given fields: a0, a1, a2, a3
+func (_m *Collection) ReplaceOne(a0 context.Context, a1, a2 interface{}, a3 ...*options.ReplaceOptions) (*mongo_mock.UpdateResult, error) {
+	va := make([]interface{}, len(a3))
+	for _i := range a3 {
+		va[_i] = a3[_i]
+	}
+	var ca []interface{}
+	ca = append(ca, a0, a1, a2)
+	ca = append(ca, va...)
+	ret := _m.Called(ca...)
+
+	var r0 *mongo_mock.UpdateResult
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, interface{}, ...*options.ReplaceOptions) *mongo_mock.UpdateResult); ok {
+		r0 = rf(a0, a1, a2, a3...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*mongo_mock.UpdateResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, interface{}, ...*options.ReplaceOptions) error); ok {
+		r1 = rf(a0, a1, a2, a3...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateMany provides a mock function with given fields: a0, a1, a2, a3
 func (_m *Collection) UpdateMany(a0 context.Context, a1, a2 interface{}, a3 ...*options.UpdateOptions) (*mongo_mock.UpdateResult, error) {
 	va := make([]interface{}, len(a3))
@@ -212,6 +381,82 @@ func (_m *Collection) UpdateOne(a0 context.Context, a1, a2 interface{}, a3 ...*o
 	return _m.UpdateMany(a0, a1, a2, a3...)
 }
 
+// BulkWrite provides a mock function with given fields: a0, a1, a2
+func (_m *Collection) BulkWrite(a0 context.Context, a1 []mongo_mock.WriteModel, a2 ...*options.BulkWriteOptions) (*mongo_mock.BulkWriteResult, error) {
+	va := make([]interface{}, len(a2))
+	for _i := range a2 {
+		va[_i] = a2[_i]
+	}
+	var ca []interface{}
+	ca = append(ca, a0, a1)
+	ca = append(ca, va...)
+	ret := _m.Called(ca...)
+
+	var r0 *mongo_mock.BulkWriteResult
+	if rf, ok := ret.Get(0).(func(context.Context, []mongo_mock.WriteModel, ...*options.BulkWriteOptions) *mongo_mock.BulkWriteResult); ok {
+		r0 = rf(a0, a1, a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*mongo_mock.BulkWriteResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []mongo_mock.WriteModel, ...*options.BulkWriteOptions) error); ok {
+		r1 = rf(a0, a1, a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Indexes provides a mock function with given fields:
+func (_m *Collection) Indexes() mongo.IndexView {
+	ret := _m.Called()
+
+	var r0 mongo.IndexView
+	if rf, ok := ret.Get(0).(func() mongo.IndexView); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(mongo.IndexView)
+		}
+	}
+
+	return r0
+}
+
+// Watch provides a mock function with given fields: a0, a1, a2
+func (_m *Collection) Watch(a0 context.Context, a1 interface{}, a2 ...*options.ChangeStreamOptions) (mongo.ChangeStream, error) {
+	va := make([]interface{}, len(a2))
+	for _i := range a2 {
+		va[_i] = a2[_i]
+	}
+	var ca []interface{}
+	ca = append(ca, a0, a1)
+	ca = append(ca, va...)
+	ret := _m.Called(ca...)
+
+	var r0 mongo.ChangeStream
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, ...*options.ChangeStreamOptions) mongo.ChangeStream); ok {
+		r0 = rf(a0, a1, a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(mongo.ChangeStream)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, ...*options.ChangeStreamOptions) error); ok {
+		r1 = rf(a0, a1, a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewCollection interface {
 	mock.TestingT
 	Cleanup(func())
@@ -53,6 +53,20 @@ func (_m *Cursor) Decode(a0 interface{}) error {
 	return r0
 }
 
+// Err provides a mock function with given fields:
+func (_m *Cursor) Err() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Next provides a mock function with given fields: a0
 func (_m *Cursor) Next(a0 context.Context) bool {
 	ret := _m.Called(a0)
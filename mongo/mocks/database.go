@@ -42,6 +42,22 @@ func (_m *Database) Collection(a0 string) mongo.Collection {
 	return r0
 }
 
+// GridFSBucket provides a mock function with given fields: name
+func (_m *Database) GridFSBucket(name string) mongo.Bucket {
+	ret := _m.Called(name)
+
+	var r0 mongo.Bucket
+	if rf, ok := ret.Get(0).(func(string) mongo.Bucket); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(mongo.Bucket)
+		}
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewDatabase interface {
 	mock.TestingT
 	Cleanup(func())
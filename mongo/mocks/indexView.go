@@ -0,0 +1,160 @@
+package mocks
+
+import (
+	context "context"
+
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	mock "github.com/stretchr/testify/mock"
+	mongo_mock "go.mongodb.org/mongo-driver/mongo"
+	options "go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// IndexView is an autogenerated mock type for the IndexView type
+type IndexView struct {
+	mock.Mock
+}
+
+// CreateMany provides a mock function with given fields: a0, a1, a2
+func (_m *IndexView) CreateMany(a0 context.Context, a1 []mongo_mock.IndexModel, a2 ...*options.CreateIndexesOptions) ([]string, error) {
+	va := make([]interface{}, len(a2))
+	for _i := range a2 {
+		va[_i] = a2[_i]
+	}
+	var ca []interface{}
+	ca = append(ca, a0, a1)
+	ca = append(ca, va...)
+	ret := _m.Called(ca...)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, []mongo_mock.IndexModel, ...*options.CreateIndexesOptions) []string); ok {
+		r0 = rf(a0, a1, a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []mongo_mock.IndexModel, ...*options.CreateIndexesOptions) error); ok {
+		r1 = rf(a0, a1, a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateOne provides a mock function with given fields: a0, a1, a2
+func (_m *IndexView) CreateOne(a0 context.Context, a1 mongo_mock.IndexModel, a2 ...*options.CreateIndexesOptions) (string, error) {
+	va := make([]interface{}, len(a2))
+	for _i := range a2 {
+		va[_i] = a2[_i]
+	}
+	var ca []interface{}
+	ca = append(ca, a0, a1)
+	ca = append(ca, va...)
+	ret := _m.Called(ca...)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, mongo_mock.IndexModel, ...*options.CreateIndexesOptions) string); ok {
+		r0 = rf(a0, a1, a2...)
+	} else {
+		r0 = ret.String(0)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, mongo_mock.IndexModel, ...*options.CreateIndexesOptions) error); ok {
+		r1 = rf(a0, a1, a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DropAll provides a mock function with given fields: a0, a1
+func (_m *IndexView) DropAll(a0 context.Context, a1 ...*options.DropIndexesOptions) error {
+	va := make([]interface{}, len(a1))
+	for _i := range a1 {
+		va[_i] = a1[_i]
+	}
+	var ca []interface{}
+	ca = append(ca, a0)
+	ca = append(ca, va...)
+	ret := _m.Called(ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...*options.DropIndexesOptions) error); ok {
+		r0 = rf(a0, a1...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DropOne provides a mock function with given fields: a0, a1, a2
+func (_m *IndexView) DropOne(a0 context.Context, a1 string, a2 ...*options.DropIndexesOptions) error {
+	va := make([]interface{}, len(a2))
+	for _i := range a2 {
+		va[_i] = a2[_i]
+	}
+	var ca []interface{}
+	ca = append(ca, a0, a1)
+	ca = append(ca, va...)
+	ret := _m.Called(ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...*options.DropIndexesOptions) error); ok {
+		r0 = rf(a0, a1, a2...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// List provides a mock function with given fields: a0, a1
+func (_m *IndexView) List(a0 context.Context, a1 ...*options.ListIndexesOptions) (mongo.Cursor, error) {
+	va := make([]interface{}, len(a1))
+	for _i := range a1 {
+		va[_i] = a1[_i]
+	}
+	var ca []interface{}
+	ca = append(ca, a0)
+	ca = append(ca, va...)
+	ret := _m.Called(ca...)
+
+	var r0 mongo.Cursor
+	if rf, ok := ret.Get(0).(func(context.Context, ...*options.ListIndexesOptions) mongo.Cursor); ok {
+		r0 = rf(a0, a1...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(mongo.Cursor)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ...*options.ListIndexesOptions) error); ok {
+		r1 = rf(a0, a1...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIndexView interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIndexView creates a new instance of IndexView. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIndexView(t mockConstructorTestingTNewIndexView) *IndexView {
+	mock := &IndexView{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
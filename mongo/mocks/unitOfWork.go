@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UnitOfWork is a mock type for the UnitOfWork type. When no function is returned it runs fn
+// directly so usecase tests exercise the repository calls made inside the transaction.
+type UnitOfWork struct {
+	mock.Mock
+}
+
+// WithTransaction provides a mock function with given fields: ctx, fn
+func (_m *UnitOfWork) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		return rf(ctx, fn)
+	}
+	if err := ret.Error(0); err != nil {
+		return err
+	}
+	return fn(ctx)
+}
+
+type mockConstructorTestingTNewUnitOfWork interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUnitOfWork creates a new instance of UnitOfWork. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUnitOfWork(t mockConstructorTestingTNewUnitOfWork) *UnitOfWork {
+	mock := &UnitOfWork{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
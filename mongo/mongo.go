@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
@@ -14,21 +15,52 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
+// ErrNoDocuments is returned by SingleResult.Decode when the filter matched no document.
+var ErrNoDocuments = mongo.ErrNoDocuments
+
 type Database interface {
 	Collection(string) Collection
+	// GridFSBucket returns the GridFS bucket of the given name.
+	GridFSBucket(name string) Bucket
 	Client() Client
 }
 
 type Collection interface {
-	FindOne(context.Context, interface{}) SingleResult
+	FindOne(context.Context, interface{}, ...*options.FindOneOptions) SingleResult
+	FindOneAndUpdate(context.Context, interface{}, interface{}, ...*options.FindOneAndUpdateOptions) SingleResult
+	FindOneAndDelete(context.Context, interface{}, ...*options.FindOneAndDeleteOptions) SingleResult
 	InsertOne(context.Context, interface{}) (interface{}, error)
 	InsertMany(context.Context, []interface{}) ([]interface{}, error)
+	ReplaceOne(context.Context, interface{}, interface{}, ...*options.ReplaceOptions) (*mongo.UpdateResult, error)
 	DeleteOne(context.Context, interface{}) (int64, error)
+	DeleteMany(context.Context, interface{}, ...*options.DeleteOptions) (int64, error)
 	Find(context.Context, interface{}, ...*options.FindOptions) (Cursor, error)
 	CountDocuments(context.Context, interface{}, ...*options.CountOptions) (int64, error)
+	EstimatedDocumentCount(context.Context, ...*options.EstimatedDocumentCountOptions) (int64, error)
+	Distinct(context.Context, string, interface{}, ...*options.DistinctOptions) ([]interface{}, error)
 	Aggregate(context.Context, interface{}) (Cursor, error)
 	UpdateOne(context.Context, interface{}, interface{}, ...*options.UpdateOptions) (*mongo.UpdateResult, error)
 	UpdateMany(context.Context, interface{}, interface{}, ...*options.UpdateOptions) (*mongo.UpdateResult, error)
+	BulkWrite(context.Context, []mongo.WriteModel, ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error)
+	Indexes() IndexView
+	Watch(context.Context, interface{}, ...*options.ChangeStreamOptions) (ChangeStream, error)
+}
+
+type IndexView interface {
+	CreateOne(context.Context, mongo.IndexModel, ...*options.CreateIndexesOptions) (string, error)
+	CreateMany(context.Context, []mongo.IndexModel, ...*options.CreateIndexesOptions) ([]string, error)
+	DropOne(context.Context, string, ...*options.DropIndexesOptions) error
+	DropAll(context.Context, ...*options.DropIndexesOptions) error
+	List(context.Context, ...*options.ListIndexesOptions) (Cursor, error)
+}
+
+type ChangeStream interface {
+	Close(context.Context) error
+	Next(context.Context) bool
+	TryNext(context.Context) bool
+	Decode(interface{}) error
+	Err() error
+	ResumeToken() bson.Raw
 }
 
 type SingleResult interface {
@@ -40,6 +72,8 @@ type Cursor interface {
 	Next(context.Context) bool
 	Decode(interface{}) error
 	All(context.Context, interface{}) error
+	// Err reports the error that ended a Next loop early, nil when the cursor was exhausted.
+	Err() error
 }
 
 type Client interface {
@@ -61,6 +95,14 @@ type mongoCollection struct {
 	coll *mongo.Collection
 }
 
+type mongoIndexView struct {
+	iv mongo.IndexView
+}
+
+type mongoChangeStream struct {
+	cs *mongo.ChangeStream
+}
+
 type mongoSingleResult struct {
 	sr *mongo.SingleResult
 }
@@ -69,10 +111,6 @@ type mongoCursor struct {
 	mc *mongo.Cursor
 }
 
-type mongoSession struct {
-	mongo.Session
-}
-
 type nullawareDecoder struct {
 	defDecoder bsoncodec.ValueDecoder
 	zeroValue  reflect.Value
@@ -95,9 +133,13 @@ func (d *nullawareDecoder) DecodeValue(dctx bsoncodec.DecodeContext, vr bsonrw.V
 }
 
 func NewClient(connection string) (Client, error) {
+	return NewClientWithOptions(options.Client().ApplyURI(connection))
+}
+
+func NewClientWithOptions(opts ...*options.ClientOptions) (Client, error) {
 
 	time.Local = time.UTC
-	c, err := mongo.NewClient(options.Client().ApplyURI(connection))
+	c, err := mongo.NewClient(opts...)
 
 	return &mongoClient{cl: c}, err
 
@@ -116,9 +158,10 @@ func (mc *mongoClient) UseSession(ctx context.Context, fn func(mongo.SessionCont
 	return mc.cl.UseSession(ctx, fn)
 }
 
+// StartSession returns the driver session unwrapped, the driver only joins operations to
+// a transaction when the context carries its own session type.
 func (mc *mongoClient) StartSession() (mongo.Session, error) {
-	session, err := mc.cl.StartSession()
-	return &mongoSession{session}, err
+	return mc.cl.StartSession()
 }
 
 func (mc *mongoClient) Connect(ctx context.Context) error {
@@ -134,16 +177,66 @@ func (md *mongoDatabase) Collection(colName string) Collection {
 	return &mongoCollection{coll: collection}
 }
 
+func (md *mongoDatabase) GridFSBucket(name string) Bucket {
+	return &mongoBucket{db: md.db, name: name}
+}
+
 func (md *mongoDatabase) Client() Client {
 	client := md.db.Client()
 	return &mongoClient{cl: client}
 }
 
-func (mc *mongoCollection) FindOne(ctx context.Context, filter interface{}) SingleResult {
-	singleResult := mc.coll.FindOne(ctx, filter)
+func (mc *mongoCollection) FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) SingleResult {
+	singleResult := mc.coll.FindOne(ctx, filter, opts...)
+	return &mongoSingleResult{sr: singleResult}
+}
+
+func (mc *mongoCollection) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) SingleResult {
+	singleResult := mc.coll.FindOneAndUpdate(ctx, filter, update, opts...)
 	return &mongoSingleResult{sr: singleResult}
 }
 
+func (mc *mongoCollection) FindOneAndDelete(ctx context.Context, filter interface{}, opts ...*options.FindOneAndDeleteOptions) SingleResult {
+	singleResult := mc.coll.FindOneAndDelete(ctx, filter, opts...)
+	return &mongoSingleResult{sr: singleResult}
+}
+
+func (mc *mongoCollection) ReplaceOne(ctx context.Context, filter interface{}, replacement interface{}, opts ...*options.ReplaceOptions) (*mongo.UpdateResult, error) {
+	return mc.coll.ReplaceOne(ctx, filter, replacement, opts...)
+}
+
+func (mc *mongoCollection) DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (int64, error) {
+	res, err := mc.coll.DeleteMany(ctx, filter, opts...)
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
+func (mc *mongoCollection) BulkWrite(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error) {
+	return mc.coll.BulkWrite(ctx, models, opts...)
+}
+
+func (mc *mongoCollection) Distinct(ctx context.Context, fieldName string, filter interface{}, opts ...*options.DistinctOptions) ([]interface{}, error) {
+	return mc.coll.Distinct(ctx, fieldName, filter, opts...)
+}
+
+func (mc *mongoCollection) EstimatedDocumentCount(ctx context.Context, opts ...*options.EstimatedDocumentCountOptions) (int64, error) {
+	return mc.coll.EstimatedDocumentCount(ctx, opts...)
+}
+
+func (mc *mongoCollection) Indexes() IndexView {
+	return &mongoIndexView{iv: mc.coll.Indexes()}
+}
+
+func (mc *mongoCollection) Watch(ctx context.Context, pipeline interface{}, opts ...*options.ChangeStreamOptions) (ChangeStream, error) {
+	cs, err := mc.coll.Watch(ctx, pipeline, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &mongoChangeStream{cs: cs}, nil
+}
+
 func (mc *mongoCollection) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
 	return mc.coll.UpdateOne(ctx, filter, update, opts[:]...)
 }
@@ -155,6 +248,9 @@ func (mc *mongoCollection) InsertOne(ctx context.Context, document interface{})
 
 func (mc *mongoCollection) InsertMany(ctx context.Context, document []interface{}) ([]interface{}, error) {
 	res, err := mc.coll.InsertMany(ctx, document)
+	if res == nil {
+		return nil, err
+	}
 	return res.InsertedIDs, err
 }
 
@@ -200,3 +296,54 @@ func (mr *mongoCursor) Decode(v interface{}) error {
 func (mr *mongoCursor) All(ctx context.Context, result interface{}) error {
 	return mr.mc.All(ctx, result)
 }
+
+func (mr *mongoCursor) Err() error {
+	return mr.mc.Err()
+}
+
+func (iv *mongoIndexView) CreateOne(ctx context.Context, model mongo.IndexModel, opts ...*options.CreateIndexesOptions) (string, error) {
+	return iv.iv.CreateOne(ctx, model, opts...)
+}
+
+func (iv *mongoIndexView) CreateMany(ctx context.Context, models []mongo.IndexModel, opts ...*options.CreateIndexesOptions) ([]string, error) {
+	return iv.iv.CreateMany(ctx, models, opts...)
+}
+
+func (iv *mongoIndexView) DropOne(ctx context.Context, name string, opts ...*options.DropIndexesOptions) error {
+	_, err := iv.iv.DropOne(ctx, name, opts...)
+	return err
+}
+
+func (iv *mongoIndexView) DropAll(ctx context.Context, opts ...*options.DropIndexesOptions) error {
+	_, err := iv.iv.DropAll(ctx, opts...)
+	return err
+}
+
+func (iv *mongoIndexView) List(ctx context.Context, opts ...*options.ListIndexesOptions) (Cursor, error) {
+	cursor, err := iv.iv.List(ctx, opts...)
+	return &mongoCursor{mc: cursor}, err
+}
+
+func (cs *mongoChangeStream) Close(ctx context.Context) error {
+	return cs.cs.Close(ctx)
+}
+
+func (cs *mongoChangeStream) Next(ctx context.Context) bool {
+	return cs.cs.Next(ctx)
+}
+
+func (cs *mongoChangeStream) TryNext(ctx context.Context) bool {
+	return cs.cs.TryNext(ctx)
+}
+
+func (cs *mongoChangeStream) Decode(v interface{}) error {
+	return cs.cs.Decode(v)
+}
+
+func (cs *mongoChangeStream) Err() error {
+	return cs.cs.Err()
+}
+
+func (cs *mongoChangeStream) ResumeToken() bson.Raw {
+	return cs.cs.ResumeToken()
+}
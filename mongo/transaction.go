@@ -0,0 +1,98 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	labelTransientTransactionError      = "TransientTransactionError"
+	labelUnknownTransactionCommitResult = "UnknownTransactionCommitResult"
+
+	defaultTransactionRetries = 3
+)
+
+// UnitOfWork runs several repository calls atomically. Repositories join the transaction
+// by using the context passed to fn, which carries the session.
+type UnitOfWork interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type TransactionOptions struct {
+	// MaxRetries is the number of retries on transient transaction errors (default 3).
+	MaxRetries int
+	Txn        *options.TransactionOptions
+}
+
+type unitOfWork struct {
+	client Client
+	opts   TransactionOptions
+}
+
+func NewUnitOfWork(client Client, opts ...TransactionOptions) UnitOfWork {
+	u := &unitOfWork{client: client}
+	if len(opts) > 0 {
+		u.opts = opts[0]
+	}
+	if u.opts.MaxRetries <= 0 {
+		u.opts.MaxRetries = defaultTransactionRetries
+	}
+	return u
+}
+
+// InTransaction reports whether ctx carries a session started by a UnitOfWork.
+func InTransaction(ctx context.Context) bool {
+	return mongo.SessionFromContext(ctx) != nil
+}
+
+func (u *unitOfWork) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Nested calls join the outer transaction
+	if InTransaction(ctx) {
+		return fn(ctx)
+	}
+
+	session, err := u.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(context.Background())
+
+	for attempt := 0; ; attempt++ {
+		err = u.runTransaction(mongo.NewSessionContext(ctx, session), session, fn)
+		if err == nil {
+			return nil
+		}
+		if !hasErrorLabel(err, labelTransientTransactionError) || attempt >= u.opts.MaxRetries {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+func (u *unitOfWork) runTransaction(sc mongo.SessionContext, session mongo.Session, fn func(ctx context.Context) error) error {
+	if err := session.StartTransaction(u.opts.Txn); err != nil {
+		return err
+	}
+
+	if err := fn(sc); err != nil {
+		_ = session.AbortTransaction(context.Background())
+		return err
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := session.CommitTransaction(sc)
+		if err == nil || !hasErrorLabel(err, labelUnknownTransactionCommitResult) || attempt >= u.opts.MaxRetries {
+			return err
+		}
+	}
+}
+
+func hasErrorLabel(err error, label string) bool {
+	var le mongo.LabeledError
+	return errors.As(err, &le) && le.HasErrorLabel(label)
+}
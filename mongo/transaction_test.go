@@ -0,0 +1,135 @@
+package mongo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo/mocks"
+	"github.com/stretchr/testify/assert"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type fakeSession struct {
+	driver.Session
+	commitErrs []error
+	started    int
+	aborted    int
+	committed  int
+	ended      bool
+}
+
+func (s *fakeSession) StartTransaction(...*options.TransactionOptions) error {
+	s.started++
+	return nil
+}
+
+func (s *fakeSession) AbortTransaction(context.Context) error {
+	s.aborted++
+	return nil
+}
+
+func (s *fakeSession) CommitTransaction(context.Context) error {
+	s.committed++
+	if len(s.commitErrs) > 0 {
+		err := s.commitErrs[0]
+		s.commitErrs = s.commitErrs[1:]
+		return err
+	}
+	return nil
+}
+
+func (s *fakeSession) EndSession(context.Context) {
+	s.ended = true
+}
+
+func labeled(label string) error {
+	return driver.CommandError{Message: label, Labels: []string{label}}
+}
+
+func TestUnitOfWorkWithTransaction(t *testing.T) {
+	t.Run("commit", func(t *testing.T) {
+		session := &fakeSession{}
+		client := &mocks.Client{}
+		client.On("StartSession").Return(session, nil).Once()
+
+		uow := mongo.NewUnitOfWork(client)
+		err := uow.WithTransaction(context.Background(), func(ctx context.Context) error {
+			assert.True(t, mongo.InTransaction(ctx))
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, session.started)
+		assert.Equal(t, 1, session.committed)
+		assert.True(t, session.ended)
+	})
+
+	t.Run("abort on error", func(t *testing.T) {
+		session := &fakeSession{}
+		client := &mocks.Client{}
+		client.On("StartSession").Return(session, nil).Once()
+
+		uow := mongo.NewUnitOfWork(client)
+		err := uow.WithTransaction(context.Background(), func(ctx context.Context) error {
+			return assert.AnError
+		})
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, 1, session.aborted)
+		assert.Equal(t, 0, session.committed)
+	})
+
+	t.Run("retry transient error", func(t *testing.T) {
+		session := &fakeSession{}
+		client := &mocks.Client{}
+		client.On("StartSession").Return(session, nil).Once()
+
+		calls := 0
+		uow := mongo.NewUnitOfWork(client, mongo.TransactionOptions{MaxRetries: 2})
+		err := uow.WithTransaction(context.Background(), func(ctx context.Context) error {
+			calls++
+			if calls < 3 {
+				return labeled("TransientTransactionError")
+			}
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls)
+		assert.Equal(t, 3, session.started)
+	})
+
+	t.Run("retry unknown commit result", func(t *testing.T) {
+		session := &fakeSession{commitErrs: []error{labeled("UnknownTransactionCommitResult")}}
+		client := &mocks.Client{}
+		client.On("StartSession").Return(session, nil).Once()
+
+		uow := mongo.NewUnitOfWork(client)
+		err := uow.WithTransaction(context.Background(), func(ctx context.Context) error {
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, session.started)
+		assert.Equal(t, 2, session.committed)
+	})
+
+	t.Run("nested joins outer", func(t *testing.T) {
+		session := &fakeSession{}
+		client := &mocks.Client{}
+		client.On("StartSession").Return(session, nil).Once()
+
+		uow := mongo.NewUnitOfWork(client)
+		err := uow.WithTransaction(context.Background(), func(ctx context.Context) error {
+			return uow.WithTransaction(ctx, func(ctx context.Context) error {
+				return nil
+			})
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, session.started)
+		client.AssertExpectations(t)
+	})
+}
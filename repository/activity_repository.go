@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type activityRepository struct {
+	activities *Mongo[domain.TaskActivity]
+}
+
+func ActivityIndexes() []mongo.Index {
+	return []mongo.Index{
+		{Name: "taskID_1_at_-1", Keys: bson.D{{Key: "taskID", Value: 1}, {Key: "at", Value: -1}}},
+	}
+}
+
+func NewActivityRepository(db mongo.Database, collection string) domain.TaskActivityRepository {
+	return NewActivityRepositoryWithClock(db, collection, domain.SystemClock{})
+}
+
+func NewActivityRepositoryWithClock(db mongo.Database, collection string, clock domain.Clock) domain.TaskActivityRepository {
+	return &activityRepository{
+		activities: NewMongo[domain.TaskActivity](db, collection).Use(AuditHook{Clock: clock}),
+	}
+}
+
+func (r *activityRepository) Append(c context.Context, activities ...domain.TaskActivity) error {
+	return r.activities.InsertMany(c, activities)
+}
+
+func (r *activityRepository) FetchByTaskID(c context.Context, taskID primitive.ObjectID, page domain.PageRequest) ([]domain.TaskActivity, int64, error) {
+	filter := bson.M{"taskID": taskID}
+
+	total, err := r.activities.Count(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	activities, err := r.activities.FindMany(c, filter, FindOptions{
+		Sort: bson.D{{Key: "at", Value: -1}, {Key: "_id", Value: -1}},
+		Page: page.Page,
+		Size: page.Size,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return activities, total, nil
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MockActivityRepository struct {
+	mock.Mock
+}
+
+func (_m *MockActivityRepository) Append(c context.Context, activities ...domain.TaskActivity) error {
+	ret := _m.Called(c, activities)
+	return ret.Error(0)
+}
+
+func (_m *MockActivityRepository) FetchByTaskID(c context.Context, taskID primitive.ObjectID, page domain.PageRequest) ([]domain.TaskActivity, int64, error) {
+	ret := _m.Called(c, taskID, page)
+
+	var r0 []domain.TaskActivity
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]domain.TaskActivity)
+	}
+
+	return r0, ret.Get(1).(int64), ret.Error(2)
+}
@@ -0,0 +1,67 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo/mocks"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestActivityRepositoryAppend(t *testing.T) {
+	databaseHelper := &mocks.Database{}
+	collectionHelper := &mocks.Collection{}
+	databaseHelper.On("Collection", domain.CollectionTaskActivity).Return(collectionHelper)
+	repo := repository.NewActivityRepositoryWithClock(databaseHelper, domain.CollectionTaskActivity, fixedClock(auditTime))
+	taskID := primitive.NewObjectID()
+	collectionHelper.On("InsertMany", mock.Anything, mock.MatchedBy(func(docs []interface{}) bool {
+		if len(docs) != 2 {
+			return false
+		}
+		for _, doc := range docs {
+			activity := doc.(*domain.TaskActivity)
+			if activity.ID.IsZero() || activity.Actor != "user-1" || !activity.At.Equal(auditTime) {
+				return false
+			}
+		}
+		return true
+	})).Return([]interface{}{primitive.NewObjectID(), primitive.NewObjectID()}, nil).Once()
+
+	err := repo.Append(domain.WithActor(context.TODO(), "user-1"),
+		domain.TaskActivity{TaskID: taskID, Action: domain.ActivityCreated},
+		domain.TaskActivity{TaskID: taskID, Action: domain.ActivityStatusChanged},
+	)
+
+	assert.NoError(t, err)
+	collectionHelper.AssertExpectations(t)
+}
+
+func TestActivityRepositoryFetchByTaskID(t *testing.T) {
+	taskID := primitive.NewObjectID()
+	databaseHelper := &mocks.Database{}
+	collectionHelper := &mocks.Collection{}
+	databaseHelper.On("Collection", domain.CollectionTaskActivity).Return(collectionHelper)
+	repo := repository.NewActivityRepositoryWithClock(databaseHelper, domain.CollectionTaskActivity, fixedClock(auditTime))
+
+	cursor, err := mongo.NewCursorFromDocuments([]any{domain.TaskActivity{TaskID: taskID, Action: domain.ActivityUpdated}}, nil, nil)
+	assert.NoError(t, err)
+	collectionHelper.On("CountDocuments", mock.Anything, bson.M{"taskID": taskID}).Return(int64(1), nil).Once()
+	collectionHelper.On("Find", mock.Anything, bson.M{"taskID": taskID}, mock.MatchedBy(func(opts *options.FindOptions) bool {
+		return *opts.Skip == 0 && *opts.Limit == 20 &&
+			assert.ObjectsAreEqual(bson.D{{Key: "at", Value: -1}, {Key: "_id", Value: -1}}, opts.Sort)
+	})).Return(cursor, nil).Once()
+
+	activities, total, err := repo.FetchByTaskID(context.TODO(), taskID, domain.PageRequest{Page: 1, Size: 20})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ActivityUpdated, activities[0].Action)
+	assert.Equal(t, int64(1), total)
+	collectionHelper.AssertExpectations(t)
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type attachmentRepository struct {
+	database mongo.Database
+	bucket   string
+	files    *Mongo[domain.Attachment]
+}
+
+// AttachmentIndexes are the indexes of the files collection of the attachment bucket.
+func AttachmentIndexes() []mongo.Index {
+	return []mongo.Index{
+		{Name: "metadata.taskID_1_uploadDate_1", Keys: bson.D{{Key: "metadata.taskID", Value: 1}, {Key: "uploadDate", Value: 1}}},
+	}
+}
+
+// AttachmentFiles is the collection of the file documents of a GridFS bucket.
+func AttachmentFiles(bucket string) string {
+	return bucket + ".files"
+}
+
+func NewAttachmentRepository(db mongo.Database, bucket string) domain.AttachmentRepository {
+	return &attachmentRepository{
+		database: db,
+		bucket:   bucket,
+		files:    NewMongo[domain.Attachment](db, AttachmentFiles(bucket)),
+	}
+}
+
+func (r *attachmentRepository) Bucket() mongo.Bucket {
+	return r.database.GridFSBucket(r.bucket)
+}
+
+func (r *attachmentRepository) Upload(c context.Context, attachment *domain.Attachment, content io.Reader) error {
+	opts := options.GridFSUpload().SetMetadata(attachment.AttachmentMetadata)
+	id, err := r.Bucket().UploadFromStream(c, attachment.Filename, content, opts)
+	if err != nil {
+		return err
+	}
+
+	// GridFS sets the length and upload date of the file
+	uploaded, err := r.files.FindOne(c, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	*attachment = uploaded
+	return nil
+}
+
+func (r *attachmentRepository) FetchByTaskID(c context.Context, taskID primitive.ObjectID) ([]domain.Attachment, error) {
+	return r.files.FindMany(c, bson.M{"metadata.taskID": taskID}, FindOptions{
+		Sort: bson.D{{Key: "uploadDate", Value: 1}, {Key: "_id", Value: 1}},
+	})
+}
+
+func (r *attachmentRepository) FetchByID(c context.Context, taskID primitive.ObjectID, attachmentID string) (domain.Attachment, error) {
+	idHex, err := ObjectID(attachmentID)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+
+	return r.files.FindOne(c, bson.M{"_id": idHex, "metadata.taskID": taskID})
+}
+
+func (r *attachmentRepository) Open(c context.Context, attachmentID primitive.ObjectID) (domain.AttachmentContent, error) {
+	stream, err := r.Bucket().OpenDownloadStream(c, attachmentID)
+	if errors.Is(err, mongo.ErrFileNotFound) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+func (r *attachmentRepository) Delete(c context.Context, attachmentID primitive.ObjectID) error {
+	err := r.Bucket().Delete(c, attachmentID)
+	if errors.Is(err, mongo.ErrFileNotFound) {
+		return domain.ErrNotFound
+	}
+	return err
+}
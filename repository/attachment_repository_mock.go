@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"io"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MockAttachmentRepository struct {
+	mock.Mock
+}
+
+func (_m *MockAttachmentRepository) Upload(c context.Context, attachment *domain.Attachment, content io.Reader) error {
+	ret := _m.Called(c, attachment, content)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Attachment, io.Reader) error); ok {
+		r0 = rf(c, attachment, content)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (_m *MockAttachmentRepository) FetchByTaskID(c context.Context, taskID primitive.ObjectID) ([]domain.Attachment, error) {
+	ret := _m.Called(c, taskID)
+
+	var r0 []domain.Attachment
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]domain.Attachment)
+	}
+
+	return r0, ret.Error(1)
+}
+
+func (_m *MockAttachmentRepository) FetchByID(c context.Context, taskID primitive.ObjectID, attachmentID string) (domain.Attachment, error) {
+	ret := _m.Called(c, taskID, attachmentID)
+	return ret.Get(0).(domain.Attachment), ret.Error(1)
+}
+
+func (_m *MockAttachmentRepository) Open(c context.Context, attachmentID primitive.ObjectID) (domain.AttachmentContent, error) {
+	ret := _m.Called(c, attachmentID)
+
+	var r0 domain.AttachmentContent
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).(domain.AttachmentContent)
+	}
+
+	return r0, ret.Error(1)
+}
+
+func (_m *MockAttachmentRepository) Delete(c context.Context, attachmentID primitive.ObjectID) error {
+	ret := _m.Called(c, attachmentID)
+	return ret.Error(0)
+}
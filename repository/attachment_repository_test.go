@@ -0,0 +1,76 @@
+package repository_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo/mocks"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func newAttachmentRepository() (domain.AttachmentRepository, *mocks.Bucket, *mocks.Collection) {
+	databaseHelper := &mocks.Database{}
+	collectionHelper := &mocks.Collection{}
+	databaseHelper.On("Collection", repository.AttachmentFiles(domain.BucketAttachment)).Return(collectionHelper)
+	bucketHelper := &mocks.Bucket{}
+	databaseHelper.On("GridFSBucket", domain.BucketAttachment).Return(bucketHelper)
+
+	return repository.NewAttachmentRepository(databaseHelper, domain.BucketAttachment), bucketHelper, collectionHelper
+}
+
+func TestAttachmentRepositoryUpload(t *testing.T) {
+	repo, bucketHelper, collectionHelper := newAttachmentRepository()
+	fileID, taskID := primitive.NewObjectID(), primitive.NewObjectID()
+	metadata := domain.AttachmentMetadata{TaskID: taskID, ContentType: "text/plain", UploadedBy: "user-1"}
+	content := strings.NewReader("hello")
+
+	bucketHelper.On("UploadFromStream", mock.Anything, "notes.txt", content, mock.MatchedBy(func(opts *options.UploadOptions) bool {
+		return assert.ObjectsAreEqual(metadata, opts.Metadata)
+	})).Return(fileID, nil).Once()
+	stored := domain.Attachment{ID: fileID, Filename: "notes.txt", Size: 5, AttachmentMetadata: metadata}
+	collectionHelper.On("FindOne", mock.Anything, bson.M{"_id": fileID}).Return(mongodriver.NewSingleResultFromDocument(stored, nil, nil)).Once()
+
+	attachment := &domain.Attachment{Filename: "notes.txt", AttachmentMetadata: metadata}
+	err := repo.Upload(context.TODO(), attachment, content)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fileID, attachment.ID)
+	assert.Equal(t, int64(5), attachment.Size)
+	bucketHelper.AssertExpectations(t)
+}
+
+func TestAttachmentRepositoryFetchByTaskID(t *testing.T) {
+	repo, _, collectionHelper := newAttachmentRepository()
+	taskID := primitive.NewObjectID()
+
+	cursor, err := mongodriver.NewCursorFromDocuments([]any{domain.Attachment{Filename: "notes.txt"}}, nil, nil)
+	assert.NoError(t, err)
+	collectionHelper.On("Find", mock.Anything, bson.M{"metadata.taskID": taskID}, mock.Anything).Return(cursor, nil).Once()
+
+	attachments, err := repo.FetchByTaskID(context.TODO(), taskID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "notes.txt", attachments[0].Filename)
+}
+
+func TestAttachmentRepositoryMissingFile(t *testing.T) {
+	repo, bucketHelper, _ := newAttachmentRepository()
+	fileID := primitive.NewObjectID()
+	bucketHelper.On("OpenDownloadStream", mock.Anything, fileID).Return(nil, mongo.ErrFileNotFound).Once()
+	bucketHelper.On("Delete", mock.Anything, fileID).Return(mongo.ErrFileNotFound).Once()
+
+	_, err := repo.Open(context.TODO(), fileID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	err = repo.Delete(context.TODO(), fileID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type commentRepository struct {
+	comments *Mongo[domain.Comment]
+}
+
+func CommentIndexes() []mongo.Index {
+	return []mongo.Index{
+		{Name: "taskID_1_createdAt_1", Keys: bson.D{{Key: "taskID", Value: 1}, {Key: "createdAt", Value: 1}}},
+	}
+}
+
+func NewCommentRepository(db mongo.Database, collection string) domain.CommentRepository {
+	return NewCommentRepositoryWithClock(db, collection, domain.SystemClock{})
+}
+
+func NewCommentRepositoryWithClock(db mongo.Database, collection string, clock domain.Clock) domain.CommentRepository {
+	return &commentRepository{
+		comments: NewMongo[domain.Comment](db, collection).Use(AuditHook{Clock: clock}),
+	}
+}
+
+func (r *commentRepository) Create(c context.Context, comment *domain.Comment) error {
+	comment.ID = primitive.NewObjectID()
+	return r.comments.Insert(c, comment)
+}
+
+func (r *commentRepository) FetchByTaskID(c context.Context, taskID primitive.ObjectID, page domain.PageRequest) ([]domain.Comment, int64, error) {
+	filter := bson.M{"taskID": taskID}
+
+	total, err := r.comments.Count(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	comments, err := r.comments.FindMany(c, filter, FindOptions{
+		Sort: bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}},
+		Page: page.Page,
+		Size: page.Size,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return comments, total, nil
+}
+
+func (r *commentRepository) FetchByID(c context.Context, taskID primitive.ObjectID, commentID string) (domain.Comment, error) {
+	idHex, err := ObjectID(commentID)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	return r.comments.FindOne(c, bson.M{"_id": idHex, "taskID": taskID})
+}
+
+func (r *commentRepository) Delete(c context.Context, commentID string) error {
+	return r.comments.Delete(c, commentID)
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MockCommentRepository struct {
+	mock.Mock
+}
+
+func (_m *MockCommentRepository) Create(c context.Context, comment *domain.Comment) error {
+	ret := _m.Called(c, comment)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Comment) error); ok {
+		r0 = rf(c, comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (_m *MockCommentRepository) FetchByTaskID(c context.Context, taskID primitive.ObjectID, page domain.PageRequest) ([]domain.Comment, int64, error) {
+	ret := _m.Called(c, taskID, page)
+
+	var r0 []domain.Comment
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]domain.Comment)
+	}
+
+	return r0, ret.Get(1).(int64), ret.Error(2)
+}
+
+func (_m *MockCommentRepository) FetchByID(c context.Context, taskID primitive.ObjectID, commentID string) (domain.Comment, error) {
+	ret := _m.Called(c, taskID, commentID)
+	return ret.Get(0).(domain.Comment), ret.Error(1)
+}
+
+func (_m *MockCommentRepository) Delete(c context.Context, commentID string) error {
+	ret := _m.Called(c, commentID)
+	return ret.Error(0)
+}
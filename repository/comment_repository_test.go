@@ -0,0 +1,84 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo/mocks"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestCommentRepositoryCreate(t *testing.T) {
+	databaseHelper := &mocks.Database{}
+	collectionHelper := &mocks.Collection{}
+	databaseHelper.On("Collection", domain.CollectionComment).Return(collectionHelper)
+	repo := repository.NewCommentRepositoryWithClock(databaseHelper, domain.CollectionComment, fixedClock(auditTime))
+	collectionHelper.On("InsertOne", mock.Anything, mock.AnythingOfType("*domain.Comment")).Return(primitive.NewObjectID(), nil).Once()
+
+	comment := &domain.Comment{TaskID: primitive.NewObjectID(), Body: "looks good"}
+	err := repo.Create(domain.WithActor(context.TODO(), "user-1"), comment)
+
+	assert.NoError(t, err)
+	assert.False(t, comment.ID.IsZero())
+	assert.Equal(t, "user-1", comment.Author)
+	assert.Equal(t, auditTime, comment.CreatedAt)
+}
+
+func TestCommentRepositoryFetchByTaskID(t *testing.T) {
+	taskID := primitive.NewObjectID()
+	databaseHelper := &mocks.Database{}
+	collectionHelper := &mocks.Collection{}
+	databaseHelper.On("Collection", domain.CollectionComment).Return(collectionHelper)
+	repo := repository.NewCommentRepositoryWithClock(databaseHelper, domain.CollectionComment, fixedClock(auditTime))
+
+	cursor, err := mongo.NewCursorFromDocuments([]any{domain.Comment{TaskID: taskID, Body: "first"}}, nil, nil)
+	assert.NoError(t, err)
+	collectionHelper.On("CountDocuments", mock.Anything, bson.M{"taskID": taskID}).Return(int64(21), nil).Once()
+	collectionHelper.On("Find", mock.Anything, bson.M{"taskID": taskID}, mock.MatchedBy(func(opts *options.FindOptions) bool {
+		return *opts.Skip == 20 && *opts.Limit == 20 &&
+			assert.ObjectsAreEqual(bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}}, opts.Sort)
+	})).Return(cursor, nil).Once()
+
+	comments, total, err := repo.FetchByTaskID(context.TODO(), taskID, domain.PageRequest{Page: 2, Size: 20})
+
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, int64(21), total)
+	collectionHelper.AssertExpectations(t)
+}
+
+func TestCommentRepositoryFetchByID(t *testing.T) {
+	taskID, commentID := primitive.NewObjectID(), primitive.NewObjectID()
+
+	t.Run("success", func(t *testing.T) {
+		databaseHelper := &mocks.Database{}
+		collectionHelper := &mocks.Collection{}
+		databaseHelper.On("Collection", domain.CollectionComment).Return(collectionHelper)
+		repo := repository.NewCommentRepositoryWithClock(databaseHelper, domain.CollectionComment, fixedClock(auditTime))
+		result := mongo.NewSingleResultFromDocument(domain.Comment{ID: commentID, TaskID: taskID, Author: "user-1"}, nil, nil)
+		collectionHelper.On("FindOne", mock.Anything, bson.M{"_id": commentID, "taskID": taskID}).Return(result).Once()
+
+		comment, err := repo.FetchByID(context.TODO(), taskID, commentID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "user-1", comment.Author)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		databaseHelper := &mocks.Database{}
+		collectionHelper := &mocks.Collection{}
+		databaseHelper.On("Collection", domain.CollectionComment).Return(collectionHelper)
+		repo := repository.NewCommentRepositoryWithClock(databaseHelper, domain.CollectionComment, fixedClock(auditTime))
+
+		_, err := repo.FetchByID(context.TODO(), taskID, "invalid")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidID)
+	})
+}
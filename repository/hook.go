@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Hook runs before a Mongo repository writes, doc is the pointer passed to Insert.
+type Hook interface {
+	BeforeInsert(c context.Context, doc any)
+	BeforeUpdate(c context.Context, update bson.M)
+}
+
+// Auditable documents have their created and updated fields set on insert.
+type Auditable interface {
+	SetCreated(at time.Time, by string)
+}
+
+// AuditHook maintains createdAt/updatedAt and createdBy/updatedBy, the actor is read from the context.
+type AuditHook struct {
+	Clock domain.Clock
+}
+
+func (h AuditHook) BeforeInsert(c context.Context, doc any) {
+	if d, ok := doc.(Auditable); ok {
+		d.SetCreated(h.Clock.Now(), domain.ActorFromContext(c))
+	}
+}
+
+func (h AuditHook) BeforeUpdate(c context.Context, update bson.M) {
+	set, ok := update["$set"].(bson.M)
+	if !ok {
+		set = bson.M{}
+		update["$set"] = set
+	}
+
+	set["updatedAt"] = h.Clock.Now()
+	if actor := domain.ActorFromContext(c); actor != "" {
+		set["updatedBy"] = actor
+	}
+}
@@ -0,0 +1,72 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+var auditTime = time.Date(2025, 2, 1, 10, 0, 0, 0, time.UTC)
+
+func TestAuditHookInsert(t *testing.T) {
+	repo, collectionHelper := newMongoRepository()
+	repo.Use(repository.AuditHook{Clock: fixedClock(auditTime)})
+
+	collectionHelper.On("InsertOne", mock.Anything, mock.MatchedBy(func(task *domain.Task) bool {
+		return task.CreatedAt.Equal(auditTime) && task.UpdatedAt.Equal(auditTime) &&
+			task.CreatedBy == "user-1" && task.UpdatedBy == "user-1"
+	})).Return(nil, nil).Once()
+
+	task := &domain.Task{Title: title}
+	err := repo.Insert(domain.WithActor(context.TODO(), "user-1"), task)
+
+	assert.NoError(t, err)
+	assert.Equal(t, auditTime, task.CreatedAt)
+	collectionHelper.AssertExpectations(t)
+}
+
+func TestAuditHookUpdate(t *testing.T) {
+	id := primitive.NewObjectID()
+
+	t.Run("with actor", func(t *testing.T) {
+		repo, collectionHelper := newMongoRepository()
+		repo.Use(repository.AuditHook{Clock: fixedClock(auditTime)})
+
+		expected := bson.M{"$set": bson.M{"title": title, "updatedAt": auditTime, "updatedBy": "user-2"}}
+		collectionHelper.On("UpdateMany", mock.Anything, bson.M{"_id": id}, expected).
+			Return(&mongo.UpdateResult{MatchedCount: 1}, nil).Once()
+
+		err := repo.Update(domain.WithActor(context.TODO(), "user-2"), id.Hex(), bson.M{"$set": bson.M{"title": title}})
+
+		assert.NoError(t, err)
+		collectionHelper.AssertExpectations(t)
+	})
+
+	t.Run("without $set", func(t *testing.T) {
+		repo, collectionHelper := newMongoRepository()
+		repo.Use(repository.AuditHook{Clock: fixedClock(auditTime)})
+
+		expected := bson.M{"$unset": bson.M{"title": ""}, "$set": bson.M{"updatedAt": auditTime}}
+		collectionHelper.On("UpdateMany", mock.Anything, bson.M{"_id": id}, expected).
+			Return(&mongo.UpdateResult{MatchedCount: 1}, nil).Once()
+
+		err := repo.Update(context.TODO(), id.Hex(), bson.M{"$unset": bson.M{"title": ""}})
+
+		assert.NoError(t, err)
+		collectionHelper.AssertExpectations(t)
+	})
+}
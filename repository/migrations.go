@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Migrations returns the data migrations of the task collection, append new ones with increasing versions.
+func Migrations(collection string) []mongo.Migration {
+	return []mongo.Migration{
+		{
+			Version:     1,
+			Description: "set the initial version of tasks created before optimistic concurrency",
+			Up: func(ctx context.Context, db mongo.Database) error {
+				_, err := db.Collection(collection).UpdateMany(ctx,
+					bson.M{"version": bson.M{"$exists": false}},
+					bson.M{"$set": bson.M{"version": 1}},
+				)
+				return err
+			},
+			Down: func(ctx context.Context, db mongo.Database) error {
+				// The version field is left in place, older code ignores it
+				return nil
+			},
+		},
+		{
+			Version:     2,
+			Description: "set the status of tasks created before the status workflow to todo",
+			Up: func(ctx context.Context, db mongo.Database) error {
+				_, err := db.Collection(collection).UpdateMany(ctx,
+					bson.M{"status": bson.M{"$exists": false}},
+					bson.M{"$set": bson.M{"status": domain.StatusTodo}},
+				)
+				return err
+			},
+			Down: func(ctx context.Context, db mongo.Database) error {
+				// Statuses are kept, an empty status reads as todo anyway
+				return nil
+			},
+		},
+	}
+}
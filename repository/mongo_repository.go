@@ -0,0 +1,321 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Identifiable documents get a new ObjectID on Insert when their ID is zero.
+type Identifiable interface {
+	GetID() primitive.ObjectID
+	SetID(id primitive.ObjectID)
+}
+
+type FindOptions struct {
+	Sort       bson.D
+	Projection any
+	// Page is 1-based, it is ignored unless Size is set.
+	Page int64
+	Size int64
+}
+
+// Mongo is a typed repository over one collection. Lookups by id take the hex string form,
+// invalid ids map to domain.ErrInvalidID and missing documents to domain.ErrNotFound.
+type Mongo[T any] struct {
+	database   mongo.Database
+	collection string
+	hooks      []Hook
+}
+
+func NewMongo[T any](db mongo.Database, collection string) *Mongo[T] {
+	return &Mongo[T]{
+		database:   db,
+		collection: collection,
+	}
+}
+
+// Use registers hooks run before every insert and update.
+func (r *Mongo[T]) Use(hooks ...Hook) *Mongo[T] {
+	r.hooks = append(r.hooks, hooks...)
+	return r
+}
+
+func ObjectID(id string) (primitive.ObjectID, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return oid, fmt.Errorf("%w: %s", domain.ErrInvalidID, id)
+	}
+	return oid, nil
+}
+
+func (r *Mongo[T]) Collection() mongo.Collection {
+	return r.database.Collection(r.collection)
+}
+
+func (r *Mongo[T]) FindByID(c context.Context, id string) (T, error) {
+	oid, err := ObjectID(id)
+	if err != nil {
+		var doc T
+		return doc, err
+	}
+	return r.FindOne(c, bson.M{"_id": oid})
+}
+
+func (r *Mongo[T]) FindOne(c context.Context, filter any) (T, error) {
+	var doc T
+	if err := r.Collection().FindOne(c, filter).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return doc, domain.ErrNotFound
+		}
+		return doc, err
+	}
+	return doc, nil
+}
+
+func (r *Mongo[T]) FindMany(c context.Context, filter any, opts ...FindOptions) ([]T, error) {
+	docs := []T{}
+
+	var findOptions []*options.FindOptions
+	if len(opts) > 0 {
+		findOptions = append(findOptions, opts[0].toFindOptions())
+	}
+
+	cursor, err := r.Collection().Find(c, filter, findOptions...)
+	if err != nil {
+		return docs, err
+	}
+
+	defer cursor.Close(c)
+
+	if err := cursor.All(c, &docs); err != nil {
+		return nil, err
+	}
+
+	return docs, nil
+}
+
+// Each decodes the documents matching filter one at a time and calls fn with each, it stops at the first
+// error of fn. Unlike FindMany it never holds more than one document.
+func (r *Mongo[T]) Each(c context.Context, filter any, opts FindOptions, fn func(T) error) error {
+	cursor, err := r.Collection().Find(c, filter, opts.toFindOptions())
+	if err != nil {
+		return err
+	}
+
+	defer cursor.Close(c)
+
+	for cursor.Next(c) {
+		var doc T
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+		if err := fn(doc); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func (r *Mongo[T]) Insert(c context.Context, doc *T) error {
+	r.beforeInsert(c, doc)
+
+	_, err := r.Collection().InsertOne(c, doc)
+	return err
+}
+
+// InsertMany inserts docs in one write, preparing each like Insert does.
+func (r *Mongo[T]) InsertMany(c context.Context, docs []T) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	documents := make([]any, len(docs))
+	for i := range docs {
+		r.beforeInsert(c, &docs[i])
+		documents[i] = &docs[i]
+	}
+
+	_, err := r.Collection().InsertMany(c, documents)
+	return err
+}
+
+func (r *Mongo[T]) beforeInsert(c context.Context, doc *T) {
+	if d, ok := any(doc).(Identifiable); ok && d.GetID().IsZero() {
+		d.SetID(primitive.NewObjectID())
+	}
+
+	for _, hook := range r.hooks {
+		hook.BeforeInsert(c, doc)
+	}
+}
+
+func (r *Mongo[T]) beforeUpdate(c context.Context, update bson.M) {
+	for _, hook := range r.hooks {
+		hook.BeforeUpdate(c, update)
+	}
+}
+
+// Update applies the update document to the document with the given id.
+func (r *Mongo[T]) Update(c context.Context, id string, update bson.M) error {
+	oid, err := ObjectID(id)
+	if err != nil {
+		return err
+	}
+	return r.UpdateOne(c, bson.M{"_id": oid}, update)
+}
+
+func (r *Mongo[T]) UpdateOne(c context.Context, filter any, update bson.M) error {
+	r.beforeUpdate(c, update)
+
+	result, err := r.Collection().UpdateOne(c, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// UpdateMany applies update to every document matching filter and returns how many were modified.
+func (r *Mongo[T]) UpdateMany(c context.Context, filter any, update bson.M) (int64, error) {
+	r.beforeUpdate(c, update)
+
+	result, err := r.Collection().UpdateMany(c, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// UpdateVersion applies update to the document matching filter at the given version and increments
+// the version, returning the updated document. A version mismatch yields domain.ErrConflict.
+func (r *Mongo[T]) UpdateVersion(c context.Context, filter bson.M, version int64, update bson.M) (T, error) {
+	r.beforeUpdate(c, update)
+
+	var doc T
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := r.Collection().FindOneAndUpdate(c, versioned(filter, version, update), update, opts).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		exists, err := r.Exists(c, filter)
+		if err != nil {
+			return doc, err
+		}
+		if exists {
+			return doc, domain.ErrConflict
+		}
+		return doc, domain.ErrNotFound
+	}
+	return doc, err
+}
+
+// versioned restricts filter to the given version and adds the version increment to update.
+func versioned(filter bson.M, version int64, update bson.M) bson.M {
+	inc, ok := update["$inc"].(bson.M)
+	if !ok {
+		inc = bson.M{}
+		update["$inc"] = inc
+	}
+	inc["version"] = 1
+
+	match := bson.M{"version": version}
+	for k, v := range filter {
+		match[k] = v
+	}
+	return match
+}
+
+// InsertModel prepares doc like Insert does and returns it as a BulkWrite model.
+func (r *Mongo[T]) InsertModel(c context.Context, doc *T) *driver.InsertOneModel {
+	r.beforeInsert(c, doc)
+	return driver.NewInsertOneModel().SetDocument(doc)
+}
+
+// UpdateModel is the BulkWrite model of UpdateOne.
+func (r *Mongo[T]) UpdateModel(c context.Context, filter any, update bson.M) *driver.UpdateOneModel {
+	r.beforeUpdate(c, update)
+	return driver.NewUpdateOneModel().SetFilter(filter).SetUpdate(update)
+}
+
+// UpdateVersionModel is the BulkWrite model of UpdateVersion, a stale version leaves the model unmatched.
+func (r *Mongo[T]) UpdateVersionModel(c context.Context, filter bson.M, version int64, update bson.M) *driver.UpdateOneModel {
+	r.beforeUpdate(c, update)
+	return driver.NewUpdateOneModel().SetFilter(versioned(filter, version, update)).SetUpdate(update)
+}
+
+// BulkWrite sends models in one batch. An ordered batch stops at the first write error, an unordered
+// one attempts every model. Failed models are listed by index in a driver.BulkWriteException.
+func (r *Mongo[T]) BulkWrite(c context.Context, models []driver.WriteModel, ordered bool) (*driver.BulkWriteResult, error) {
+	return r.Collection().BulkWrite(c, models, options.BulkWrite().SetOrdered(ordered))
+}
+
+func (r *Mongo[T]) Delete(c context.Context, id string) error {
+	oid, err := ObjectID(id)
+	if err != nil {
+		return err
+	}
+
+	count, err := r.Collection().DeleteOne(c, bson.M{"_id": oid})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// Aggregate runs pipeline and decodes every result into results, a pointer to a slice.
+func (r *Mongo[T]) Aggregate(c context.Context, pipeline any, results any) error {
+	cursor, err := r.Collection().Aggregate(c, pipeline)
+	if err != nil {
+		return err
+	}
+
+	defer cursor.Close(c)
+
+	return cursor.All(c, results)
+}
+
+func (r *Mongo[T]) DeleteMany(c context.Context, filter any) (int64, error) {
+	return r.Collection().DeleteMany(c, filter)
+}
+
+func (r *Mongo[T]) Count(c context.Context, filter any) (int64, error) {
+	return r.Collection().CountDocuments(c, filter)
+}
+
+func (r *Mongo[T]) Exists(c context.Context, filter any) (bool, error) {
+	count, err := r.Collection().CountDocuments(c, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (o FindOptions) toFindOptions() *options.FindOptions {
+	opts := options.Find()
+	if len(o.Sort) > 0 {
+		opts.SetSort(o.Sort)
+	}
+	if o.Projection != nil {
+		opts.SetProjection(o.Projection)
+	}
+	if o.Size > 0 {
+		page := o.Page
+		if page < 1 {
+			page = 1
+		}
+		opts.SetSkip((page - 1) * o.Size)
+		opts.SetLimit(o.Size)
+	}
+	return opts
+}
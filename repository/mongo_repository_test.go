@@ -0,0 +1,186 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo/mocks"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func newMongoRepository() (*repository.Mongo[domain.Task], *mocks.Collection) {
+	databaseHelper := &mocks.Database{}
+	collectionHelper := &mocks.Collection{}
+	databaseHelper.On("Collection", domain.CollectionTask).Return(collectionHelper)
+	return repository.NewMongo[domain.Task](databaseHelper, domain.CollectionTask), collectionHelper
+}
+
+func TestMongoRepositoryFindByID(t *testing.T) {
+	id := primitive.NewObjectID()
+
+	t.Run("success", func(t *testing.T) {
+		repo, collectionHelper := newMongoRepository()
+		result := mongo.NewSingleResultFromDocument(domain.Task{ID: id, Title: title}, nil, nil)
+		collectionHelper.On("FindOne", mock.Anything, bson.M{"_id": id}).Return(result).Once()
+
+		task, err := repo.FindByID(context.TODO(), id.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, title, task.Title)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repo, collectionHelper := newMongoRepository()
+		result := &mocks.SingleResult{}
+		result.On("Decode", mock.Anything).Return(mongo.ErrNoDocuments).Once()
+		collectionHelper.On("FindOne", mock.Anything, bson.M{"_id": id}).Return(result).Once()
+
+		_, err := repo.FindByID(context.TODO(), id.Hex())
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		repo, _ := newMongoRepository()
+
+		_, err := repo.FindByID(context.TODO(), "invalid")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidID)
+	})
+}
+
+func TestMongoRepositoryFindMany(t *testing.T) {
+	repo, collectionHelper := newMongoRepository()
+	cursor, err := mongo.NewCursorFromDocuments([]any{domain.Task{Title: title}}, nil, nil)
+	assert.NoError(t, err)
+
+	collectionHelper.On("Find", mock.Anything, bson.M{}, mock.MatchedBy(func(opts *options.FindOptions) bool {
+		return *opts.Skip == 20 && *opts.Limit == 10 && opts.Sort != nil
+	})).Return(cursor, nil).Once()
+
+	tasks, err := repo.FindMany(context.TODO(), bson.M{}, repository.FindOptions{
+		Sort: bson.D{{Key: "title", Value: 1}},
+		Page: 3,
+		Size: 10,
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	collectionHelper.AssertExpectations(t)
+}
+
+func TestMongoRepositoryInsert(t *testing.T) {
+	repo, collectionHelper := newMongoRepository()
+	collectionHelper.On("InsertOne", mock.Anything, mock.AnythingOfType("*domain.Task")).Return(primitive.NewObjectID(), nil).Once()
+
+	task := &domain.Task{Title: title}
+	err := repo.Insert(context.TODO(), task)
+
+	assert.NoError(t, err)
+	assert.False(t, task.ID.IsZero())
+}
+
+func TestMongoRepositoryUpdate(t *testing.T) {
+	id := primitive.NewObjectID()
+	update := bson.M{"$set": bson.M{"title": title}}
+
+	t.Run("success", func(t *testing.T) {
+		repo, collectionHelper := newMongoRepository()
+		// mocks.Collection.UpdateOne delegates to UpdateMany
+		collectionHelper.On("UpdateMany", mock.Anything, bson.M{"_id": id}, update).Return(&mongo.UpdateResult{MatchedCount: 1}, nil).Once()
+
+		err := repo.Update(context.TODO(), id.Hex(), update)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repo, collectionHelper := newMongoRepository()
+		collectionHelper.On("UpdateMany", mock.Anything, bson.M{"_id": id}, update).Return(&mongo.UpdateResult{}, nil).Once()
+
+		err := repo.Update(context.TODO(), id.Hex(), update)
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+func TestMongoRepositoryDelete(t *testing.T) {
+	id := primitive.NewObjectID()
+
+	t.Run("success", func(t *testing.T) {
+		repo, collectionHelper := newMongoRepository()
+		collectionHelper.On("DeleteOne", mock.Anything, bson.M{"_id": id}).Return(int64(1), nil).Once()
+
+		assert.NoError(t, repo.Delete(context.TODO(), id.Hex()))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repo, collectionHelper := newMongoRepository()
+		collectionHelper.On("DeleteOne", mock.Anything, bson.M{"_id": id}).Return(int64(0), nil).Once()
+
+		assert.ErrorIs(t, repo.Delete(context.TODO(), id.Hex()), domain.ErrNotFound)
+	})
+}
+
+func TestMongoRepositoryCountExists(t *testing.T) {
+	repo, collectionHelper := newMongoRepository()
+	filter := bson.M{"title": title}
+	collectionHelper.On("CountDocuments", mock.Anything, filter).Return(int64(2), nil).Once()
+	collectionHelper.On("CountDocuments", mock.Anything, filter, mock.AnythingOfType("*options.CountOptions")).Return(int64(1), nil).Once()
+
+	count, err := repo.Count(context.TODO(), filter)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	exists, err := repo.Exists(context.TODO(), filter)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestMongoRepositoryUpdateVersion(t *testing.T) {
+	id := primitive.NewObjectID()
+	versioned := bson.M{"_id": id, "version": int64(2)}
+
+	t.Run("success", func(t *testing.T) {
+		repo, collectionHelper := newMongoRepository()
+		result := mongo.NewSingleResultFromDocument(domain.Task{ID: id, Title: title, Version: 3}, nil, nil)
+		collectionHelper.On("FindOneAndUpdate", mock.Anything, versioned,
+			bson.M{"$set": bson.M{"title": title}, "$inc": bson.M{"version": 1}}, mock.Anything).Return(result).Once()
+
+		task, err := repo.UpdateVersion(context.TODO(), bson.M{"_id": id}, 2, bson.M{"$set": bson.M{"title": title}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), task.Version)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		repo, collectionHelper := newMongoRepository()
+		result := &mocks.SingleResult{}
+		result.On("Decode", mock.Anything).Return(mongo.ErrNoDocuments).Once()
+		collectionHelper.On("FindOneAndUpdate", mock.Anything, versioned, mock.Anything, mock.Anything).Return(result).Once()
+		collectionHelper.On("CountDocuments", mock.Anything, bson.M{"_id": id}, mock.Anything).Return(int64(1), nil).Once()
+
+		_, err := repo.UpdateVersion(context.TODO(), bson.M{"_id": id}, 2, bson.M{"$set": bson.M{"title": title}})
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repo, collectionHelper := newMongoRepository()
+		result := &mocks.SingleResult{}
+		result.On("Decode", mock.Anything).Return(mongo.ErrNoDocuments).Once()
+		collectionHelper.On("FindOneAndUpdate", mock.Anything, versioned, mock.Anything, mock.Anything).Return(result).Once()
+		collectionHelper.On("CountDocuments", mock.Anything, bson.M{"_id": id}, mock.Anything).Return(int64(0), nil).Once()
+
+		_, err := repo.UpdateVersion(context.TODO(), bson.M{"_id": id}, 2, bson.M{"$set": bson.M{"title": title}})
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type projectRepository struct {
+	projects *Mongo[domain.Project]
+	clock    domain.Clock
+}
+
+func ProjectIndexes() []mongo.Index {
+	return []mongo.Index{
+		{Name: "workspaceID_1_name_1", Keys: bson.D{{Key: "workspaceID", Value: 1}, {Key: "name", Value: 1}}},
+	}
+}
+
+func NewProjectRepository(db mongo.Database, collection string) domain.ProjectRepository {
+	return NewProjectRepositoryWithClock(db, collection, domain.SystemClock{})
+}
+
+func NewProjectRepositoryWithClock(db mongo.Database, collection string, clock domain.Clock) domain.ProjectRepository {
+	return &projectRepository{
+		projects: NewMongo[domain.Project](db, collection).Use(AuditHook{Clock: clock}),
+		clock:    clock,
+	}
+}
+
+func (r *projectRepository) Create(c context.Context, project *domain.Project) error {
+	project.ID = primitive.NewObjectID()
+	project.Version = 1
+	project.ArchivedAt = nil
+	return r.projects.Insert(c, project)
+}
+
+func (r *projectRepository) FetchByID(c context.Context, projectID string) (domain.Project, error) {
+	return r.projects.FindByID(c, projectID)
+}
+
+func (r *projectRepository) FetchByWorkspaceID(c context.Context, workspaceID primitive.ObjectID, archived bool) ([]domain.Project, error) {
+	filter := bson.M{"workspaceID": workspaceID}
+	if !archived {
+		filter["archivedAt"] = nil
+	}
+
+	return r.projects.FindMany(c, filter, FindOptions{
+		Sort: bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}},
+	})
+}
+
+func (r *projectRepository) FetchByWorkspaceIDs(c context.Context, workspaceIDs []primitive.ObjectID) ([]domain.Project, error) {
+	return r.projects.FindMany(c, bson.M{"workspaceID": bson.M{"$in": workspaceIDs}})
+}
+
+func (r *projectRepository) Update(c context.Context, project *domain.Project) error {
+	update := bson.M{"$set": bson.M{"name": project.Name}}
+	if project.Description != "" {
+		update["$set"].(bson.M)["description"] = project.Description
+	} else {
+		update["$unset"] = bson.M{"description": ""}
+	}
+
+	return r.update(c, project, update)
+}
+
+func (r *projectRepository) SetArchived(c context.Context, project *domain.Project, archived bool) error {
+	update := bson.M{"$unset": bson.M{"archivedAt": ""}}
+	if archived {
+		update = bson.M{"$set": bson.M{"archivedAt": r.clock.Now()}}
+	}
+
+	return r.update(c, project, update)
+}
+
+func (r *projectRepository) update(c context.Context, project *domain.Project, update bson.M) error {
+	updated, err := r.projects.UpdateVersion(c, bson.M{"_id": project.ID}, project.Version, update)
+	if err != nil {
+		return err
+	}
+
+	*project = updated
+	return nil
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MockProjectRepository struct {
+	mock.Mock
+}
+
+func (_m *MockProjectRepository) Create(c context.Context, project *domain.Project) error {
+	ret := _m.Called(c, project)
+	return ret.Error(0)
+}
+
+func (_m *MockProjectRepository) FetchByID(c context.Context, projectID string) (domain.Project, error) {
+	ret := _m.Called(c, projectID)
+	return ret.Get(0).(domain.Project), ret.Error(1)
+}
+
+func (_m *MockProjectRepository) FetchByWorkspaceID(c context.Context, workspaceID primitive.ObjectID, archived bool) ([]domain.Project, error) {
+	ret := _m.Called(c, workspaceID, archived)
+
+	var r0 []domain.Project
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]domain.Project)
+	}
+
+	return r0, ret.Error(1)
+}
+
+func (_m *MockProjectRepository) FetchByWorkspaceIDs(c context.Context, workspaceIDs []primitive.ObjectID) ([]domain.Project, error) {
+	ret := _m.Called(c, workspaceIDs)
+
+	var r0 []domain.Project
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]domain.Project)
+	}
+
+	return r0, ret.Error(1)
+}
+
+func (_m *MockProjectRepository) Update(c context.Context, project *domain.Project) error {
+	ret := _m.Called(c, project)
+	return ret.Error(0)
+}
+
+func (_m *MockProjectRepository) SetArchived(c context.Context, project *domain.Project, archived bool) error {
+	ret := _m.Called(c, project, archived)
+	return ret.Error(0)
+}
@@ -0,0 +1,99 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo/mocks"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestProjectRepositoryFetchByWorkspaceID(t *testing.T) {
+	workspaceID := primitive.NewObjectID()
+
+	tests := []struct {
+		name     string
+		archived bool
+		filter   bson.M
+	}{
+		{name: "live", filter: bson.M{"workspaceID": workspaceID, "archivedAt": nil}},
+		{name: "archived", archived: true, filter: bson.M{"workspaceID": workspaceID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			databaseHelper := &mocks.Database{}
+			collectionHelper := &mocks.Collection{}
+			databaseHelper.On("Collection", domain.CollectionProject).Return(collectionHelper)
+			repo := repository.NewProjectRepositoryWithClock(databaseHelper, domain.CollectionProject, fixedClock(auditTime))
+			cursor, err := mongo.NewCursorFromDocuments([]any{domain.Project{Name: "Launch"}}, nil, nil)
+			assert.NoError(t, err)
+			collectionHelper.On("Find", mock.Anything, tt.filter, mock.Anything).Return(cursor, nil).Once()
+
+			projects, err := repo.FetchByWorkspaceID(context.TODO(), workspaceID, tt.archived)
+
+			assert.NoError(t, err)
+			assert.Len(t, projects, 1)
+			collectionHelper.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProjectRepositoryFetchByWorkspaceIDs(t *testing.T) {
+	workspaceIDs := []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID()}
+
+	databaseHelper := &mocks.Database{}
+	collectionHelper := &mocks.Collection{}
+	databaseHelper.On("Collection", domain.CollectionProject).Return(collectionHelper)
+	repo := repository.NewProjectRepositoryWithClock(databaseHelper, domain.CollectionProject, fixedClock(auditTime))
+	cursor, err := mongo.NewCursorFromDocuments([]any{domain.Project{Name: "Launch"}, domain.Project{Name: "Old"}}, nil, nil)
+	assert.NoError(t, err)
+	collectionHelper.On("Find", mock.Anything, bson.M{"workspaceID": bson.M{"$in": workspaceIDs}}, mock.Anything).Return(cursor, nil).Once()
+
+	projects, err := repo.FetchByWorkspaceIDs(context.TODO(), workspaceIDs)
+
+	assert.NoError(t, err)
+	assert.Len(t, projects, 2)
+	collectionHelper.AssertExpectations(t)
+}
+
+func TestProjectRepositorySetArchived(t *testing.T) {
+	id := primitive.NewObjectID()
+
+	tests := []struct {
+		name     string
+		archived bool
+		update   func(bson.M) bool
+	}{
+		{name: "archive", archived: true, update: func(update bson.M) bool {
+			return update["$set"].(bson.M)["archivedAt"] == auditTime
+		}},
+		{name: "unarchive", update: func(update bson.M) bool {
+			_, ok := update["$unset"].(bson.M)["archivedAt"]
+			return ok
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			databaseHelper := &mocks.Database{}
+			collectionHelper := &mocks.Collection{}
+			databaseHelper.On("Collection", domain.CollectionProject).Return(collectionHelper)
+			repo := repository.NewProjectRepositoryWithClock(databaseHelper, domain.CollectionProject, fixedClock(auditTime))
+			result := mongo.NewSingleResultFromDocument(domain.Project{ID: id, Version: 2}, nil, nil)
+			collectionHelper.On("FindOneAndUpdate", mock.Anything, bson.M{"_id": id, "version": int64(1)}, mock.MatchedBy(tt.update), mock.Anything).
+				Return(result).Once()
+
+			project := &domain.Project{ID: id, Version: 1}
+			assert.NoError(t, repo.SetArchived(context.TODO(), project, tt.archived))
+			assert.Equal(t, int64(2), project.Version)
+			collectionHelper.AssertExpectations(t)
+		})
+	}
+}
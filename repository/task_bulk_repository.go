@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+)
+
+func NewTaskBulkRepository(db mongo.Database, collection string) domain.TaskBulkRepository {
+	return newTaskRepository(db, collection, domain.SystemClock{})
+}
+
+func NewTaskTransferRepository(db mongo.Database, collection string) domain.TaskTransferRepository {
+	return newTaskRepository(db, collection, domain.SystemClock{})
+}
+
+// BulkWrite reads the version of every task updated or deleted by the batch first, a bulk write only
+// counts the documents it matched while the read tells which operation misses and why. Operations on
+// the same task see the effect of the earlier ones, an update followed by a delete is valid.
+func (r *taskRepository) BulkWrite(c context.Context, operations []domain.BulkOperation, opts domain.BulkOptions) ([]domain.BulkResult, error) {
+	results := make([]domain.BulkResult, len(operations))
+	ids := []primitive.ObjectID{}
+	for i, op := range operations {
+		results[i] = domain.BulkResult{Index: i, Action: op.Action}
+		if op.Action == domain.BulkCreate {
+			continue
+		}
+
+		id, err := ObjectID(op.ID)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].ID = id
+		ids = append(ids, id)
+	}
+
+	versions, err := r.versions(c, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	models := []driver.WriteModel{}
+	// indexes maps each model to the index of its operation
+	indexes := []int{}
+	stopped := false
+	for i, op := range operations {
+		result := &results[i]
+		if stopped {
+			result.Err = domain.ErrSkipped
+			continue
+		}
+		if result.Err == nil {
+			if model := r.bulkModel(c, op, result, versions); model != nil {
+				models = append(models, model)
+				indexes = append(indexes, i)
+			}
+		}
+		stopped = result.Err != nil && opts.Ordered
+	}
+
+	if len(models) == 0 {
+		return results, nil
+	}
+
+	written, err := r.tasks.BulkWrite(c, models, opts.Ordered)
+	var bulkErr driver.BulkWriteException
+	if errors.As(err, &bulkErr) && bulkErr.WriteConcernError == nil {
+		for _, writeErr := range bulkErr.WriteErrors {
+			i := indexes[writeErr.Index]
+			results[i].Err = bulkWriteError(writeErr)
+			if opts.Ordered {
+				for j := i + 1; j < len(results); j++ {
+					results[j].Err = domain.ErrSkipped
+				}
+			}
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	if written != nil && written.MatchedCount < matched(results, indexes) {
+		return results, r.checkUpdated(c, results, versions)
+	}
+	return results, nil
+}
+
+// Import writes tasks in one unordered batch, an id already in use reports domain.ErrDuplicate.
+func (r *taskRepository) Import(c context.Context, tasks []domain.Task) ([]error, error) {
+	errs := make([]error, len(tasks))
+	if len(tasks) == 0 {
+		return errs, nil
+	}
+
+	models := make([]driver.WriteModel, len(tasks))
+	for i := range tasks {
+		if tasks[i].ID.IsZero() {
+			tasks[i].ID = primitive.NewObjectID()
+		}
+		initTask(&tasks[i])
+		models[i] = r.tasks.InsertModel(c, &tasks[i])
+	}
+
+	_, err := r.tasks.BulkWrite(c, models, false)
+	var bulkErr driver.BulkWriteException
+	if errors.As(err, &bulkErr) && bulkErr.WriteConcernError == nil {
+		for _, writeErr := range bulkErr.WriteErrors {
+			errs[writeErr.Index] = bulkWriteError(writeErr)
+		}
+		return errs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return errs, nil
+}
+
+// bulkModel returns the write of op, or records in result why op cannot be applied and returns nil.
+func (r *taskRepository) bulkModel(c context.Context, op domain.BulkOperation, result *domain.BulkResult, versions map[primitive.ObjectID]int64) driver.WriteModel {
+	if op.Action == domain.BulkCreate {
+		newTask(op.Task)
+		result.ID, result.Version = op.Task.ID, op.Task.Version
+		return r.tasks.InsertModel(c, op.Task)
+	}
+
+	version, ok := versions[result.ID]
+	if !ok {
+		result.Err = domain.ErrNotFound
+		return nil
+	}
+
+	filter := live(bson.M{"_id": result.ID})
+	switch op.Action {
+	case domain.BulkUpdate:
+		if op.Task.Version != version {
+			result.Err = domain.ErrConflict
+			return nil
+		}
+		op.Task.ID = result.ID
+		versions[result.ID] = version + 1
+		result.Version = version + 1
+		return r.tasks.UpdateVersionModel(c, filter, version, taskUpdate(op.Task))
+	default:
+		delete(versions, result.ID)
+		result.Version = version
+		return r.tasks.UpdateModel(c, filter, r.softDelete())
+	}
+}
+
+// versions reads the current version of the live tasks among ids.
+func (r *taskRepository) versions(c context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]int64, error) {
+	versions := map[primitive.ObjectID]int64{}
+	if len(ids) == 0 {
+		return versions, nil
+	}
+
+	tasks, err := r.tasks.FindMany(c, live(bson.M{"_id": bson.M{"$in": ids}}), FindOptions{
+		Projection: bson.M{"version": 1},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		versions[task.ID] = task.Version
+	}
+	return versions, nil
+}
+
+// matched counts the updates and deletes expected to have matched a task.
+func matched(results []domain.BulkResult, indexes []int) int64 {
+	var count int64
+	for _, i := range indexes {
+		if results[i].Action != domain.BulkCreate && results[i].Err == nil {
+			count++
+		}
+	}
+	return count
+}
+
+// checkUpdated runs when a write raced with the batch and left an update or delete unmatched, expected
+// holds the versions the batch should have left. The updates of a task that is not at that version
+// report domain.ErrConflict, a partial chain of updates to one task cannot be told apart from a foreign
+// write. An unmatched delete found the task already deleted as it asked for.
+func (r *taskRepository) checkUpdated(c context.Context, results []domain.BulkResult, expected map[primitive.ObjectID]int64) error {
+	ids := []primitive.ObjectID{}
+	for _, result := range results {
+		if result.Action == domain.BulkUpdate && result.Err == nil {
+			ids = append(ids, result.ID)
+		}
+	}
+
+	versions, err := r.versions(c, ids)
+	if err != nil {
+		return err
+	}
+	for i, result := range results {
+		if result.Action != domain.BulkUpdate || result.Err != nil {
+			continue
+		}
+		want, ok := expected[result.ID]
+		if !ok {
+			// deleted later in the batch
+			continue
+		}
+		if version, ok := versions[result.ID]; !ok {
+			results[i].Err = domain.ErrNotFound
+		} else if version != want {
+			results[i].Err = domain.ErrConflict
+		}
+	}
+	return nil
+}
+
+func bulkWriteError(err driver.BulkWriteError) error {
+	if driver.IsDuplicateKeyError(err.WriteError) {
+		return domain.ErrDuplicate
+	}
+	return err.WriteError
+}
@@ -0,0 +1,182 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo/mocks"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestTaskRepositoryBulkWrite(t *testing.T) {
+	updated, deleted := primitive.NewObjectID(), primitive.NewObjectID()
+
+	newRepository := func(t *testing.T, versions ...bson.M) (domain.TaskBulkRepository, *mocks.Collection) {
+		databaseHelper := &mocks.Database{}
+		collectionHelper := &mocks.Collection{}
+		databaseHelper.On("Collection", domain.CollectionTask).Return(collectionHelper)
+
+		documents := []any{}
+		for _, version := range versions {
+			documents = append(documents, version)
+		}
+		cursor, err := mongo.NewCursorFromDocuments(documents, nil, nil)
+		assert.NoError(t, err)
+		collectionHelper.On("Find", mock.Anything, mock.MatchedBy(func(filter bson.M) bool {
+			return filter["deletedAt"] == nil && filter["_id"] != nil
+		}), mock.Anything).Return(cursor, nil).Once()
+
+		return repository.NewTaskBulkRepository(databaseHelper, domain.CollectionTask), collectionHelper
+	}
+
+	ordered := func(ordered bool) any {
+		return mock.MatchedBy(func(opts *options.BulkWriteOptions) bool {
+			return *opts.Ordered == ordered
+		})
+	}
+
+	t.Run("mixed batch", func(t *testing.T) {
+		repo, collectionHelper := newRepository(t,
+			bson.M{"_id": updated, "version": 3},
+			bson.M{"_id": deleted, "version": 1},
+		)
+		collectionHelper.On("BulkWrite", mock.Anything, mock.MatchedBy(func(models []mongo.WriteModel) bool {
+			if len(models) != 3 {
+				return false
+			}
+			insert := models[0].(*mongo.InsertOneModel).Document.(*domain.Task)
+			update := models[1].(*mongo.UpdateOneModel)
+			remove := models[2].(*mongo.UpdateOneModel)
+			return insert.Title == "New" && !insert.CreatedAt.IsZero() &&
+				assert.ObjectsAreEqual(bson.M{"_id": updated, "version": int64(3), "deletedAt": nil}, update.Filter) &&
+				assert.ObjectsAreEqual(bson.M{"version": 1}, update.Update.(bson.M)["$inc"]) &&
+				remove.Update.(bson.M)["$set"].(bson.M)["deletedAt"] != nil
+		}), ordered(true)).Return(&mongo.BulkWriteResult{InsertedCount: 1, MatchedCount: 2, ModifiedCount: 2}, nil).Once()
+
+		results, err := repo.BulkWrite(context.TODO(), []domain.BulkOperation{
+			{Action: domain.BulkCreate, Task: &domain.Task{Title: "New"}},
+			{Action: domain.BulkUpdate, ID: updated.Hex(), Task: &domain.Task{Title: "Renamed", Version: 3}},
+			{Action: domain.BulkDelete, ID: deleted.Hex()},
+		}, domain.BulkOptions{Ordered: true})
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 3)
+		for _, result := range results {
+			assert.NoError(t, result.Err)
+		}
+		assert.False(t, results[0].ID.IsZero())
+		assert.Equal(t, int64(1), results[0].Version)
+		assert.Equal(t, domain.BulkResult{Index: 1, Action: domain.BulkUpdate, ID: updated, Version: 4}, results[1])
+		assert.Equal(t, deleted, results[2].ID)
+		collectionHelper.AssertExpectations(t)
+	})
+
+	t.Run("unordered reports each failure", func(t *testing.T) {
+		repo, collectionHelper := newRepository(t, bson.M{"_id": updated, "version": 3})
+		collectionHelper.On("BulkWrite", mock.Anything, mock.MatchedBy(func(models []mongo.WriteModel) bool {
+			return len(models) == 1
+		}), ordered(false)).Return(&mongo.BulkWriteResult{InsertedCount: 1}, nil).Once()
+
+		results, err := repo.BulkWrite(context.TODO(), []domain.BulkOperation{
+			{Action: domain.BulkUpdate, ID: updated.Hex(), Task: &domain.Task{Version: 2}},
+			{Action: domain.BulkDelete, ID: deleted.Hex()},
+			{Action: domain.BulkDelete, ID: "bad"},
+			{Action: domain.BulkCreate, Task: &domain.Task{Title: "New"}},
+		}, domain.BulkOptions{})
+
+		assert.NoError(t, err)
+		assert.ErrorIs(t, results[0].Err, domain.ErrConflict)
+		assert.ErrorIs(t, results[1].Err, domain.ErrNotFound)
+		assert.ErrorIs(t, results[2].Err, domain.ErrInvalidID)
+		assert.NoError(t, results[3].Err)
+		collectionHelper.AssertExpectations(t)
+	})
+
+	t.Run("ordered stops at the first failure", func(t *testing.T) {
+		repo, collectionHelper := newRepository(t, bson.M{"_id": updated, "version": 3})
+		collectionHelper.On("BulkWrite", mock.Anything, mock.MatchedBy(func(models []mongo.WriteModel) bool {
+			return len(models) == 1
+		}), ordered(true)).Return(&mongo.BulkWriteResult{MatchedCount: 1}, nil).Once()
+
+		results, err := repo.BulkWrite(context.TODO(), []domain.BulkOperation{
+			{Action: domain.BulkDelete, ID: updated.Hex()},
+			{Action: domain.BulkUpdate, ID: updated.Hex(), Task: &domain.Task{Version: 3}},
+			{Action: domain.BulkCreate, Task: &domain.Task{Title: "New"}},
+		}, domain.BulkOptions{Ordered: true})
+
+		assert.NoError(t, err)
+		assert.NoError(t, results[0].Err)
+		assert.ErrorIs(t, results[1].Err, domain.ErrNotFound)
+		assert.ErrorIs(t, results[2].Err, domain.ErrSkipped)
+		collectionHelper.AssertExpectations(t)
+	})
+
+	t.Run("write errors", func(t *testing.T) {
+		repo, collectionHelper := newRepository(t)
+		collectionHelper.On("BulkWrite", mock.Anything, mock.Anything, ordered(true)).Return(&mongo.BulkWriteResult{InsertedCount: 1}, mongo.BulkWriteException{
+			WriteErrors: []mongo.BulkWriteError{{WriteError: mongo.WriteError{Index: 1, Code: 11000, Message: "E11000 duplicate key"}}},
+		}).Once()
+
+		results, err := repo.BulkWrite(context.TODO(), []domain.BulkOperation{
+			{Action: domain.BulkCreate, Task: &domain.Task{Title: "First"}},
+			{Action: domain.BulkCreate, Task: &domain.Task{Title: "Second"}},
+			{Action: domain.BulkCreate, Task: &domain.Task{Title: "Third"}},
+		}, domain.BulkOptions{Ordered: true})
+
+		assert.NoError(t, err)
+		assert.NoError(t, results[0].Err)
+		assert.ErrorIs(t, results[1].Err, domain.ErrDuplicate)
+		assert.ErrorIs(t, results[2].Err, domain.ErrSkipped)
+	})
+
+	t.Run("update lost a race", func(t *testing.T) {
+		repo, collectionHelper := newRepository(t, bson.M{"_id": updated, "version": 3})
+		collectionHelper.On("BulkWrite", mock.Anything, mock.Anything, ordered(false)).Return(&mongo.BulkWriteResult{}, nil).Once()
+		cursor, err := mongo.NewCursorFromDocuments([]any{bson.M{"_id": updated, "version": 4}}, nil, nil)
+		assert.NoError(t, err)
+		// the version read after the batch, another writer moved the task to version 4
+		collectionHelper.On("Find", mock.Anything, mock.Anything, mock.Anything).Return(cursor, nil).Once()
+
+		results, err := repo.BulkWrite(context.TODO(), []domain.BulkOperation{
+			{Action: domain.BulkUpdate, ID: updated.Hex(), Task: &domain.Task{Version: 3}},
+			{Action: domain.BulkUpdate, ID: updated.Hex(), Task: &domain.Task{Version: 4}},
+		}, domain.BulkOptions{})
+
+		assert.NoError(t, err)
+		assert.ErrorIs(t, results[0].Err, domain.ErrConflict)
+		assert.ErrorIs(t, results[1].Err, domain.ErrConflict)
+		collectionHelper.AssertExpectations(t)
+	})
+}
+
+func TestTaskRepositoryImport(t *testing.T) {
+	databaseHelper := &mocks.Database{}
+	collectionHelper := &mocks.Collection{}
+	databaseHelper.On("Collection", domain.CollectionTask).Return(collectionHelper)
+	repo := repository.NewTaskTransferRepository(databaseHelper, domain.CollectionTask)
+
+	kept := primitive.NewObjectID()
+	collectionHelper.On("BulkWrite", mock.Anything, mock.MatchedBy(func(models []mongo.WriteModel) bool {
+		first := models[0].(*mongo.InsertOneModel).Document.(*domain.Task)
+		second := models[1].(*mongo.InsertOneModel).Document.(*domain.Task)
+		return len(models) == 2 && first.ID == kept && first.Version == 1 && !second.ID.IsZero()
+	}), mock.MatchedBy(func(opts *options.BulkWriteOptions) bool {
+		return !*opts.Ordered
+	})).Return(&mongo.BulkWriteResult{InsertedCount: 1}, mongo.BulkWriteException{
+		WriteErrors: []mongo.BulkWriteError{{WriteError: mongo.WriteError{Index: 0, Code: 11000}}},
+	}).Once()
+
+	errs, err := repo.Import(context.TODO(), []domain.Task{{ID: kept, Title: "Kept"}, {Title: "New"}})
+
+	assert.NoError(t, err)
+	assert.ErrorIs(t, errs[0], domain.ErrDuplicate)
+	assert.NoError(t, errs[1])
+	collectionHelper.AssertExpectations(t)
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func NewTaskProjectRepository(db mongo.Database, collection string) domain.TaskProjectRepository {
+	return newTaskRepository(db, collection, domain.SystemClock{})
+}
+
+func (r *taskRepository) FetchByProjectID(c context.Context, projectID primitive.ObjectID, page domain.PageRequest) ([]domain.Task, int64, error) {
+	filter := live(bson.M{"projectID": projectID})
+
+	total, err := r.tasks.Count(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tasks, err := r.tasks.FindMany(c, filter, FindOptions{
+		Sort: bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}},
+		Page: page.Page,
+		Size: page.Size,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return tasks, total, nil
+}
+
+// FetchOpenByProjectID lists the live tasks of the project that are not closed, oldest first.
+func (r *taskRepository) FetchOpenByProjectID(c context.Context, projectID primitive.ObjectID) ([]domain.Task, error) {
+	return r.tasks.FindMany(c, live(bson.M{"projectID": projectID, "status": bson.M{"$nin": closedStatuses}}), FindOptions{
+		Sort: bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}},
+	})
+}
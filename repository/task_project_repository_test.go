@@ -0,0 +1,60 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo/mocks"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestTaskRepositoryFetchByProjectID(t *testing.T) {
+	databaseHelper := &mocks.Database{}
+	collectionHelper := &mocks.Collection{}
+	databaseHelper.On("Collection", domain.CollectionTask).Return(collectionHelper)
+	repo := repository.NewTaskProjectRepository(databaseHelper, domain.CollectionTask)
+
+	projectID := primitive.NewObjectID()
+	filter := bson.M{"projectID": projectID, "deletedAt": nil}
+	cursor, err := mongo.NewCursorFromDocuments([]any{domain.Task{Title: "first", ProjectID: projectID}}, nil, nil)
+	assert.NoError(t, err)
+	collectionHelper.On("CountDocuments", mock.Anything, filter).Return(int64(1), nil).Once()
+	collectionHelper.On("Find", mock.Anything, filter, mock.MatchedBy(func(opts *options.FindOptions) bool {
+		return assert.ObjectsAreEqual(bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}}, opts.Sort)
+	})).Return(cursor, nil).Once()
+
+	tasks, total, err := repo.FetchByProjectID(context.TODO(), projectID, domain.PageRequest{Page: 1, Size: 20})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "first", tasks[0].Title)
+	assert.Equal(t, int64(1), total)
+	collectionHelper.AssertExpectations(t)
+}
+
+func TestTaskRepositoryFetchOpenByProjectID(t *testing.T) {
+	projectID, open := primitive.NewObjectID(), primitive.NewObjectID()
+
+	databaseHelper := &mocks.Database{}
+	collectionHelper := &mocks.Collection{}
+	databaseHelper.On("Collection", domain.CollectionTask).Return(collectionHelper)
+	repo := repository.NewTaskProjectRepository(databaseHelper, domain.CollectionTask)
+	cursor, err := mongo.NewCursorFromDocuments([]any{domain.Task{ID: open, Status: domain.StatusInProgress, Version: 2}}, nil, nil)
+	assert.NoError(t, err)
+	collectionHelper.On("Find", mock.Anything, mock.MatchedBy(func(filter bson.M) bool {
+		return filter["projectID"] == projectID &&
+			assert.ObjectsAreEqual(bson.M{"$nin": []domain.TaskStatus{domain.StatusDone, domain.StatusArchived}}, filter["status"])
+	}), mock.Anything).Return(cursor, nil).Once()
+
+	tasks, err := repo.FetchOpenByProjectID(context.TODO(), projectID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Task{{ID: open, Status: domain.StatusInProgress, Version: 2}}, tasks)
+	collectionHelper.AssertExpectations(t)
+}
@@ -1,87 +1,515 @@
 package repository
 
 import (
+	"bytes"
 	"context"
+	"slices"
+	"time"
 
 	"github.com/sing3demons/go-backend-clean-architecture/domain"
 	"github.com/sing3demons/go-backend-clean-architecture/mongo"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
 )
 
 type taskRepository struct {
-	database   mongo.Database
-	collection string
+	tasks *Mongo[domain.Task]
+	// matches reads search results, which carry the text score of each task
+	matches *Mongo[domain.TaskMatch]
+	clock   domain.Clock
+}
+
+func TaskIndexes() []mongo.Index {
+	return []mongo.Index{
+		{Name: "userID_1", Keys: bson.D{{Key: "userID", Value: 1}}},
+		{Name: "deletedAt_1", Keys: bson.D{{Key: "deletedAt", Value: 1}}, Sparse: true},
+		// Overdue and due-within queries range over dueDate and exclude closed statuses
+		{Name: "dueDate_1_status_1", Keys: bson.D{{Key: "dueDate", Value: 1}, {Key: "status", Value: 1}}},
+		{Name: "priority_1_dueDate_1", Keys: bson.D{{Key: "priority", Value: 1}, {Key: "dueDate", Value: 1}}},
+		{Name: "status_1_dueDate_1", Keys: bson.D{{Key: "status", Value: 1}, {Key: "dueDate", Value: 1}}},
+		// Stats select the tasks created or completed in a range
+		{Name: "createdAt_1", Keys: bson.D{{Key: "createdAt", Value: 1}}},
+		{Name: "completedAt_1", Keys: bson.D{{Key: "completedAt", Value: 1}}, Sparse: true},
+		// Subtree and dependency graph lookups
+		{Name: "parentID_1", Keys: bson.D{{Key: "parentID", Value: 1}}, Sparse: true},
+		{Name: "blockedBy_1", Keys: bson.D{{Key: "blockedBy", Value: 1}}, Sparse: true},
+		// Project listings page through the tasks of a project by creation
+		{
+			Name:          "projectID_1_createdAt_1",
+			Keys:          bson.D{{Key: "projectID", Value: 1}, {Key: "createdAt", Value: 1}},
+			PartialFilter: bson.M{"projectID": bson.M{"$exists": true}},
+		},
+		// Full-text search, a title match counts more than a description match
+		{
+			Name:    "title_text_description_text",
+			Keys:    bson.D{{Key: "title", Value: "text"}, {Key: "description", Value: "text"}},
+			Weights: bson.D{{Key: "title", Value: 5}, {Key: "description", Value: 1}},
+		},
+		// Completing an occurrence twice must not create its successor twice
+		{
+			Name:          "seriesID_1_occurrence_1",
+			Keys:          bson.D{{Key: "seriesID", Value: 1}, {Key: "occurrence", Value: 1}},
+			Unique:        true,
+			PartialFilter: bson.M{"seriesID": bson.M{"$exists": true}},
+		},
+	}
 }
 
 func NewTaskRepository(db mongo.Database, collection string) domain.TaskRepository {
+	return NewTaskRepositoryWithClock(db, collection, domain.SystemClock{})
+}
+
+func NewTaskRepositoryWithClock(db mongo.Database, collection string, clock domain.Clock) domain.TaskRepository {
+	return newTaskRepository(db, collection, clock)
+}
+
+func NewTaskReminderRepository(db mongo.Database, collection string) domain.TaskReminderRepository {
+	return NewTaskReminderRepositoryWithClock(db, collection, domain.SystemClock{})
+}
+
+func NewTaskReminderRepositoryWithClock(db mongo.Database, collection string, clock domain.Clock) domain.TaskReminderRepository {
+	return newTaskRepository(db, collection, clock)
+}
+
+// newTaskRepository backs every task repository interface, they all work on the same collection.
+func newTaskRepository(db mongo.Database, collection string, clock domain.Clock) *taskRepository {
 	return &taskRepository{
-		database:   db,
-		collection: collection,
+		tasks:   NewMongo[domain.Task](db, collection).Use(AuditHook{Clock: clock}),
+		matches: NewMongo[domain.TaskMatch](db, collection),
+		clock:   clock,
+	}
+}
+
+// live restricts filter to tasks that are not soft-deleted, a null match also covers a missing field.
+func live(filter bson.M) bson.M {
+	filter["deletedAt"] = nil
+	return filter
+}
+
+// scoped restricts filter to the tasks of scope, the null in $in matches the tasks without a project.
+func scoped(filter bson.M, scope domain.TaskScope) bson.M {
+	if scope.AllProjects {
+		return filter
+	}
+
+	projects := bson.A{nil}
+	for _, id := range scope.ProjectIDs {
+		projects = append(projects, id)
 	}
+	filter["projectID"] = bson.M{"$in": projects}
+	return filter
 }
 
+// Create starts a recurring series at a recurring task without one, an occurrence that already exists
+// in its series reports domain.ErrDuplicate.
 func (r *taskRepository) Create(c context.Context, task *domain.Task) error {
-	col := r.database.Collection(r.collection)
+	// A duplicate key error aborts the transaction, inside one the occurrence is looked up first
+	if !task.SeriesID.IsZero() && mongo.InTransaction(c) {
+		exists, err := r.tasks.Exists(c, bson.M{"seriesID": task.SeriesID, "occurrence": task.Occurrence})
+		if err != nil {
+			return err
+		}
+		if exists {
+			return domain.ErrDuplicate
+		}
+	}
+
+	newTask(task)
 
+	err := r.tasks.Insert(c, task)
+	if driver.IsDuplicateKeyError(err) {
+		return domain.ErrDuplicate
+	}
+	return err
+}
+
+func newTask(task *domain.Task) {
 	task.ID = primitive.NewObjectID()
+	initTask(task)
+}
 
-	_, err := col.InsertOne(c, task)
+// initTask sets the fields of a task about to be inserted with its id.
+func initTask(task *domain.Task) {
+	task.Version = 1
+	task.Status = task.Status.OrDefault()
 
-	return err
+	if task.Recurrence != "" && task.SeriesID.IsZero() {
+		task.SeriesID = task.ID
+		task.SeriesStart = task.DueDate
+		task.Occurrence = 1
+	}
 }
 
 func (r *taskRepository) FetchAll(c context.Context) ([]domain.Task, error) {
-	col := r.database.Collection(r.collection)
-	tasks := []domain.Task{}
+	return r.tasks.FindMany(c, live(bson.M{}))
+}
 
-	cursor, err := col.Find(context.Background(), bson.M{})
+func (r *taskRepository) FetchByUserID(c context.Context, userID string) ([]domain.Task, error) {
+	idHex, err := ObjectID(userID)
 	if err != nil {
-		return tasks, err
+		return []domain.Task{}, err
 	}
 
-	defer cursor.Close(c)
+	return r.tasks.FindMany(c, live(bson.M{"userID": idHex}))
+}
 
-	if err := cursor.All(c, &tasks); err != nil {
-		return nil, err
+func (r *taskRepository) FetchByTaskID(c context.Context, taskID string) (domain.Task, error) {
+	idHex, err := ObjectID(taskID)
+	if err != nil {
+		return domain.Task{}, err
 	}
 
-	return tasks, err
+	return r.tasks.FindOne(c, live(bson.M{"_id": idHex}))
 }
 
-func (r *taskRepository) FetchByUserID(c context.Context, userID string) ([]domain.Task, error) {
-	col := r.database.Collection(r.collection)
-	tasks := []domain.Task{}
-	idHex, err := primitive.ObjectIDFromHex(userID)
+// closedStatuses are left out of due date queries.
+var closedStatuses = []domain.TaskStatus{domain.StatusDone, domain.StatusArchived}
+
+// FetchByFilter lists live tasks matching filter, sorted by due date when filtering on due dates or priority.
+func (r *taskRepository) FetchByFilter(c context.Context, filter domain.TaskFilter) ([]domain.Task, error) {
+	query := live(bson.M{})
+	if filter.UserID != "" {
+		idHex, err := ObjectID(filter.UserID)
+		if err != nil {
+			return []domain.Task{}, err
+		}
+		query["userID"] = idHex
+	}
+	if filter.Scope != nil {
+		query = scoped(query, *filter.Scope)
+	}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+	if filter.Priority != domain.PriorityNone {
+		query["priority"] = filter.Priority
+	}
+
+	now := r.clock.Now()
+	due := bson.M{}
+	if filter.Overdue {
+		due["$lt"] = now
+	}
+	if filter.DueWithin > 0 {
+		due["$gte"] = now
+		due["$lt"] = now.Add(filter.DueWithin)
+	}
+	if len(due) > 0 {
+		query["dueDate"] = due
+		if filter.Status == "" {
+			query["status"] = bson.M{"$nin": closedStatuses}
+		}
+	}
+
+	if len(due) == 0 && filter.Priority == domain.PriorityNone {
+		return r.tasks.FindMany(c, query)
+	}
+	return r.tasks.FindMany(c, query, FindOptions{Sort: bson.D{{Key: "dueDate", Value: 1}}})
+}
+
+func (r *taskRepository) FetchOverdue(c context.Context) ([]domain.Task, error) {
+	return r.FetchByFilter(c, domain.TaskFilter{Overdue: true})
+}
+
+func (r *taskRepository) FetchDueWithin(c context.Context, window time.Duration) ([]domain.Task, error) {
+	return r.FetchByFilter(c, domain.TaskFilter{DueWithin: window})
+}
+
+func (r *taskRepository) FetchByPriority(c context.Context, priority domain.TaskPriority) ([]domain.Task, error) {
+	return r.FetchByFilter(c, domain.TaskFilter{Priority: priority})
+}
+
+// Update replaces the editable fields of the task, a nil due date or empty description removes it.
+// The reminder of the task is reset.
+func (r *taskRepository) Update(c context.Context, task *domain.Task) error {
+	updated, err := r.tasks.UpdateVersion(c, live(bson.M{"_id": task.ID}), task.Version, taskUpdate(task))
 	if err != nil {
-		return tasks, err
+		return err
 	}
 
-	cursor, err := col.Find(c, domain.Task{UserID: idHex})
+	*task = updated
+	return nil
+}
+
+// taskUpdate rewrites the mutable fields of task, empty fields are removed.
+func taskUpdate(task *domain.Task) bson.M {
+	set := bson.M{"title": task.Title}
+	unset := bson.M{}
+
+	if task.Description != "" {
+		set["description"] = task.Description
+	} else {
+		unset["description"] = ""
+	}
+	if task.DueDate != nil {
+		set["dueDate"] = task.DueDate
+	} else {
+		unset["dueDate"] = ""
+	}
+	// The due date may have moved, remind it again
+	unset["remindedAt"] = ""
+	if task.Priority != domain.PriorityNone {
+		set["priority"] = task.Priority
+	} else {
+		unset["priority"] = ""
+	}
+	if task.Recurrence != "" {
+		set["recurrence"] = task.Recurrence
+	} else {
+		unset["recurrence"] = ""
+	}
+	if !task.ParentID.IsZero() {
+		set["parentID"] = task.ParentID
+	} else {
+		unset["parentID"] = ""
+	}
+	if len(task.BlockedBy) > 0 {
+		set["blockedBy"] = task.BlockedBy
+	} else {
+		unset["blockedBy"] = ""
+	}
+	if task.TimeZone != "" {
+		set["timeZone"] = task.TimeZone
+	} else {
+		unset["timeZone"] = ""
+	}
+	if !task.ProjectID.IsZero() {
+		set["projectID"] = task.ProjectID
+	} else {
+		unset["projectID"] = ""
+	}
+
+	return bson.M{"$set": set, "$unset": unset}
+}
+
+func (r *taskRepository) UpdateStatus(c context.Context, task *domain.Task) error {
+	update := bson.M{"$set": bson.M{"status": task.Status}}
+	if task.Status == domain.StatusDone {
+		update["$set"].(bson.M)["completedAt"] = r.clock.Now()
+	} else {
+		update["$unset"] = bson.M{"completedAt": ""}
+	}
+
+	updated, err := r.tasks.UpdateVersion(c, live(bson.M{"_id": task.ID}), task.Version, update)
 	if err != nil {
-		return tasks, err
+		return err
 	}
 
-	defer cursor.Close(c)
+	*task = updated
+	return nil
+}
 
-	if err := cursor.All(c, &tasks); err != nil {
-		return nil, err
+// Delete soft-deletes the task, deleting it again reports domain.ErrNotFound.
+func (r *taskRepository) Delete(c context.Context, taskID string) error {
+	idHex, err := ObjectID(taskID)
+	if err != nil {
+		return err
 	}
 
-	return tasks, err
+	return r.tasks.UpdateOne(c, live(bson.M{"_id": idHex}), r.softDelete())
 }
 
-func (r *taskRepository) FetchByTaskID(c context.Context, taskID string) (domain.Task, error) {
-	col := r.database.Collection(r.collection)
-	task := domain.Task{}
-	idHex, err := primitive.ObjectIDFromHex(taskID)
+func (r *taskRepository) softDelete() bson.M {
+	return bson.M{"$set": bson.M{"deletedAt": r.clock.Now()}}
+}
+
+func (r *taskRepository) Restore(c context.Context, taskID string) error {
+	idHex, err := ObjectID(taskID)
+	if err != nil {
+		return err
+	}
+
+	return r.tasks.UpdateOne(c, bson.M{"_id": idHex, "deletedAt": bson.M{"$ne": nil}}, bson.M{
+		"$unset": bson.M{"deletedAt": ""},
+	})
+}
+
+func (r *taskRepository) FetchDeleted(c context.Context) ([]domain.Task, error) {
+	return r.tasks.FindMany(c, bson.M{"deletedAt": bson.M{"$ne": nil}}, FindOptions{
+		Sort: bson.D{{Key: "deletedAt", Value: -1}},
+	})
+}
+
+func (r *taskRepository) FetchDeletedByTaskID(c context.Context, taskID string) (domain.Task, error) {
+	idHex, err := ObjectID(taskID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	return r.tasks.FindOne(c, bson.M{"_id": idHex, "deletedAt": bson.M{"$ne": nil}})
+}
+
+func (r *taskRepository) PurgeDeleted(c context.Context, retention time.Duration) (int64, error) {
+	return r.tasks.DeleteMany(c, bson.M{"deletedAt": bson.M{"$lt": r.clock.Now().Add(-retention)}})
+}
+
+func (r *taskRepository) FetchDueForReminder(c context.Context, window time.Duration) ([]domain.Task, error) {
+	now := r.clock.Now()
+	return r.tasks.FindMany(c, live(bson.M{
+		"status":     bson.M{"$nin": closedStatuses},
+		"dueDate":    bson.M{"$gte": now, "$lt": now.Add(window)},
+		"remindedAt": nil,
+	}), FindOptions{Sort: bson.D{{Key: "dueDate", Value: 1}}})
+}
+
+// MarkReminded bypasses the hooks, a reminder is not an edit of the task and keeps its audit fields and version.
+func (r *taskRepository) MarkReminded(c context.Context, taskID string) error {
+	idHex, err := ObjectID(taskID)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.tasks.Collection().UpdateOne(c, bson.M{"_id": idHex}, bson.M{
+		"$set": bson.M{"remindedAt": r.clock.Now()},
+	})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *taskRepository) Search(c context.Context, scope domain.TaskScope, query string, page domain.PageRequest) ([]domain.TaskMatch, int64, error) {
+	filter := scoped(live(bson.M{"$text": bson.M{"$search": query}}), scope)
+	score := bson.M{"$meta": "textScore"}
+
+	total, err := r.matches.Count(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	matches, err := r.matches.FindMany(c, filter, FindOptions{
+		Sort:       bson.D{{Key: "score", Value: score}, {Key: "_id", Value: 1}},
+		Projection: bson.M{"score": score},
+		Page:       page.Page,
+		Size:       page.Size,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return matches, total, nil
+}
+
+func (r *taskRepository) Each(c context.Context, scope domain.TaskScope, fn func(domain.Task) error) error {
+	return r.tasks.Each(c, scoped(live(bson.M{}), scope), FindOptions{Sort: bson.D{{Key: "_id", Value: 1}}}, fn)
+}
+
+func (r *taskRepository) FetchByIDs(c context.Context, ids []primitive.ObjectID) ([]domain.Task, error) {
+	return r.tasks.FindMany(c, live(bson.M{"_id": bson.M{"$in": ids}}))
+}
+
+// FetchTree loads the live descendants of the task in one $graphLookup over parentID, a deleted
+// subtask hides its own subtasks. Subtasks are in creation order.
+func (r *taskRepository) FetchTree(c context.Context, taskID string) (domain.TaskTree, error) {
+	idHex, err := ObjectID(taskID)
 	if err != nil {
-		return task, err
+		return domain.TaskTree{}, err
+	}
+
+	pipeline := bson.A{
+		bson.M{"$match": live(bson.M{"_id": idHex})},
+		bson.M{"$graphLookup": bson.M{
+			"from":                    r.tasks.collection,
+			"startWith":               "$_id",
+			"connectFromField":        "_id",
+			"connectToField":          "parentID",
+			"as":                      "descendants",
+			"restrictSearchWithMatch": bson.M{"deletedAt": nil},
+		}},
+	}
+
+	var results []struct {
+		domain.Task `bson:",inline"`
+		Descendants []domain.Task `bson:"descendants"`
+	}
+	if err := r.tasks.Aggregate(c, pipeline, &results); err != nil {
+		return domain.TaskTree{}, err
+	}
+	if len(results) == 0 {
+		return domain.TaskTree{}, domain.ErrNotFound
+	}
+
+	return buildTaskTree(results[0].Task, results[0].Descendants), nil
+}
+
+func buildTaskTree(root domain.Task, descendants []domain.Task) domain.TaskTree {
+	children := map[primitive.ObjectID][]domain.Task{}
+	for _, task := range descendants {
+		children[task.ParentID] = append(children[task.ParentID], task)
+	}
+
+	var build func(task domain.Task) *domain.TaskTree
+	build = func(task domain.Task) *domain.TaskTree {
+		subtasks := children[task.ID]
+		// ObjectIDs start with their creation time
+		slices.SortFunc(subtasks, func(a, b domain.Task) int { return bytes.Compare(a.ID[:], b.ID[:]) })
+
+		tree := &domain.TaskTree{Task: task, Subtasks: []*domain.TaskTree{}}
+		for _, subtask := range subtasks {
+			tree.Subtasks = append(tree.Subtasks, build(subtask))
+		}
+		return tree
 	}
 
-	result := col.FindOne(c, domain.Task{ID: idHex})
-	if err := result.Decode(&task); err != nil {
-		return task, err
+	return *build(root)
+}
+
+type graphIDs struct {
+	ID    primitive.ObjectID `bson:"_id"`
+	Chain []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	} `bson:"chain"`
+}
+
+func (r *taskRepository) FetchAncestorIDs(c context.Context, taskID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	var results []graphIDs
+	if err := r.tasks.Aggregate(c, r.graphPipeline(bson.M{"_id": taskID}, "parentID"), &results); err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	ids := []primitive.ObjectID{}
+	for _, ancestor := range results[0].Chain {
+		ids = append(ids, ancestor.ID)
+	}
+	return ids, nil
+}
+
+func (r *taskRepository) FetchBlockerIDs(c context.Context, ids []primitive.ObjectID) ([]primitive.ObjectID, error) {
+	var results []graphIDs
+	if err := r.tasks.Aggregate(c, r.graphPipeline(bson.M{"_id": bson.M{"$in": ids}}, "blockedBy"), &results); err != nil {
+		return nil, err
+	}
+
+	blockers := []primitive.ObjectID{}
+	for _, result := range results {
+		blockers = append(blockers, result.ID)
+		for _, blocker := range result.Chain {
+			blockers = append(blockers, blocker.ID)
+		}
+	}
+	slices.SortFunc(blockers, func(a, b primitive.ObjectID) int { return bytes.Compare(a[:], b[:]) })
+	return slices.Compact(blockers), nil
+}
+
+// graphPipeline follows field from the live tasks matching match to the tasks it references, keeping
+// only their ids in chain.
+func (r *taskRepository) graphPipeline(match bson.M, field string) bson.A {
+	return bson.A{
+		bson.M{"$match": live(match)},
+		bson.M{"$graphLookup": bson.M{
+			"from":                    r.tasks.collection,
+			"startWith":               "$" + field,
+			"connectFromField":        field,
+			"connectToField":          "_id",
+			"as":                      "chain",
+			"restrictSearchWithMatch": bson.M{"deletedAt": nil},
+		}},
+		bson.M{"$project": bson.M{"chain._id": 1}},
 	}
-	return task, nil
 }
@@ -2,11 +2,15 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/sing3demons/go-backend-clean-architecture/domain"
 	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// MockTaskRepository implements every task repository interface of domain, a test passes it for each
+// repository a usecase takes.
 type MockTaskRepository struct {
 	mock.Mock
 }
@@ -91,12 +95,420 @@ func (_m *MockTaskRepository) FetchByTaskID(c context.Context, taskID string) (d
 	return r0, r1
 }
 
+func (_m *MockTaskRepository) FetchByFilter(c context.Context, filter domain.TaskFilter) ([]domain.Task, error) {
+	ret := _m.Called(c, filter)
+
+	var r0 []domain.Task
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TaskFilter) []domain.Task); ok {
+		r0 = rf(c, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.TaskFilter) error); ok {
+		r1 = rf(c, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *MockTaskRepository) FetchOverdue(c context.Context) ([]domain.Task, error) {
+	ret := _m.Called(c)
+
+	var r0 []domain.Task
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Task); ok {
+		r0 = rf(c)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *MockTaskRepository) FetchDueWithin(c context.Context, window time.Duration) ([]domain.Task, error) {
+	ret := _m.Called(c, window)
+
+	var r0 []domain.Task
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) []domain.Task); ok {
+		r0 = rf(c, window)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(c, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *MockTaskRepository) FetchByPriority(c context.Context, priority domain.TaskPriority) ([]domain.Task, error) {
+	ret := _m.Called(c, priority)
+
+	var r0 []domain.Task
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TaskPriority) []domain.Task); ok {
+		r0 = rf(c, priority)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.TaskPriority) error); ok {
+		r1 = rf(c, priority)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *MockTaskRepository) Update(c context.Context, task *domain.Task) error {
+	ret := _m.Called(c, task)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Task) error); ok {
+		r0 = rf(c, task)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (_m *MockTaskRepository) UpdateStatus(c context.Context, task *domain.Task) error {
+	ret := _m.Called(c, task)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Task) error); ok {
+		r0 = rf(c, task)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (_m *MockTaskRepository) Delete(c context.Context, taskID string) error {
+	ret := _m.Called(c, taskID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, taskID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (_m *MockTaskRepository) Restore(c context.Context, taskID string) error {
+	ret := _m.Called(c, taskID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, taskID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (_m *MockTaskRepository) FetchDeleted(c context.Context) ([]domain.Task, error) {
+	ret := _m.Called(c)
+
+	var r0 []domain.Task
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Task); ok {
+		r0 = rf(c)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *MockTaskRepository) FetchDeletedByTaskID(c context.Context, taskID string) (domain.Task, error) {
+	ret := _m.Called(c, taskID)
+
+	var r0 domain.Task
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Task); ok {
+		r0 = rf(c, taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *MockTaskRepository) PurgeDeleted(c context.Context, retention time.Duration) (int64, error) {
+	ret := _m.Called(c, retention)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) int64); ok {
+		r0 = rf(c, retention)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(c, retention)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *MockTaskRepository) FetchDueForReminder(c context.Context, window time.Duration) ([]domain.Task, error) {
+	ret := _m.Called(c, window)
+
+	var r0 []domain.Task
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) []domain.Task); ok {
+		r0 = rf(c, window)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(c, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *MockTaskRepository) MarkReminded(c context.Context, taskID string) error {
+	ret := _m.Called(c, taskID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, taskID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (_m *MockTaskRepository) FetchByIDs(c context.Context, ids []primitive.ObjectID) ([]domain.Task, error) {
+	ret := _m.Called(c, ids)
+
+	var r0 []domain.Task
+	if rf, ok := ret.Get(0).(func(context.Context, []primitive.ObjectID) []domain.Task); ok {
+		r0 = rf(c, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []primitive.ObjectID) error); ok {
+		r1 = rf(c, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *MockTaskRepository) FetchTree(c context.Context, taskID string) (domain.TaskTree, error) {
+	ret := _m.Called(c, taskID)
+
+	var r0 domain.TaskTree
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.TaskTree); ok {
+		r0 = rf(c, taskID)
+	} else {
+		r0 = ret.Get(0).(domain.TaskTree)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *MockTaskRepository) FetchAncestorIDs(c context.Context, taskID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	ret := _m.Called(c, taskID)
+
+	var r0 []primitive.ObjectID
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) []primitive.ObjectID); ok {
+		r0 = rf(c, taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]primitive.ObjectID)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID) error); ok {
+		r1 = rf(c, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *MockTaskRepository) FetchBlockerIDs(c context.Context, ids []primitive.ObjectID) ([]primitive.ObjectID, error) {
+	ret := _m.Called(c, ids)
+
+	var r0 []primitive.ObjectID
+	if rf, ok := ret.Get(0).(func(context.Context, []primitive.ObjectID) []primitive.ObjectID); ok {
+		r0 = rf(c, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]primitive.ObjectID)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []primitive.ObjectID) error); ok {
+		r1 = rf(c, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *MockTaskRepository) Search(c context.Context, scope domain.TaskScope, query string, page domain.PageRequest) ([]domain.TaskMatch, int64, error) {
+	ret := _m.Called(c, scope, query, page)
+
+	var r0 []domain.TaskMatch
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]domain.TaskMatch)
+	}
+
+	return r0, ret.Get(1).(int64), ret.Error(2)
+}
+
+func (_m *MockTaskRepository) BulkWrite(c context.Context, operations []domain.BulkOperation, opts domain.BulkOptions) ([]domain.BulkResult, error) {
+	ret := _m.Called(c, operations, opts)
+
+	var r0 []domain.BulkResult
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]domain.BulkResult)
+	}
+
+	return r0, ret.Error(1)
+}
+
+func (_m *MockTaskRepository) Each(c context.Context, scope domain.TaskScope, fn func(domain.Task) error) error {
+	ret := _m.Called(c, scope, fn)
+	return ret.Error(0)
+}
+
+func (_m *MockTaskRepository) Import(c context.Context, tasks []domain.Task) ([]error, error) {
+	ret := _m.Called(c, tasks)
+
+	var r0 []error
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]error)
+	}
+
+	return r0, ret.Error(1)
+}
+
+func (_m *MockTaskRepository) FetchByProjectID(c context.Context, projectID primitive.ObjectID, page domain.PageRequest) ([]domain.Task, int64, error) {
+	ret := _m.Called(c, projectID, page)
+
+	var r0 []domain.Task
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]domain.Task)
+	}
+
+	return r0, ret.Get(1).(int64), ret.Error(2)
+}
+
+func (_m *MockTaskRepository) FetchOpenByProjectID(c context.Context, projectID primitive.ObjectID) ([]domain.Task, error) {
+	ret := _m.Called(c, projectID)
+
+	var r0 []domain.Task
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]domain.Task)
+	}
+
+	return r0, ret.Error(1)
+}
+
 func NewMockTaskRepository() *MockTaskRepository {
 	m := &MockTaskRepository{}
 	m.On("Create", mock.Anything, mock.Anything).Return(nil)
 	m.On("FetchAll", mock.Anything).Return([]domain.Task{}, nil)
 	m.On("FetchByUserID", mock.Anything, mock.Anything).Return([]domain.Task{}, nil)
 	m.On("FetchByTaskID", mock.Anything, mock.Anything).Return(domain.Task{}, nil)
+	m.On("FetchByFilter", mock.Anything, mock.Anything).Return([]domain.Task{}, nil)
+	m.On("FetchOverdue", mock.Anything).Return([]domain.Task{}, nil)
+	m.On("FetchDueWithin", mock.Anything, mock.Anything).Return([]domain.Task{}, nil)
+	m.On("FetchByPriority", mock.Anything, mock.Anything).Return([]domain.Task{}, nil)
+	m.On("Update", mock.Anything, mock.Anything).Return(nil)
+	m.On("UpdateStatus", mock.Anything, mock.Anything).Return(nil)
+	m.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	m.On("Restore", mock.Anything, mock.Anything).Return(nil)
+	m.On("FetchDeleted", mock.Anything).Return([]domain.Task{}, nil)
+	m.On("FetchDeletedByTaskID", mock.Anything, mock.Anything).Return(domain.Task{}, nil)
+	m.On("PurgeDeleted", mock.Anything, mock.Anything).Return(int64(0), nil)
+	m.On("FetchDueForReminder", mock.Anything, mock.Anything).Return([]domain.Task{}, nil)
+	m.On("MarkReminded", mock.Anything, mock.Anything).Return(nil)
+	m.On("FetchByIDs", mock.Anything, mock.Anything).Return([]domain.Task{}, nil)
+	m.On("FetchTree", mock.Anything, mock.Anything).Return(domain.TaskTree{}, nil)
+	m.On("FetchAncestorIDs", mock.Anything, mock.Anything).Return([]primitive.ObjectID{}, nil)
+	m.On("FetchBlockerIDs", mock.Anything, mock.Anything).Return([]primitive.ObjectID{}, nil)
+	m.On("Search", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]domain.TaskMatch{}, int64(0), nil)
+	m.On("BulkWrite", mock.Anything, mock.Anything, mock.Anything).Return([]domain.BulkResult{}, nil)
+	m.On("Each", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	m.On("Import", mock.Anything, mock.Anything).Return([]error{}, nil)
+	m.On("FetchByProjectID", mock.Anything, mock.Anything, mock.Anything).Return([]domain.Task{}, int64(0), nil)
+	m.On("FetchOpenByProjectID", mock.Anything, mock.Anything).Return([]domain.Task{}, nil)
 
 	// mock.Mock.Test(t)
 
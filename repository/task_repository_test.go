@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/sing3demons/go-backend-clean-architecture/domain"
 	"github.com/sing3demons/go-backend-clean-architecture/mongo/mocks"
@@ -13,6 +14,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 const title = "test title"
@@ -76,7 +78,7 @@ func TestTaskRepositoryFetchByUserID(t *testing.T) {
 		Title: title,
 	}
 
-	taskMode := "domain.Task"
+	taskMode := "primitive.M"
 
 	t.Run("success", func(t *testing.T) {
 		collectionHelper.On("All", mock.Anything, mock.AnythingOfType("*[]domain.Task")).Return(nil).Once()
@@ -156,7 +158,7 @@ func TestTaskRepositoryFetchByTaskID(t *testing.T) {
 		UserID: primitive.NewObjectID(),
 	}
 
-	taskMode := "domain.Task"
+	taskMode := "primitive.M"
 
 	t.Run("success", func(t *testing.T) {
 		mockSingleResult := mongo.NewSingleResultFromDocument(bson.M{}, nil, nil)
@@ -192,3 +194,519 @@ func TestTaskRepositoryFetchByTaskID(t *testing.T) {
 	})
 
 }
+
+func TestTaskRepositoryFetchExcludesDeleted(t *testing.T) {
+	databaseHelper := &mocks.Database{}
+	collectionHelper := &mocks.Collection{}
+	userID := primitive.NewObjectID()
+
+	mockCursor, err := mongo.NewCursorFromDocuments([]any{domain.Task{Title: title}}, nil, nil)
+	assert.NoError(t, err)
+	collectionHelper.On("Find", mock.Anything, bson.M{"userID": userID, "deletedAt": nil}).Return(mockCursor, nil).Once()
+	databaseHelper.On("Collection", domain.CollectionTask).Return(collectionHelper).Once()
+
+	repo := repository.NewTaskRepository(databaseHelper, domain.CollectionTask)
+	tasks, err := repo.FetchByUserID(context.TODO(), userID.Hex())
+
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	collectionHelper.AssertExpectations(t)
+}
+
+func TestTaskRepositorySoftDelete(t *testing.T) {
+	id := primitive.NewObjectID()
+	now := time.Date(2025, 2, 1, 10, 0, 0, 0, time.UTC)
+
+	newRepository := func() (domain.TaskRepository, *mocks.Collection) {
+		databaseHelper := &mocks.Database{}
+		collectionHelper := &mocks.Collection{}
+		databaseHelper.On("Collection", domain.CollectionTask).Return(collectionHelper)
+		return repository.NewTaskRepositoryWithClock(databaseHelper, domain.CollectionTask, fixedClock(now)), collectionHelper
+	}
+
+	t.Run("delete", func(t *testing.T) {
+		repo, collectionHelper := newRepository()
+		update := bson.M{"$set": bson.M{"deletedAt": now, "updatedAt": now}}
+		// mocks.Collection.UpdateOne delegates to UpdateMany
+		collectionHelper.On("UpdateMany", mock.Anything, bson.M{"_id": id, "deletedAt": nil}, update).
+			Return(&mongo.UpdateResult{MatchedCount: 1}, nil).Once()
+
+		assert.NoError(t, repo.Delete(context.TODO(), id.Hex()))
+		collectionHelper.AssertExpectations(t)
+	})
+
+	t.Run("delete already deleted", func(t *testing.T) {
+		repo, collectionHelper := newRepository()
+		collectionHelper.On("UpdateMany", mock.Anything, bson.M{"_id": id, "deletedAt": nil}, mock.Anything).
+			Return(&mongo.UpdateResult{}, nil).Once()
+
+		assert.ErrorIs(t, repo.Delete(context.TODO(), id.Hex()), domain.ErrNotFound)
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		repo, collectionHelper := newRepository()
+		update := bson.M{"$unset": bson.M{"deletedAt": ""}, "$set": bson.M{"updatedAt": now}}
+		collectionHelper.On("UpdateMany", mock.Anything, bson.M{"_id": id, "deletedAt": bson.M{"$ne": nil}}, update).
+			Return(&mongo.UpdateResult{MatchedCount: 1}, nil).Once()
+
+		assert.NoError(t, repo.Restore(context.TODO(), id.Hex()))
+		collectionHelper.AssertExpectations(t)
+	})
+
+	t.Run("fetch deleted", func(t *testing.T) {
+		repo, collectionHelper := newRepository()
+		mockCursor, err := mongo.NewCursorFromDocuments([]any{domain.Task{Title: title, DeletedAt: &now}}, nil, nil)
+		assert.NoError(t, err)
+		collectionHelper.On("Find", mock.Anything, bson.M{"deletedAt": bson.M{"$ne": nil}}, mock.Anything).Return(mockCursor, nil).Once()
+
+		tasks, err := repo.FetchDeleted(context.TODO())
+
+		assert.NoError(t, err)
+		assert.Len(t, tasks, 1)
+		assert.NotNil(t, tasks[0].DeletedAt)
+	})
+
+	t.Run("fetch deleted by id", func(t *testing.T) {
+		repo, collectionHelper := newRepository()
+		result := mongo.NewSingleResultFromDocument(domain.Task{ID: id, DeletedAt: &now}, nil, nil)
+		collectionHelper.On("FindOne", mock.Anything, bson.M{"_id": id, "deletedAt": bson.M{"$ne": nil}}).Return(result).Once()
+
+		task, err := repo.FetchDeletedByTaskID(context.TODO(), id.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, id, task.ID)
+		collectionHelper.AssertExpectations(t)
+	})
+
+	t.Run("purge", func(t *testing.T) {
+		repo, collectionHelper := newRepository()
+		cutoff := now.Add(-24 * time.Hour)
+		collectionHelper.On("DeleteMany", mock.Anything, bson.M{"deletedAt": bson.M{"$lt": cutoff}}).Return(int64(3), nil).Once()
+
+		count, err := repo.PurgeDeleted(context.TODO(), 24*time.Hour)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+		collectionHelper.AssertExpectations(t)
+	})
+}
+
+func TestTaskRepositoryDueQueries(t *testing.T) {
+	now := time.Date(2025, 2, 1, 10, 0, 0, 0, time.UTC)
+	sortByDueDate := mock.MatchedBy(func(opts *options.FindOptions) bool {
+		return assert.ObjectsAreEqual(bson.D{{Key: "dueDate", Value: 1}}, opts.Sort)
+	})
+	open := bson.M{"$nin": []domain.TaskStatus{domain.StatusDone, domain.StatusArchived}}
+
+	newRepository := func(filter bson.M) (domain.TaskRepository, *mocks.Collection) {
+		databaseHelper := &mocks.Database{}
+		collectionHelper := &mocks.Collection{}
+		databaseHelper.On("Collection", domain.CollectionTask).Return(collectionHelper)
+
+		cursor, err := mongo.NewCursorFromDocuments([]any{domain.Task{Title: title}}, nil, nil)
+		assert.NoError(t, err)
+		collectionHelper.On("Find", mock.Anything, filter, sortByDueDate).Return(cursor, nil).Once()
+
+		return repository.NewTaskRepositoryWithClock(databaseHelper, domain.CollectionTask, fixedClock(now)), collectionHelper
+	}
+
+	t.Run("overdue", func(t *testing.T) {
+		repo, collectionHelper := newRepository(bson.M{"deletedAt": nil, "status": open, "dueDate": bson.M{"$lt": now}})
+
+		tasks, err := repo.FetchOverdue(context.TODO())
+
+		assert.NoError(t, err)
+		assert.Len(t, tasks, 1)
+		collectionHelper.AssertExpectations(t)
+	})
+
+	t.Run("due within", func(t *testing.T) {
+		repo, collectionHelper := newRepository(bson.M{
+			"deletedAt": nil,
+			"status":    open,
+			"dueDate":   bson.M{"$gte": now, "$lt": now.Add(48 * time.Hour)},
+		})
+
+		_, err := repo.FetchDueWithin(context.TODO(), 48*time.Hour)
+
+		assert.NoError(t, err)
+		collectionHelper.AssertExpectations(t)
+	})
+
+	t.Run("by priority", func(t *testing.T) {
+		repo, collectionHelper := newRepository(bson.M{"deletedAt": nil, "priority": domain.PriorityHigh})
+
+		_, err := repo.FetchByPriority(context.TODO(), domain.PriorityHigh)
+
+		assert.NoError(t, err)
+		collectionHelper.AssertExpectations(t)
+	})
+
+	t.Run("overdue with status", func(t *testing.T) {
+		repo, collectionHelper := newRepository(bson.M{"deletedAt": nil, "status": domain.StatusBlocked, "dueDate": bson.M{"$lt": now}})
+
+		_, err := repo.FetchByFilter(context.TODO(), domain.TaskFilter{Status: domain.StatusBlocked, Overdue: true})
+
+		assert.NoError(t, err)
+		collectionHelper.AssertExpectations(t)
+	})
+}
+
+func TestTaskRepositoryFetchByFilterScope(t *testing.T) {
+	userID, projectID := primitive.NewObjectID(), primitive.NewObjectID()
+
+	tests := []struct {
+		name   string
+		filter domain.TaskFilter
+		query  bson.M
+	}{
+		{
+			name: "user in scope",
+			filter: domain.TaskFilter{
+				UserID: userID.Hex(),
+				Scope:  &domain.TaskScope{ProjectIDs: []primitive.ObjectID{projectID}},
+			},
+			query: bson.M{"userID": userID, "projectID": bson.M{"$in": bson.A{nil, projectID}}, "deletedAt": nil},
+		},
+		{
+			name:   "empty scope",
+			filter: domain.TaskFilter{Scope: &domain.TaskScope{}},
+			query:  bson.M{"projectID": bson.M{"$in": bson.A{nil}}, "deletedAt": nil},
+		},
+		{
+			name:   "all projects",
+			filter: domain.TaskFilter{Scope: &domain.TaskScope{AllProjects: true}},
+			query:  bson.M{"deletedAt": nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			databaseHelper := &mocks.Database{}
+			collectionHelper := &mocks.Collection{}
+
+			mockCursor, err := mongo.NewCursorFromDocuments([]any{domain.Task{Title: title}}, nil, nil)
+			assert.NoError(t, err)
+			collectionHelper.On("Find", mock.Anything, tt.query).Return(mockCursor, nil).Once()
+			databaseHelper.On("Collection", domain.CollectionTask).Return(collectionHelper)
+
+			repo := repository.NewTaskRepository(databaseHelper, domain.CollectionTask)
+			tasks, err := repo.FetchByFilter(context.TODO(), tt.filter)
+
+			assert.NoError(t, err)
+			assert.Len(t, tasks, 1)
+			collectionHelper.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskRepositoryUpdateStatusCompletedAt(t *testing.T) {
+	id := primitive.NewObjectID()
+	now := time.Date(2025, 2, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		status domain.TaskStatus
+		update bson.M
+	}{
+		{
+			status: domain.StatusDone,
+			update: bson.M{"$set": bson.M{"status": domain.StatusDone, "completedAt": now, "updatedAt": now}, "$inc": bson.M{"version": 1}},
+		},
+		{
+			status: domain.StatusTodo,
+			update: bson.M{"$set": bson.M{"status": domain.StatusTodo, "updatedAt": now}, "$unset": bson.M{"completedAt": ""}, "$inc": bson.M{"version": 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			databaseHelper := &mocks.Database{}
+			collectionHelper := &mocks.Collection{}
+			databaseHelper.On("Collection", domain.CollectionTask).Return(collectionHelper)
+
+			result := mongo.NewSingleResultFromDocument(domain.Task{ID: id, Status: tt.status, Version: 2}, nil, nil)
+			collectionHelper.On("FindOneAndUpdate", mock.Anything, bson.M{"_id": id, "deletedAt": nil, "version": int64(1)}, tt.update, mock.Anything).
+				Return(result).Once()
+
+			repo := repository.NewTaskRepositoryWithClock(databaseHelper, domain.CollectionTask, fixedClock(now))
+			task := &domain.Task{ID: id, Status: tt.status, Version: 1}
+
+			assert.NoError(t, repo.UpdateStatus(context.TODO(), task))
+			assert.Equal(t, int64(2), task.Version)
+			collectionHelper.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskRepositoryReminders(t *testing.T) {
+	id := primitive.NewObjectID()
+	now := time.Date(2025, 2, 1, 10, 0, 0, 0, time.UTC)
+
+	newRepository := func() (domain.TaskReminderRepository, *mocks.Collection) {
+		databaseHelper := &mocks.Database{}
+		collectionHelper := &mocks.Collection{}
+		databaseHelper.On("Collection", domain.CollectionTask).Return(collectionHelper)
+		return repository.NewTaskReminderRepositoryWithClock(databaseHelper, domain.CollectionTask, fixedClock(now)), collectionHelper
+	}
+
+	t.Run("fetch due for reminder", func(t *testing.T) {
+		repo, collectionHelper := newRepository()
+		cursor, err := mongo.NewCursorFromDocuments([]any{domain.Task{ID: id, Title: title}}, nil, nil)
+		assert.NoError(t, err)
+		collectionHelper.On("Find", mock.Anything, bson.M{
+			"deletedAt":  nil,
+			"status":     bson.M{"$nin": []domain.TaskStatus{domain.StatusDone, domain.StatusArchived}},
+			"dueDate":    bson.M{"$gte": now, "$lt": now.Add(time.Hour)},
+			"remindedAt": nil,
+		}, mock.Anything).Return(cursor, nil).Once()
+
+		tasks, err := repo.FetchDueForReminder(context.TODO(), time.Hour)
+
+		assert.NoError(t, err)
+		assert.Len(t, tasks, 1)
+		collectionHelper.AssertExpectations(t)
+	})
+
+	t.Run("mark reminded keeps the audit fields", func(t *testing.T) {
+		repo, collectionHelper := newRepository()
+		collectionHelper.On("UpdateMany", mock.Anything, bson.M{"_id": id}, bson.M{"$set": bson.M{"remindedAt": now}}).
+			Return(&mongo.UpdateResult{MatchedCount: 1}, nil).Once()
+
+		assert.NoError(t, repo.MarkReminded(context.TODO(), id.Hex()))
+		collectionHelper.AssertExpectations(t)
+	})
+
+	t.Run("mark reminded not found", func(t *testing.T) {
+		repo, collectionHelper := newRepository()
+		collectionHelper.On("UpdateMany", mock.Anything, bson.M{"_id": id}, mock.Anything).Return(&mongo.UpdateResult{}, nil).Once()
+
+		assert.ErrorIs(t, repo.MarkReminded(context.TODO(), id.Hex()), domain.ErrNotFound)
+	})
+
+	t.Run("update resets the reminder", func(t *testing.T) {
+		databaseHelper := &mocks.Database{}
+		collectionHelper := &mocks.Collection{}
+		databaseHelper.On("Collection", domain.CollectionTask).Return(collectionHelper)
+		repo := repository.NewTaskRepositoryWithClock(databaseHelper, domain.CollectionTask, fixedClock(now))
+		dueDate := now.Add(48 * time.Hour)
+		result := mongo.NewSingleResultFromDocument(domain.Task{ID: id, Title: title, DueDate: &dueDate, Version: 2}, nil, nil)
+		collectionHelper.On("FindOneAndUpdate", mock.Anything, mock.Anything, mock.MatchedBy(func(update bson.M) bool {
+			_, reset := update["$unset"].(bson.M)["remindedAt"]
+			return reset
+		}), mock.Anything).Return(result).Once()
+
+		task := &domain.Task{ID: id, Title: title, DueDate: &dueDate, Version: 1}
+		assert.NoError(t, repo.Update(context.TODO(), task))
+		collectionHelper.AssertExpectations(t)
+	})
+}
+
+func TestTaskRepositoryCreateRecurring(t *testing.T) {
+	due := time.Date(2025, 2, 3, 9, 0, 0, 0, time.UTC)
+
+	newRepository := func(err error) (domain.TaskRepository, *mocks.Collection) {
+		databaseHelper := &mocks.Database{}
+		collectionHelper := &mocks.Collection{}
+		databaseHelper.On("Collection", domain.CollectionTask).Return(collectionHelper)
+		collectionHelper.On("InsertOne", mock.Anything, mock.AnythingOfType("*domain.Task")).Return(primitive.NewObjectID(), err).Once()
+		return repository.NewTaskRepository(databaseHelper, domain.CollectionTask), collectionHelper
+	}
+
+	t.Run("starts a series", func(t *testing.T) {
+		repo, _ := newRepository(nil)
+		task := &domain.Task{Title: title, Recurrence: "FREQ=DAILY", DueDate: &due}
+
+		assert.NoError(t, repo.Create(context.TODO(), task))
+		assert.Equal(t, task.ID, task.SeriesID)
+		assert.Equal(t, &due, task.SeriesStart)
+		assert.Equal(t, 1, task.Occurrence)
+	})
+
+	t.Run("duplicate occurrence", func(t *testing.T) {
+		duplicate := mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000, Message: "duplicate key"}}}
+		repo, _ := newRepository(duplicate)
+		seriesID := primitive.NewObjectID()
+		task := &domain.Task{Title: title, Recurrence: "FREQ=DAILY", DueDate: &due, SeriesID: seriesID, Occurrence: 2}
+
+		assert.ErrorIs(t, repo.Create(context.TODO(), task), domain.ErrDuplicate)
+		assert.Equal(t, seriesID, task.SeriesID)
+	})
+
+	t.Run("existing occurrence in a transaction", func(t *testing.T) {
+		databaseHelper := &mocks.Database{}
+		collectionHelper := &mocks.Collection{}
+		databaseHelper.On("Collection", domain.CollectionTask).Return(collectionHelper)
+		repo := repository.NewTaskRepository(databaseHelper, domain.CollectionTask)
+		seriesID := primitive.NewObjectID()
+		collectionHelper.On("CountDocuments", mock.Anything, bson.M{"seriesID": seriesID, "occurrence": 2}, mock.Anything).Return(int64(1), nil).Once()
+
+		ctx := mongo.NewSessionContext(context.TODO(), struct{ mongo.Session }{})
+		task := &domain.Task{Title: title, Recurrence: "FREQ=DAILY", DueDate: &due, SeriesID: seriesID, Occurrence: 2}
+
+		assert.ErrorIs(t, repo.Create(ctx, task), domain.ErrDuplicate)
+		collectionHelper.AssertNotCalled(t, "InsertOne", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTaskRepositoryHierarchy(t *testing.T) {
+	newRepository := func() (domain.TaskRepository, *mocks.Collection) {
+		databaseHelper := &mocks.Database{}
+		collectionHelper := &mocks.Collection{}
+		databaseHelper.On("Collection", domain.CollectionTask).Return(collectionHelper)
+		return repository.NewTaskRepository(databaseHelper, domain.CollectionTask), collectionHelper
+	}
+	graphLookup := func(startWith string) any {
+		return mock.MatchedBy(func(pipeline bson.A) bool {
+			lookup, ok := pipeline[1].(bson.M)["$graphLookup"].(bson.M)
+			return ok && lookup["startWith"] == startWith && lookup["from"] == domain.CollectionTask
+		})
+	}
+
+	root, child, second, grandchild := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+
+	t.Run("tree", func(t *testing.T) {
+		repo, collectionHelper := newRepository()
+		cursor, err := mongo.NewCursorFromDocuments([]any{bson.M{
+			"_id":   root,
+			"title": "root",
+			"descendants": bson.A{
+				bson.M{"_id": grandchild, "title": "grandchild", "parentID": child},
+				bson.M{"_id": second, "title": "second", "parentID": root},
+				bson.M{"_id": child, "title": "child", "parentID": root},
+			},
+		}}, nil, nil)
+		assert.NoError(t, err)
+		collectionHelper.On("Aggregate", mock.Anything, graphLookup("$_id")).Return(cursor, nil).Once()
+
+		tree, err := repo.FetchTree(context.TODO(), root.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "root", tree.Title)
+		assert.Len(t, tree.Subtasks, 2)
+		assert.Equal(t, "child", tree.Subtasks[0].Title)
+		assert.Equal(t, "second", tree.Subtasks[1].Title)
+		assert.Len(t, tree.Subtasks[0].Subtasks, 1)
+		assert.Equal(t, "grandchild", tree.Subtasks[0].Subtasks[0].Title)
+		assert.Empty(t, tree.Subtasks[1].Subtasks)
+	})
+
+	t.Run("tree not found", func(t *testing.T) {
+		repo, collectionHelper := newRepository()
+		cursor, err := mongo.NewCursorFromDocuments([]any{}, nil, nil)
+		assert.NoError(t, err)
+		collectionHelper.On("Aggregate", mock.Anything, mock.Anything).Return(cursor, nil).Once()
+
+		_, err = repo.FetchTree(context.TODO(), root.Hex())
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("ancestors", func(t *testing.T) {
+		repo, collectionHelper := newRepository()
+		cursor, err := mongo.NewCursorFromDocuments([]any{bson.M{
+			"_id":   grandchild,
+			"chain": bson.A{bson.M{"_id": child}, bson.M{"_id": root}},
+		}}, nil, nil)
+		assert.NoError(t, err)
+		collectionHelper.On("Aggregate", mock.Anything, graphLookup("$parentID")).Return(cursor, nil).Once()
+
+		ids, err := repo.FetchAncestorIDs(context.TODO(), grandchild)
+
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []primitive.ObjectID{child, root}, ids)
+	})
+
+	t.Run("blockers", func(t *testing.T) {
+		repo, collectionHelper := newRepository()
+		cursor, err := mongo.NewCursorFromDocuments([]any{
+			bson.M{"_id": child, "chain": bson.A{bson.M{"_id": root}}},
+			bson.M{"_id": second, "chain": bson.A{bson.M{"_id": root}, bson.M{"_id": child}}},
+		}, nil, nil)
+		assert.NoError(t, err)
+		collectionHelper.On("Aggregate", mock.Anything, graphLookup("$blockedBy")).Return(cursor, nil).Once()
+
+		ids, err := repo.FetchBlockerIDs(context.TODO(), []primitive.ObjectID{child, second})
+
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []primitive.ObjectID{root, child, second}, ids)
+	})
+}
+
+func TestTaskRepositorySearch(t *testing.T) {
+	databaseHelper := &mocks.Database{}
+	collectionHelper := &mocks.Collection{}
+	databaseHelper.On("Collection", domain.CollectionTask).Return(collectionHelper)
+	repo := repository.NewTaskRepository(databaseHelper, domain.CollectionTask)
+
+	project := primitive.NewObjectID()
+	filter := bson.M{"$text": bson.M{"$search": "login bug"}, "deletedAt": nil, "projectID": bson.M{"$in": bson.A{nil, project}}}
+	score := bson.M{"$meta": "textScore"}
+
+	cursor, err := mongo.NewCursorFromDocuments([]any{bson.M{"title": "Fix login", "score": 1.5}}, nil, nil)
+	assert.NoError(t, err)
+	collectionHelper.On("CountDocuments", mock.Anything, filter).Return(int64(11), nil).Once()
+	collectionHelper.On("Find", mock.Anything, filter, mock.MatchedBy(func(opts *options.FindOptions) bool {
+		return assert.ObjectsAreEqual(bson.M{"score": score}, opts.Projection) &&
+			assert.ObjectsAreEqual(bson.D{{Key: "score", Value: score}, {Key: "_id", Value: 1}}, opts.Sort) &&
+			*opts.Skip == 10 && *opts.Limit == 10
+	})).Return(cursor, nil).Once()
+
+	matches, total, err := repo.Search(context.TODO(), domain.TaskScope{ProjectIDs: []primitive.ObjectID{project}}, "login bug", domain.PageRequest{Page: 2, Size: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(11), total)
+	assert.Equal(t, "Fix login", matches[0].Title)
+	assert.Equal(t, 1.5, matches[0].Score)
+	collectionHelper.AssertExpectations(t)
+}
+
+func TestTaskRepositoryEach(t *testing.T) {
+	databaseHelper := &mocks.Database{}
+	collectionHelper := &mocks.Collection{}
+	databaseHelper.On("Collection", domain.CollectionTask).Return(collectionHelper)
+	repo := repository.NewTaskTransferRepository(databaseHelper, domain.CollectionTask)
+
+	t.Run("reads one task at a time", func(t *testing.T) {
+		cursor, err := mongo.NewCursorFromDocuments([]any{bson.M{"title": "First"}, bson.M{"title": "Second"}}, nil, nil)
+		assert.NoError(t, err)
+		collectionHelper.On("Find", mock.Anything, bson.M{"deletedAt": nil}, mock.MatchedBy(func(opts *options.FindOptions) bool {
+			return assert.ObjectsAreEqual(bson.D{{Key: "_id", Value: 1}}, opts.Sort)
+		})).Return(cursor, nil).Once()
+
+		titles := []string{}
+		err = repo.Each(context.TODO(), domain.TaskScope{AllProjects: true}, func(task domain.Task) error {
+			titles = append(titles, task.Title)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"First", "Second"}, titles)
+	})
+
+	t.Run("stops at the first error", func(t *testing.T) {
+		cursor := &mocks.Cursor{}
+		cursor.On("Next", mock.Anything).Return(true).Once()
+		cursor.On("Decode", mock.Anything).Return(nil).Once()
+		cursor.On("Close", mock.Anything).Return(nil).Once()
+		collectionHelper.On("Find", mock.Anything, mock.Anything, mock.Anything).Return(cursor, nil).Once()
+
+		err := repo.Each(context.TODO(), domain.TaskScope{AllProjects: true}, func(domain.Task) error {
+			return assert.AnError
+		})
+
+		assert.ErrorIs(t, err, assert.AnError)
+		cursor.AssertExpectations(t)
+	})
+
+	t.Run("cursor error", func(t *testing.T) {
+		cursor := &mocks.Cursor{}
+		cursor.On("Next", mock.Anything).Return(false).Once()
+		cursor.On("Err").Return(assert.AnError).Once()
+		cursor.On("Close", mock.Anything).Return(nil).Once()
+		collectionHelper.On("Find", mock.Anything, mock.Anything, mock.Anything).Return(cursor, nil).Once()
+
+		err := repo.Each(context.TODO(), domain.TaskScope{AllProjects: true}, func(domain.Task) error { return nil })
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
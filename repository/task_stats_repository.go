@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type taskStatsRepository struct {
+	tasks *Mongo[domain.Task]
+}
+
+func NewTaskStatsRepository(db mongo.Database, collection string) domain.TaskStatsRepository {
+	return &taskStatsRepository{
+		tasks: NewMongo[domain.Task](db, collection),
+	}
+}
+
+// taskStatsFacets is the single document of the stats pipeline, one field per facet.
+type taskStatsFacets struct {
+	Totals []struct {
+		Total     int64 `bson:"total"`
+		Completed int64 `bson:"completed"`
+	} `bson:"totals"`
+	ByStatus []struct {
+		Status domain.TaskStatus `bson:"_id"`
+		Count  int64             `bson:"count"`
+	} `bson:"byStatus"`
+	ByUser  []domain.UserTaskStats `bson:"byUser"`
+	Created []struct {
+		Start time.Time `bson:"_id"`
+		Count int64     `bson:"count"`
+	} `bson:"created"`
+	Completed []struct {
+		Start time.Time `bson:"_id"`
+		Count int64     `bson:"count"`
+		// LeadTime is in milliseconds
+		LeadTime float64 `bson:"leadTime"`
+	} `bson:"completed"`
+}
+
+// Stats needs MongoDB 5.0 for $dateTrunc.
+func (r *taskStatsRepository) Stats(c context.Context, scope domain.TaskScope, query domain.TaskStatsQuery) (domain.TaskStats, error) {
+	var facets []taskStatsFacets
+	if err := r.tasks.Aggregate(c, taskStatsPipeline(scope, query), &facets); err != nil {
+		return domain.TaskStats{}, err
+	}
+
+	stats := domain.TaskStats{
+		From:     query.From,
+		To:       query.To,
+		Interval: query.Interval,
+		ByStatus: map[domain.TaskStatus]int64{},
+		ByUser:   []domain.UserTaskStats{},
+		Periods:  []domain.PeriodTaskStats{},
+	}
+	if len(facets) == 0 {
+		return stats, nil
+	}
+	result := facets[0]
+
+	for _, totals := range result.Totals {
+		stats.Total, stats.Completed = totals.Total, totals.Completed
+	}
+	stats.CompletionRate = domain.CompletionRate(stats.Completed, stats.Total)
+
+	for _, status := range result.ByStatus {
+		stats.ByStatus[status.Status] = status.Count
+	}
+
+	for _, user := range result.ByUser {
+		user.CompletionRate = domain.CompletionRate(user.Completed, user.Total)
+		stats.ByUser = append(stats.ByUser, user)
+	}
+
+	periods := make(map[time.Time]*domain.PeriodTaskStats)
+	period := func(start time.Time) *domain.PeriodTaskStats {
+		start = start.UTC()
+		if periods[start] == nil {
+			periods[start] = &domain.PeriodTaskStats{Start: start}
+		}
+		return periods[start]
+	}
+	for _, created := range result.Created {
+		period(created.Start).Created = created.Count
+	}
+	for _, completed := range result.Completed {
+		p := period(completed.Start)
+		p.Completed = completed.Count
+		p.AvgLeadTimeHours = completed.LeadTime / float64(time.Hour/time.Millisecond)
+	}
+	for _, p := range periods {
+		stats.Periods = append(stats.Periods, *p)
+	}
+	sort.Slice(stats.Periods, func(i, j int) bool {
+		return stats.Periods[i].Start.Before(stats.Periods[j].Start)
+	})
+
+	return stats, nil
+}
+
+// taskStatsPipeline matches the live tasks of scope created or completed in the range once, then a $facet
+// narrows them down for each part of the report.
+func taskStatsPipeline(scope domain.TaskScope, query domain.TaskStatsQuery) bson.A {
+	inRange := bson.M{"$gte": query.From, "$lt": query.To}
+	created := bson.M{"$match": bson.M{"createdAt": inRange}}
+	// Tasks created before statuses existed read as todo
+	status := bson.M{"$ifNull": bson.A{"$status", domain.StatusTodo}}
+	done := bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{status, domain.StatusDone}}, 1, 0}}
+
+	timeZone := query.TimeZone
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+	periodOf := func(field string) bson.M {
+		return bson.M{"$dateTrunc": bson.M{
+			"date":        field,
+			"unit":        string(query.Interval),
+			"timezone":    timeZone,
+			"startOfWeek": "monday",
+		}}
+	}
+
+	return bson.A{
+		bson.M{"$match": scoped(bson.M{
+			"deletedAt": nil,
+			"$or":       bson.A{bson.M{"createdAt": inRange}, bson.M{"completedAt": inRange}},
+		}, scope)},
+		bson.M{"$facet": bson.M{
+			"totals": bson.A{
+				created,
+				bson.M{"$group": bson.M{"_id": nil, "total": bson.M{"$sum": 1}, "completed": bson.M{"$sum": done}}},
+			},
+			"byStatus": bson.A{
+				created,
+				bson.M{"$group": bson.M{"_id": status, "count": bson.M{"$sum": 1}}},
+			},
+			"byUser": bson.A{
+				created,
+				bson.M{"$group": bson.M{
+					"_id":       bson.M{"$ifNull": bson.A{"$createdBy", ""}},
+					"total":     bson.M{"$sum": 1},
+					"completed": bson.M{"$sum": done},
+				}},
+				bson.M{"$sort": bson.D{{Key: "total", Value: -1}, {Key: "_id", Value: 1}}},
+			},
+			"created": bson.A{
+				created,
+				bson.M{"$group": bson.M{"_id": periodOf("$createdAt"), "count": bson.M{"$sum": 1}}},
+			},
+			// The completion time is cleared when a task is reopened, so it only exists on done tasks
+			"completed": bson.A{
+				bson.M{"$match": bson.M{"completedAt": inRange}},
+				bson.M{"$group": bson.M{
+					"_id":      periodOf("$completedAt"),
+					"count":    bson.M{"$sum": 1},
+					"leadTime": bson.M{"$avg": bson.M{"$subtract": bson.A{"$completedAt", "$createdAt"}}},
+				}},
+			},
+		}},
+	}
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTaskStatsRepository struct {
+	mock.Mock
+}
+
+func (_m *MockTaskStatsRepository) Stats(c context.Context, scope domain.TaskScope, query domain.TaskStatsQuery) (domain.TaskStats, error) {
+	ret := _m.Called(c, scope, query)
+	return ret.Get(0).(domain.TaskStats), ret.Error(1)
+}
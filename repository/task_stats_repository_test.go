@@ -0,0 +1,109 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo/mocks"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestTaskStatsRepository(t *testing.T) {
+	from := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 14)
+	query := domain.TaskStatsQuery{From: from, To: to, Interval: domain.IntervalWeek, TimeZone: "Asia/Bangkok"}
+	all := domain.TaskScope{AllProjects: true}
+
+	newRepository := func(cursor *mocks.Cursor, pipeline any) domain.TaskStatsRepository {
+		databaseHelper := &mocks.Database{}
+		collectionHelper := &mocks.Collection{}
+		databaseHelper.On("Collection", domain.CollectionTask).Return(collectionHelper)
+		collectionHelper.On("Aggregate", mock.Anything, pipeline).Return(cursor, nil).Once()
+		return repository.NewTaskStatsRepository(databaseHelper, domain.CollectionTask)
+	}
+
+	t.Run("pipeline", func(t *testing.T) {
+		cursor := &mocks.Cursor{}
+		cursor.On("All", mock.Anything, mock.Anything).Return(nil).Once()
+		cursor.On("Close", mock.Anything).Return(nil).Once()
+
+		pipeline := mock.MatchedBy(func(pipeline bson.A) bool {
+			inRange := bson.M{"$gte": from, "$lt": to}
+			match := bson.M{
+				"deletedAt": nil,
+				"$or":       bson.A{bson.M{"createdAt": inRange}, bson.M{"completedAt": inRange}},
+				"projectID": bson.M{"$in": bson.A{nil}},
+			}
+			if !assert.ObjectsAreEqual(bson.M{"$match": match}, pipeline[0]) {
+				return false
+			}
+
+			facets := pipeline[1].(bson.M)["$facet"].(bson.M)
+			created := facets["created"].(bson.A)[1].(bson.M)["$group"].(bson.M)["_id"].(bson.M)["$dateTrunc"].(bson.M)
+			return len(facets) == 5 &&
+				created["date"] == "$createdAt" && created["unit"] == "week" &&
+				created["timezone"] == "Asia/Bangkok" && created["startOfWeek"] == "monday"
+		})
+
+		stats, err := newRepository(cursor, pipeline).Stats(context.TODO(), domain.TaskScope{}, query)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), stats.Total)
+		assert.Empty(t, stats.Periods)
+		cursor.AssertExpectations(t)
+	})
+
+	t.Run("report", func(t *testing.T) {
+		second := from.AddDate(0, 0, 7)
+		result, err := mongo.NewCursorFromDocuments([]any{bson.M{
+			"totals":   bson.A{bson.M{"total": 4, "completed": 1}},
+			"byStatus": bson.A{bson.M{"_id": "todo", "count": 3}, bson.M{"_id": "done", "count": 1}},
+			"byUser":   bson.A{bson.M{"_id": "user-1", "total": 3, "completed": 1}, bson.M{"_id": "", "total": 1, "completed": 0}},
+			"created":  bson.A{bson.M{"_id": second, "count": 1}, bson.M{"_id": from, "count": 3}},
+			"completed": bson.A{bson.M{
+				"_id":      second,
+				"count":    2,
+				"leadTime": float64(36 * time.Hour / time.Millisecond),
+			}},
+		}}, nil, nil)
+		assert.NoError(t, err)
+
+		cursor := &mocks.Cursor{}
+		cursor.On("All", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			assert.NoError(t, result.All(context.TODO(), args.Get(1)))
+		}).Return(nil).Once()
+		cursor.On("Close", mock.Anything).Return(nil).Once()
+
+		stats, err := newRepository(cursor, mock.Anything).Stats(context.TODO(), all, query)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), stats.Total)
+		assert.Equal(t, 0.25, stats.CompletionRate)
+		assert.Equal(t, map[domain.TaskStatus]int64{domain.StatusTodo: 3, domain.StatusDone: 1}, stats.ByStatus)
+		assert.Equal(t, []domain.UserTaskStats{
+			{User: "user-1", Total: 3, Completed: 1, CompletionRate: 1.0 / 3},
+			{User: "", Total: 1},
+		}, stats.ByUser)
+		assert.Equal(t, []domain.PeriodTaskStats{
+			{Start: from, Created: 3},
+			{Start: second, Created: 1, Completed: 2, AvgLeadTimeHours: 36},
+		}, stats.Periods)
+	})
+
+	t.Run("cursor error", func(t *testing.T) {
+		cursor := &mocks.Cursor{}
+		cursor.On("All", mock.Anything, mock.Anything).Return(errors.New("cursor failed")).Once()
+		cursor.On("Close", mock.Anything).Return(nil).Once()
+
+		_, err := newRepository(cursor, mock.Anything).Stats(context.TODO(), all, query)
+
+		assert.EqualError(t, err, "cursor failed")
+	})
+}
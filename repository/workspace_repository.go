@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type workspaceRepository struct {
+	workspaces *Mongo[domain.Workspace]
+}
+
+func WorkspaceIndexes() []mongo.Index {
+	return []mongo.Index{
+		{Name: "members.userID_1_name_1", Keys: bson.D{{Key: "members.userID", Value: 1}, {Key: "name", Value: 1}}},
+	}
+}
+
+func NewWorkspaceRepository(db mongo.Database, collection string) domain.WorkspaceRepository {
+	return NewWorkspaceRepositoryWithClock(db, collection, domain.SystemClock{})
+}
+
+func NewWorkspaceRepositoryWithClock(db mongo.Database, collection string, clock domain.Clock) domain.WorkspaceRepository {
+	return &workspaceRepository{
+		workspaces: NewMongo[domain.Workspace](db, collection).Use(AuditHook{Clock: clock}),
+	}
+}
+
+func (r *workspaceRepository) Create(c context.Context, workspace *domain.Workspace) error {
+	workspace.ID = primitive.NewObjectID()
+	workspace.Version = 1
+	return r.workspaces.Insert(c, workspace)
+}
+
+func (r *workspaceRepository) FetchByID(c context.Context, workspaceID string) (domain.Workspace, error) {
+	return r.workspaces.FindByID(c, workspaceID)
+}
+
+func (r *workspaceRepository) FetchByMember(c context.Context, userID string) ([]domain.Workspace, error) {
+	return r.workspaces.FindMany(c, bson.M{"members.userID": userID}, FindOptions{
+		Sort: bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}},
+	})
+}
+
+func (r *workspaceRepository) Update(c context.Context, workspace *domain.Workspace) error {
+	updated, err := r.workspaces.UpdateVersion(c, bson.M{"_id": workspace.ID}, workspace.Version, bson.M{
+		"$set": bson.M{"name": workspace.Name, "members": workspace.Members},
+	})
+	if err != nil {
+		return err
+	}
+
+	*workspace = updated
+	return nil
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWorkspaceRepository struct {
+	mock.Mock
+}
+
+func (_m *MockWorkspaceRepository) Create(c context.Context, workspace *domain.Workspace) error {
+	ret := _m.Called(c, workspace)
+	return ret.Error(0)
+}
+
+func (_m *MockWorkspaceRepository) FetchByID(c context.Context, workspaceID string) (domain.Workspace, error) {
+	ret := _m.Called(c, workspaceID)
+	return ret.Get(0).(domain.Workspace), ret.Error(1)
+}
+
+func (_m *MockWorkspaceRepository) FetchByMember(c context.Context, userID string) ([]domain.Workspace, error) {
+	ret := _m.Called(c, userID)
+
+	var r0 []domain.Workspace
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]domain.Workspace)
+	}
+
+	return r0, ret.Error(1)
+}
+
+func (_m *MockWorkspaceRepository) Update(c context.Context, workspace *domain.Workspace) error {
+	ret := _m.Called(c, workspace)
+	return ret.Error(0)
+}
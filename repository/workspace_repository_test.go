@@ -0,0 +1,66 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo/mocks"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestWorkspaceRepositoryCreate(t *testing.T) {
+	databaseHelper := &mocks.Database{}
+	collectionHelper := &mocks.Collection{}
+	databaseHelper.On("Collection", domain.CollectionWorkspace).Return(collectionHelper)
+	repo := repository.NewWorkspaceRepositoryWithClock(databaseHelper, domain.CollectionWorkspace, fixedClock(auditTime))
+	collectionHelper.On("InsertOne", mock.Anything, mock.AnythingOfType("*domain.Workspace")).Return(primitive.NewObjectID(), nil).Once()
+
+	workspace := &domain.Workspace{Name: "Acme", Members: []domain.Member{{UserID: "user-1", Role: domain.RoleOwner}}}
+	err := repo.Create(domain.WithActor(context.TODO(), "user-1"), workspace)
+
+	assert.NoError(t, err)
+	assert.False(t, workspace.ID.IsZero())
+	assert.Equal(t, int64(1), workspace.Version)
+	assert.Equal(t, auditTime, workspace.CreatedAt)
+}
+
+func TestWorkspaceRepositoryFetchByMember(t *testing.T) {
+	databaseHelper := &mocks.Database{}
+	collectionHelper := &mocks.Collection{}
+	databaseHelper.On("Collection", domain.CollectionWorkspace).Return(collectionHelper)
+	repo := repository.NewWorkspaceRepositoryWithClock(databaseHelper, domain.CollectionWorkspace, fixedClock(auditTime))
+	cursor, err := mongo.NewCursorFromDocuments([]any{domain.Workspace{Name: "Acme"}}, nil, nil)
+	assert.NoError(t, err)
+	collectionHelper.On("Find", mock.Anything, bson.M{"members.userID": "user-1"}, mock.Anything).Return(cursor, nil).Once()
+
+	workspaces, err := repo.FetchByMember(context.TODO(), "user-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Acme", workspaces[0].Name)
+	collectionHelper.AssertExpectations(t)
+}
+
+func TestWorkspaceRepositoryUpdate(t *testing.T) {
+	databaseHelper := &mocks.Database{}
+	collectionHelper := &mocks.Collection{}
+	databaseHelper.On("Collection", domain.CollectionWorkspace).Return(collectionHelper)
+	repo := repository.NewWorkspaceRepositoryWithClock(databaseHelper, domain.CollectionWorkspace, fixedClock(auditTime))
+	id := primitive.NewObjectID()
+	members := []domain.Member{{UserID: "user-1", Role: domain.RoleOwner}, {UserID: "user-2", Role: domain.RoleMember}}
+
+	result := mongo.NewSingleResultFromDocument(domain.Workspace{ID: id, Name: "Acme", Members: members, Version: 3}, nil, nil)
+	collectionHelper.On("FindOneAndUpdate", mock.Anything, bson.M{"_id": id, "version": int64(2)}, mock.MatchedBy(func(update bson.M) bool {
+		return assert.ObjectsAreEqual(members, update["$set"].(bson.M)["members"])
+	}), mock.Anything).Return(result).Once()
+
+	workspace := &domain.Workspace{ID: id, Name: "Acme", Members: members, Version: 2}
+	assert.NoError(t, repo.Update(context.TODO(), workspace))
+	assert.Equal(t, int64(3), workspace.Version)
+	collectionHelper.AssertExpectations(t)
+}
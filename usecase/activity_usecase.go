@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// taskHistory appends the activities of changes to the history, a nil repository records nothing.
+type taskHistory struct {
+	repository domain.TaskActivityRepository
+}
+
+// record runs in the unit of work of the change, c carries it: the change is not saved when its history
+// cannot be written.
+func (h taskHistory) record(c context.Context, activities ...domain.TaskActivity) error {
+	if h.repository == nil || len(activities) == 0 {
+		return nil
+	}
+	return h.repository.Append(c, activities...)
+}
+
+func (h taskHistory) enabled() bool {
+	return h.repository != nil
+}
+
+func created(task domain.Task) domain.TaskActivity {
+	return domain.TaskActivity{
+		TaskID:  task.ID,
+		Action:  domain.ActivityCreated,
+		Version: task.Version,
+		Changes: domain.TaskChanges(domain.Task{}, task),
+	}
+}
+
+func changed(action domain.ActivityAction, before, after domain.Task) domain.TaskActivity {
+	return domain.TaskActivity{
+		TaskID:  after.ID,
+		Action:  action,
+		Version: after.Version,
+		Changes: domain.TaskChanges(before, after),
+	}
+}
+
+// removed is the activity of a delete or restore, taskID was already accepted by the repository.
+func removed(action domain.ActivityAction, taskID string) domain.TaskActivity {
+	id, _ := primitive.ObjectIDFromHex(taskID)
+	return domain.TaskActivity{TaskID: id, Action: action}
+}
+
+// edited returns before with the fields an update rewrites taken from task.
+func edited(before, task domain.Task) domain.Task {
+	after := before
+	after.Title, after.Description = task.Title, task.Description
+	after.DueDate, after.Priority = task.DueDate, task.Priority
+	after.Recurrence, after.TimeZone = task.Recurrence, task.TimeZone
+	after.ParentID, after.BlockedBy = task.ParentID, task.BlockedBy
+	after.ProjectID = task.ProjectID
+	return after
+}
+
+type activityUsecase struct {
+	activityRepository domain.TaskActivityRepository
+	taskRepository     domain.TaskRepository
+	projects           projectAccess
+	contextTimeout     time.Duration
+}
+
+// ActivityDeps are the collaborators of the activity usecase, Projects and Workspaces may be nil.
+type ActivityDeps struct {
+	Activities domain.TaskActivityRepository
+	Tasks      domain.TaskRepository
+	// Projects and Workspaces keep the history of a task to the members of the workspace of its
+	// project, nil leaves projects unchecked.
+	Projects   domain.ProjectRepository
+	Workspaces domain.WorkspaceRepository
+}
+
+func NewActivityUsecase(activityRepository domain.TaskActivityRepository, taskRepository domain.TaskRepository, timeout time.Duration) domain.TaskActivityUsecase {
+	return NewActivityUsecaseWithDeps(ActivityDeps{Activities: activityRepository, Tasks: taskRepository}, timeout)
+}
+
+func NewActivityUsecaseWithDeps(deps ActivityDeps, timeout time.Duration) domain.TaskActivityUsecase {
+	return &activityUsecase{
+		activityRepository: deps.Activities,
+		taskRepository:     deps.Tasks,
+		projects:           projectAccess{projects: deps.Projects, workspaces: deps.Workspaces},
+		contextTimeout:     timeout,
+	}
+}
+
+// FetchByTaskID keeps the history of a deleted task readable, ErrNotFound is reported only for a task
+// that has no history and is not live. With projects the task is read first, live or deleted, to check
+// its project: the history of a purged task is no longer readable.
+func (u *activityUsecase) FetchByTaskID(c context.Context, taskID string, page domain.PageRequest) (domain.Page[domain.TaskActivity], error) {
+	page = page.Normalize()
+	result := domain.Page[domain.TaskActivity]{Items: []domain.TaskActivity{}, Page: page.Page, Size: page.Size}
+
+	id, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return result, fmt.Errorf("%w: %s", domain.ErrInvalidID, taskID)
+	}
+
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	if u.projects.enabled() {
+		if err := u.readable(ctx, taskID); err != nil {
+			return result, err
+		}
+	}
+
+	activities, total, err := u.activityRepository.FetchByTaskID(ctx, id, page)
+	if err != nil {
+		return result, err
+	}
+	if total == 0 && !u.projects.enabled() {
+		if _, err := u.taskRepository.FetchByTaskID(ctx, taskID); err != nil {
+			return result, err
+		}
+	}
+
+	if activities != nil {
+		result.Items = activities
+	}
+	result.Total = total
+	return result, nil
+}
+
+// readable checks that the actor of c may read the live or deleted task.
+func (u *activityUsecase) readable(c context.Context, taskID string) error {
+	task, err := u.taskRepository.FetchByTaskID(c, taskID)
+	if errors.Is(err, domain.ErrNotFound) {
+		task, err = u.taskRepository.FetchDeletedByTaskID(c, taskID)
+	}
+	if err != nil {
+		return err
+	}
+	return u.projects.readable(c, task)
+}
@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockActivityUsecase is a mock for the TaskActivityUsecase interface
+type MockActivityUsecase struct {
+	mock.Mock
+}
+
+func (m *MockActivityUsecase) FetchByTaskID(c context.Context, taskID string, page domain.PageRequest) (domain.Page[domain.TaskActivity], error) {
+	args := m.Called(c, taskID, page)
+	return args.Get(0).(domain.Page[domain.TaskActivity]), args.Error(1)
+}
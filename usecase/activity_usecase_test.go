@@ -0,0 +1,160 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo/mocks"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestActivityFetchByTaskID(t *testing.T) {
+	taskObjectID := primitive.NewObjectID()
+	taskID := taskObjectID.Hex()
+	page := domain.PageRequest{Page: 1, Size: 20}
+
+	t.Run("success", func(t *testing.T) {
+		activities := new(repository.MockActivityRepository)
+		tasks := new(repository.MockTaskRepository)
+		activities.On("FetchByTaskID", mock.Anything, taskObjectID, page).
+			Return([]domain.TaskActivity{{TaskID: taskObjectID, Action: domain.ActivityDeleted}}, int64(1), nil).Once()
+
+		result, err := usecase.NewActivityUsecase(activities, tasks, time.Second*2).FetchByTaskID(context.Background(), taskID, domain.PageRequest{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), result.Total)
+		assert.Equal(t, domain.ActivityDeleted, result.Items[0].Action)
+		tasks.AssertNotCalled(t, "FetchByTaskID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown task", func(t *testing.T) {
+		activities := new(repository.MockActivityRepository)
+		tasks := new(repository.MockTaskRepository)
+		activities.On("FetchByTaskID", mock.Anything, taskObjectID, page).Return(nil, int64(0), nil).Once()
+		tasks.On("FetchByTaskID", mock.Anything, taskID).Return(domain.Task{}, domain.ErrNotFound).Once()
+
+		_, err := usecase.NewActivityUsecase(activities, tasks, time.Second*2).FetchByTaskID(context.Background(), taskID, page)
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		activities := new(repository.MockActivityRepository)
+
+		_, err := usecase.NewActivityUsecase(activities, nil, time.Second*2).FetchByTaskID(context.Background(), "bad", page)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidID)
+		activities.AssertNotCalled(t, "FetchByTaskID", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestTaskHistory(t *testing.T) {
+	taskObjectID := primitive.NewObjectID()
+	taskID := taskObjectID.Hex()
+
+	t.Run("update records the changed fields", func(t *testing.T) {
+		tasks := new(repository.MockTaskRepository)
+		history := new(repository.MockActivityRepository)
+		ctx := domain.WithActor(context.Background(), "user-1")
+
+		tasks.On("FetchByTaskID", mock.Anything, taskID).Return(domain.Task{ID: taskObjectID, Title: "Draft", Version: 3}, nil).Once()
+		tasks.On("Update", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.Task).Version = 4
+		}).Return(nil).Once()
+		history.On("Append", mock.MatchedBy(func(c context.Context) bool {
+			return domain.ActorFromContext(c) == "user-1"
+		}), []domain.TaskActivity{{
+			TaskID:  taskObjectID,
+			Action:  domain.ActivityUpdated,
+			Version: 4,
+			Changes: []domain.FieldChange{{Field: "title", From: "Draft", To: "Final"}},
+		}}).Return(nil).Once()
+
+		u := usecase.NewTaskUsecaseWithDeps(usecase.TaskDeps{Tasks: tasks, History: history}, time.Second*2)
+		err := u.Update(ctx, &domain.Task{ID: taskObjectID, Title: "Final", Version: 3})
+
+		assert.NoError(t, err)
+		history.AssertExpectations(t)
+	})
+
+	t.Run("transition fails when the change cannot be recorded", func(t *testing.T) {
+		tasks := new(repository.MockTaskRepository)
+		history := new(repository.MockActivityRepository)
+		publisher := new(mockPublisher)
+
+		tasks.On("FetchByTaskID", mock.Anything, taskID).Return(domain.Task{ID: taskObjectID, Version: 1}, nil).Once()
+		tasks.On("UpdateStatus", mock.Anything, mock.Anything).Return(nil).Once()
+		history.On("Append", mock.Anything, mock.MatchedBy(func(activities []domain.TaskActivity) bool {
+			return len(activities) == 1 && activities[0].Action == domain.ActivityStatusChanged &&
+				activities[0].Changes[0] == domain.FieldChange{Field: "status", To: "in_progress"}
+		})).Return(errors.New("write failed")).Once()
+
+		u := usecase.NewTaskUsecaseWithDeps(usecase.TaskDeps{Tasks: tasks, Publisher: publisher, History: history}, time.Second*2)
+		_, err := u.Transition(context.Background(), taskID, domain.StatusInProgress)
+
+		assert.EqualError(t, err, "write failed")
+		publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		tasks := new(repository.MockTaskRepository)
+		history := new(repository.MockActivityRepository)
+		tasks.On("Delete", mock.Anything, taskID).Return(nil).Once()
+		history.On("Append", mock.Anything, []domain.TaskActivity{{TaskID: taskObjectID, Action: domain.ActivityDeleted}}).Return(nil).Once()
+
+		err := usecase.NewTaskUsecaseWithDeps(usecase.TaskDeps{Tasks: tasks, History: history}, time.Second*2).Delete(context.Background(), taskID)
+
+		assert.NoError(t, err)
+		history.AssertExpectations(t)
+	})
+
+	t.Run("bulk diffs updates of one task in order", func(t *testing.T) {
+		tasks := new(repository.MockTaskRepository)
+		history := new(repository.MockActivityRepository)
+		tasks.On("FetchByIDs", mock.Anything, mock.Anything).Return([]domain.Task{{ID: taskObjectID, Title: "A", Version: 1}}, nil).Once()
+		tasks.On("BulkWrite", mock.Anything, mock.Anything, mock.Anything).Return([]domain.BulkResult{
+			{Action: domain.BulkUpdate, ID: taskObjectID, Version: 2},
+			{Action: domain.BulkUpdate, ID: taskObjectID, Version: 3},
+			{Action: domain.BulkDelete, ID: taskObjectID, Err: domain.ErrNotFound},
+		}, nil).Once()
+		history.On("Append", mock.Anything, []domain.TaskActivity{
+			{TaskID: taskObjectID, Action: domain.ActivityUpdated, Version: 2, Changes: []domain.FieldChange{{Field: "title", From: "A", To: "B"}}},
+			{TaskID: taskObjectID, Action: domain.ActivityUpdated, Version: 3, Changes: []domain.FieldChange{{Field: "title", From: "B", To: "C"}}},
+		}).Return(nil).Once()
+
+		u := usecase.NewTaskBulkUsecaseWithDeps(usecase.TaskBulkDeps{Tasks: tasks, Bulk: tasks, History: history}, 0, time.Second*2)
+		_, err := u.Bulk(context.Background(), []domain.BulkOperation{
+			{Action: domain.BulkUpdate, ID: taskID, Task: &domain.Task{Title: "B", Version: 1}},
+			{Action: domain.BulkUpdate, ID: taskID, Task: &domain.Task{Title: "C", Version: 2}},
+			{Action: domain.BulkDelete, ID: taskID},
+		}, domain.BulkOptions{})
+
+		assert.NoError(t, err)
+		history.AssertExpectations(t)
+	})
+
+	t.Run("bulk fails in the unit of work of its history", func(t *testing.T) {
+		tasks := new(repository.MockTaskRepository)
+		history := new(repository.MockActivityRepository)
+		transactions := mocks.NewUnitOfWork(t)
+		tasks.On("BulkWrite", mock.Anything, mock.Anything, mock.Anything).Return([]domain.BulkResult{
+			{Action: domain.BulkDelete, ID: taskObjectID},
+		}, nil).Once()
+		history.On("Append", mock.Anything, mock.Anything).Return(errors.New("write failed")).Once()
+		transactions.On("WithTransaction", mock.Anything, mock.Anything).Return(nil).Once()
+
+		u := usecase.NewTaskBulkUsecaseWithDeps(usecase.TaskBulkDeps{Tasks: tasks, Bulk: tasks, History: history, Transactions: transactions}, 0, time.Second*2)
+		results, err := u.Bulk(context.Background(), []domain.BulkOperation{{Action: domain.BulkDelete, ID: taskID}}, domain.BulkOptions{})
+
+		assert.EqualError(t, err, "write failed")
+		assert.Nil(t, results)
+		tasks.AssertExpectations(t)
+	})
+}
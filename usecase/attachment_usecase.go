@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+)
+
+// sniffLength is the number of bytes http.DetectContentType looks at.
+const sniffLength = 512
+
+type attachmentUsecase struct {
+	attachmentRepository domain.AttachmentRepository
+	taskRepository       domain.TaskRepository
+	projects             projectAccess
+	policy               domain.AttachmentPolicy
+	contextTimeout       time.Duration
+}
+
+// AttachmentDeps are the collaborators of the attachment usecase, Projects and Workspaces may be nil.
+type AttachmentDeps struct {
+	Attachments domain.AttachmentRepository
+	Tasks       domain.TaskRepository
+	// Projects and Workspaces keep the attachments of a task to the members of the workspace of its
+	// project, nil leaves projects unchecked.
+	Projects   domain.ProjectRepository
+	Workspaces domain.WorkspaceRepository
+}
+
+// NewAttachmentUsecase bounds every call by timeout, which includes storing the content of an upload.
+func NewAttachmentUsecase(attachmentRepository domain.AttachmentRepository, taskRepository domain.TaskRepository, policy domain.AttachmentPolicy, timeout time.Duration) domain.AttachmentUsecase {
+	return NewAttachmentUsecaseWithDeps(AttachmentDeps{Attachments: attachmentRepository, Tasks: taskRepository}, policy, timeout)
+}
+
+func NewAttachmentUsecaseWithDeps(deps AttachmentDeps, policy domain.AttachmentPolicy, timeout time.Duration) domain.AttachmentUsecase {
+	return &attachmentUsecase{
+		attachmentRepository: deps.Attachments,
+		taskRepository:       deps.Tasks,
+		projects:             projectAccess{projects: deps.Projects, workspaces: deps.Workspaces},
+		policy:               policy,
+		contextTimeout:       timeout,
+	}
+}
+
+// Upload trusts neither the declared size nor a generic content type: the content is cut off past the
+// size limit and the content type of application/octet-stream uploads is detected from the content.
+func (u *attachmentUsecase) Upload(c context.Context, taskID string, attachment *domain.Attachment, content io.Reader) error {
+	actor := domain.ActorFromContext(c)
+	if actor == "" {
+		return domain.ErrUnauthenticated
+	}
+
+	// Browsers may send the full client path of the file
+	filename := path.Base(strings.ReplaceAll(strings.TrimSpace(attachment.Filename), `\`, "/"))
+	if filename == "." || filename == "/" {
+		return &domain.ValidationError{Field: "filename", Message: "must not be empty"}
+	}
+	if len(filename) > domain.MaxAttachmentFilename {
+		return &domain.ValidationError{Field: "filename", Message: fmt.Sprintf("must be at most %d bytes", domain.MaxAttachmentFilename)}
+	}
+	if attachment.Size > u.policy.MaxSize {
+		return fmt.Errorf("%w: attachments are limited to %d bytes", domain.ErrTooLarge, u.policy.MaxSize)
+	}
+
+	reader := bufio.NewReaderSize(content, sniffLength)
+	contentType := attachment.ContentType
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType == "" || mediaType == "application/octet-stream" {
+		head, _ := reader.Peek(sniffLength)
+		contentType = http.DetectContentType(head)
+	}
+	if !u.policy.Allows(contentType) {
+		return fmt.Errorf("%w: %s", domain.ErrUnsupportedMediaType, contentType)
+	}
+
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	task, err := u.projects.fetchTask(ctx, u.taskRepository, taskID)
+	if err != nil {
+		return err
+	}
+
+	attachment.Filename = filename
+	attachment.AttachmentMetadata = domain.AttachmentMetadata{
+		TaskID:      task.ID,
+		ContentType: contentType,
+		UploadedBy:  actor,
+	}
+	return u.attachmentRepository.Upload(ctx, attachment, &sizeLimitedReader{r: reader, n: u.policy.MaxSize})
+}
+
+func (u *attachmentUsecase) FetchByTaskID(c context.Context, taskID string) ([]domain.Attachment, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	task, err := u.projects.fetchTask(ctx, u.taskRepository, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.attachmentRepository.FetchByTaskID(ctx, task.ID)
+}
+
+func (u *attachmentUsecase) Open(c context.Context, taskID, attachmentID string) (domain.Attachment, domain.AttachmentContent, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	attachment, err := u.fetch(ctx, taskID, attachmentID)
+	if err != nil {
+		return attachment, nil, err
+	}
+
+	content, err := u.attachmentRepository.Open(ctx, attachment.ID)
+	if err != nil {
+		return attachment, nil, err
+	}
+	return attachment, content, nil
+}
+
+func (u *attachmentUsecase) Delete(c context.Context, taskID, attachmentID string) error {
+	actor := domain.ActorFromContext(c)
+	if actor == "" {
+		return domain.ErrUnauthenticated
+	}
+
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	attachment, err := u.fetch(ctx, taskID, attachmentID)
+	if err != nil {
+		return err
+	}
+	if attachment.UploadedBy != actor {
+		return domain.ErrForbidden
+	}
+
+	return u.attachmentRepository.Delete(ctx, attachment.ID)
+}
+
+func (u *attachmentUsecase) fetch(c context.Context, taskID, attachmentID string) (domain.Attachment, error) {
+	task, err := u.projects.fetchTask(c, u.taskRepository, taskID)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+
+	return u.attachmentRepository.FetchByID(c, task.ID, attachmentID)
+}
+
+// sizeLimitedReader fails with ErrTooLarge once more than n bytes are read, so that an upload
+// over the limit is aborted instead of stored truncated.
+type sizeLimitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *sizeLimitedReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+	n, err := l.r.Read(p)
+	if l.n -= int64(n); l.n < 0 {
+		return 0, domain.ErrTooLarge
+	}
+	return n, err
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"io"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAttachmentUsecase is a mock for the AttachmentUsecase interface
+type MockAttachmentUsecase struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentUsecase) Upload(c context.Context, taskID string, attachment *domain.Attachment, content io.Reader) error {
+	args := m.Called(c, taskID, attachment, content)
+	return args.Error(0)
+}
+
+func (m *MockAttachmentUsecase) FetchByTaskID(c context.Context, taskID string) ([]domain.Attachment, error) {
+	args := m.Called(c, taskID)
+	return args.Get(0).([]domain.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentUsecase) Open(c context.Context, taskID, attachmentID string) (domain.Attachment, domain.AttachmentContent, error) {
+	args := m.Called(c, taskID, attachmentID)
+
+	var content domain.AttachmentContent
+	if args.Get(1) != nil {
+		content = args.Get(1).(domain.AttachmentContent)
+	}
+	return args.Get(0).(domain.Attachment), content, args.Error(2)
+}
+
+func (m *MockAttachmentUsecase) Delete(c context.Context, taskID, attachmentID string) error {
+	args := m.Called(c, taskID, attachmentID)
+	return args.Error(0)
+}
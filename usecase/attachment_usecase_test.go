@@ -0,0 +1,150 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var pngHeader = []byte("\x89PNG\x0D\x0A\x1A\x0A")
+
+func TestAttachmentUpload(t *testing.T) {
+	taskObjectID := primitive.NewObjectID()
+	taskID := taskObjectID.Hex()
+	actor := domain.WithActor(context.Background(), "user-1")
+	policy := domain.AttachmentPolicy{MaxSize: 16, ContentTypes: []string{"image/png", "text/plain"}}
+
+	newUsecase := func() (domain.AttachmentUsecase, *repository.MockAttachmentRepository, *repository.MockTaskRepository) {
+		attachments := new(repository.MockAttachmentRepository)
+		tasks := new(repository.MockTaskRepository)
+		tasks.On("FetchByTaskID", mock.Anything, taskID).Return(domain.Task{ID: taskObjectID}, nil).Maybe()
+		return usecase.NewAttachmentUsecase(attachments, tasks, policy, time.Second*2), attachments, tasks
+	}
+
+	t.Run("success", func(t *testing.T) {
+		u, attachments, _ := newUsecase()
+		attachments.On("Upload", mock.Anything, mock.MatchedBy(func(attachment *domain.Attachment) bool {
+			return attachment.Filename == "notes.txt" && attachment.TaskID == taskObjectID &&
+				attachment.UploadedBy == "user-1" && attachment.ContentType == "text/plain; charset=utf-8"
+		}), mock.Anything).Run(func(args mock.Arguments) {
+			data, err := io.ReadAll(args.Get(2).(io.Reader))
+			assert.NoError(t, err)
+			assert.Equal(t, "hello", string(data))
+		}).Return(nil).Once()
+
+		attachment := &domain.Attachment{Filename: `C:\Users\me\notes.txt`, Size: 5}
+		attachment.ContentType = "text/plain; charset=utf-8"
+		err := u.Upload(actor, taskID, attachment, strings.NewReader("hello"))
+
+		assert.NoError(t, err)
+		attachments.AssertExpectations(t)
+	})
+
+	t.Run("detects octet-stream content", func(t *testing.T) {
+		u, attachments, _ := newUsecase()
+		attachments.On("Upload", mock.Anything, mock.MatchedBy(func(attachment *domain.Attachment) bool {
+			return attachment.ContentType == "image/png"
+		}), mock.Anything).Return(nil).Once()
+
+		attachment := &domain.Attachment{Filename: "image", Size: int64(len(pngHeader))}
+		attachment.ContentType = "application/octet-stream"
+		err := u.Upload(actor, taskID, attachment, bytes.NewReader(pngHeader))
+
+		assert.NoError(t, err)
+		attachments.AssertExpectations(t)
+	})
+
+	t.Run("content type not allowed", func(t *testing.T) {
+		u, attachments, _ := newUsecase()
+
+		attachment := &domain.Attachment{Filename: "page.html", Size: 5}
+		attachment.ContentType = "text/html"
+		err := u.Upload(actor, taskID, attachment, strings.NewReader("<html>"))
+
+		assert.ErrorIs(t, err, domain.ErrUnsupportedMediaType)
+		attachments.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("declared size over the limit", func(t *testing.T) {
+		u, _, _ := newUsecase()
+
+		err := u.Upload(actor, taskID, &domain.Attachment{Filename: "notes.txt", Size: 17}, strings.NewReader(""))
+
+		assert.ErrorIs(t, err, domain.ErrTooLarge)
+	})
+
+	t.Run("content over the limit", func(t *testing.T) {
+		u, attachments, _ := newUsecase()
+		attachments.On("Upload", mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, _ *domain.Attachment, content io.Reader) error {
+			_, err := io.ReadAll(content)
+			return err
+		}).Once()
+
+		attachment := &domain.Attachment{Filename: "notes.txt", Size: 1}
+		attachment.ContentType = "text/plain"
+		err := u.Upload(actor, taskID, attachment, strings.NewReader(strings.Repeat("a", 17)))
+
+		assert.ErrorIs(t, err, domain.ErrTooLarge)
+	})
+
+	t.Run("invalid filename", func(t *testing.T) {
+		u, _, _ := newUsecase()
+
+		err := u.Upload(actor, taskID, &domain.Attachment{Filename: " "}, strings.NewReader("hello"))
+
+		assert.ErrorIs(t, err, domain.ErrValidation)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		u, _, _ := newUsecase()
+
+		err := u.Upload(context.Background(), taskID, &domain.Attachment{Filename: "notes.txt"}, strings.NewReader("hello"))
+
+		assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+	})
+}
+
+func TestAttachmentDelete(t *testing.T) {
+	taskObjectID, attachmentID := primitive.NewObjectID(), primitive.NewObjectID()
+	taskID := taskObjectID.Hex()
+
+	newUsecase := func() (domain.AttachmentUsecase, *repository.MockAttachmentRepository) {
+		attachments := new(repository.MockAttachmentRepository)
+		tasks := new(repository.MockTaskRepository)
+		tasks.On("FetchByTaskID", mock.Anything, taskID).Return(domain.Task{ID: taskObjectID}, nil).Once()
+		attachments.On("FetchByID", mock.Anything, taskObjectID, attachmentID.Hex()).Return(domain.Attachment{
+			ID:                 attachmentID,
+			AttachmentMetadata: domain.AttachmentMetadata{UploadedBy: "user-1"},
+		}, nil).Once()
+		return usecase.NewAttachmentUsecase(attachments, tasks, domain.DefaultAttachmentPolicy, time.Second*2), attachments
+	}
+
+	t.Run("uploader", func(t *testing.T) {
+		u, attachments := newUsecase()
+		attachments.On("Delete", mock.Anything, attachmentID).Return(nil).Once()
+
+		err := u.Delete(domain.WithActor(context.Background(), "user-1"), taskID, attachmentID.Hex())
+
+		assert.NoError(t, err)
+		attachments.AssertExpectations(t)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		u, attachments := newUsecase()
+
+		err := u.Delete(domain.WithActor(context.Background(), "user-2"), taskID, attachmentID.Hex())
+
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+		attachments.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
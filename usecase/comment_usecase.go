@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+)
+
+type commentUsecase struct {
+	commentRepository domain.CommentRepository
+	taskRepository    domain.TaskRepository
+	projects          projectAccess
+	publisher         domain.EventPublisher
+	contextTimeout    time.Duration
+}
+
+// CommentDeps are the collaborators of the comment usecase, Projects and Workspaces may be nil.
+type CommentDeps struct {
+	Comments  domain.CommentRepository
+	Tasks     domain.TaskRepository
+	Publisher domain.EventPublisher
+	// Projects and Workspaces keep the comments of a task to the members of the workspace of its
+	// project, nil leaves projects unchecked.
+	Projects   domain.ProjectRepository
+	Workspaces domain.WorkspaceRepository
+}
+
+func NewCommentUsecase(commentRepository domain.CommentRepository, taskRepository domain.TaskRepository, publisher domain.EventPublisher, timeout time.Duration) domain.CommentUsecase {
+	return NewCommentUsecaseWithDeps(CommentDeps{Comments: commentRepository, Tasks: taskRepository, Publisher: publisher}, timeout)
+}
+
+func NewCommentUsecaseWithDeps(deps CommentDeps, timeout time.Duration) domain.CommentUsecase {
+	return &commentUsecase{
+		commentRepository: deps.Comments,
+		taskRepository:    deps.Tasks,
+		projects:          projectAccess{projects: deps.Projects, workspaces: deps.Workspaces},
+		publisher:         deps.Publisher,
+		contextTimeout:    timeout,
+	}
+}
+
+// Create returns an ErrEventNotPublished error when the comment was saved but the event could not be published.
+func (u *commentUsecase) Create(c context.Context, taskID string, comment *domain.Comment) error {
+	if domain.ActorFromContext(c) == "" {
+		return domain.ErrUnauthenticated
+	}
+
+	comment.Body = strings.TrimSpace(comment.Body)
+	if comment.Body == "" {
+		return &domain.ValidationError{Field: "body", Message: "must not be empty"}
+	}
+	if utf8.RuneCountInString(comment.Body) > domain.MaxCommentLength {
+		return &domain.ValidationError{Field: "body", Message: fmt.Sprintf("must be at most %d characters", domain.MaxCommentLength)}
+	}
+
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	task, err := u.projects.fetchTask(ctx, u.taskRepository, taskID)
+	if err != nil {
+		return err
+	}
+
+	comment.TaskID = task.ID
+	if err := u.commentRepository.Create(ctx, comment); err != nil {
+		return err
+	}
+
+	event := domain.TaskCommented{
+		TaskID:    task.ID.Hex(),
+		CommentID: comment.ID.Hex(),
+		Author:    comment.Author,
+		Body:      comment.Body,
+		At:        comment.CreatedAt,
+	}
+	if err := u.publisher.Publish(ctx, domain.TopicTaskCommented, event.TaskID, event); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrEventNotPublished, err)
+	}
+	return nil
+}
+
+func (u *commentUsecase) FetchByTaskID(c context.Context, taskID string, page domain.PageRequest) (domain.Page[domain.Comment], error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	page = page.Normalize()
+	result := domain.Page[domain.Comment]{Items: []domain.Comment{}, Page: page.Page, Size: page.Size}
+
+	task, err := u.projects.fetchTask(ctx, u.taskRepository, taskID)
+	if err != nil {
+		return result, err
+	}
+
+	comments, total, err := u.commentRepository.FetchByTaskID(ctx, task.ID, page)
+	if err != nil {
+		return result, err
+	}
+
+	result.Items, result.Total = comments, total
+	return result, nil
+}
+
+func (u *commentUsecase) Delete(c context.Context, taskID, commentID string) error {
+	actor := domain.ActorFromContext(c)
+	if actor == "" {
+		return domain.ErrUnauthenticated
+	}
+
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	task, err := u.projects.fetchTask(ctx, u.taskRepository, taskID)
+	if err != nil {
+		return err
+	}
+
+	comment, err := u.commentRepository.FetchByID(ctx, task.ID, commentID)
+	if err != nil {
+		return err
+	}
+	if comment.Author != actor {
+		return domain.ErrForbidden
+	}
+
+	return u.commentRepository.Delete(ctx, commentID)
+}
@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCommentUsecase is a mock for the CommentUsecase interface
+type MockCommentUsecase struct {
+	mock.Mock
+}
+
+func (m *MockCommentUsecase) Create(c context.Context, taskID string, comment *domain.Comment) error {
+	args := m.Called(c, taskID, comment)
+	return args.Error(0)
+}
+
+func (m *MockCommentUsecase) FetchByTaskID(c context.Context, taskID string, page domain.PageRequest) (domain.Page[domain.Comment], error) {
+	args := m.Called(c, taskID, page)
+	return args.Get(0).(domain.Page[domain.Comment]), args.Error(1)
+}
+
+func (m *MockCommentUsecase) Delete(c context.Context, taskID, commentID string) error {
+	args := m.Called(c, taskID, commentID)
+	return args.Error(0)
+}
@@ -0,0 +1,142 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCommentCreate(t *testing.T) {
+	taskObjectID, commentID := primitive.NewObjectID(), primitive.NewObjectID()
+	taskID := taskObjectID.Hex()
+	createdAt := time.Date(2025, 2, 1, 10, 0, 0, 0, time.UTC)
+	actor := domain.WithActor(context.Background(), "user-1")
+
+	t.Run("success", func(t *testing.T) {
+		tasks := new(repository.MockTaskRepository)
+		comments := new(repository.MockCommentRepository)
+		publisher := new(mockPublisher)
+
+		tasks.On("FetchByTaskID", mock.Anything, taskID).Return(domain.Task{ID: taskObjectID}, nil).Once()
+		comments.On("Create", mock.Anything, mock.MatchedBy(func(comment *domain.Comment) bool {
+			return comment.TaskID == taskObjectID && comment.Body == "looks good"
+		})).Run(func(args mock.Arguments) {
+			comment := args.Get(1).(*domain.Comment)
+			comment.ID, comment.Author, comment.CreatedAt = commentID, "user-1", createdAt
+		}).Return(nil).Once()
+		publisher.On("Publish", mock.Anything, domain.TopicTaskCommented, taskID, domain.TaskCommented{
+			TaskID:    taskID,
+			CommentID: commentID.Hex(),
+			Author:    "user-1",
+			Body:      "looks good",
+			At:        createdAt,
+		}).Return(nil).Once()
+
+		u := usecase.NewCommentUsecase(comments, tasks, publisher, time.Second*2)
+		comment := &domain.Comment{Body: "  looks good\n"}
+		err := u.Create(actor, taskID, comment)
+
+		assert.NoError(t, err)
+		comments.AssertExpectations(t)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		u := usecase.NewCommentUsecase(new(repository.MockCommentRepository), new(repository.MockTaskRepository), new(mockPublisher), time.Second*2)
+
+		err := u.Create(context.Background(), taskID, &domain.Comment{Body: "hi"})
+
+		assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		u := usecase.NewCommentUsecase(new(repository.MockCommentRepository), new(repository.MockTaskRepository), new(mockPublisher), time.Second*2)
+
+		assert.ErrorIs(t, u.Create(actor, taskID, &domain.Comment{Body: "   "}), domain.ErrValidation)
+		assert.ErrorIs(t, u.Create(actor, taskID, &domain.Comment{Body: strings.Repeat("a", domain.MaxCommentLength+1)}), domain.ErrValidation)
+	})
+
+	t.Run("task not found", func(t *testing.T) {
+		tasks := new(repository.MockTaskRepository)
+		comments := new(repository.MockCommentRepository)
+		tasks.On("FetchByTaskID", mock.Anything, taskID).Return(domain.Task{}, domain.ErrNotFound).Once()
+
+		u := usecase.NewCommentUsecase(comments, tasks, new(mockPublisher), time.Second*2)
+		err := u.Create(actor, taskID, &domain.Comment{Body: "hi"})
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		comments.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("publish failure", func(t *testing.T) {
+		tasks := new(repository.MockTaskRepository)
+		comments := new(repository.MockCommentRepository)
+		publisher := new(mockPublisher)
+		tasks.On("FetchByTaskID", mock.Anything, taskID).Return(domain.Task{ID: taskObjectID}, nil).Once()
+		comments.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+		publisher.On("Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("broker down")).Once()
+
+		u := usecase.NewCommentUsecase(comments, tasks, publisher, time.Second*2)
+		err := u.Create(actor, taskID, &domain.Comment{Body: "hi"})
+
+		assert.ErrorIs(t, err, domain.ErrEventNotPublished)
+	})
+}
+
+func TestCommentFetchByTaskID(t *testing.T) {
+	taskObjectID := primitive.NewObjectID()
+	taskID := taskObjectID.Hex()
+
+	tasks := new(repository.MockTaskRepository)
+	comments := new(repository.MockCommentRepository)
+	tasks.On("FetchByTaskID", mock.Anything, taskID).Return(domain.Task{ID: taskObjectID}, nil).Once()
+	comments.On("FetchByTaskID", mock.Anything, taskObjectID, domain.PageRequest{Page: 1, Size: domain.MaxPageSize}).
+		Return([]domain.Comment{{Body: "hi"}}, int64(1), nil).Once()
+
+	u := usecase.NewCommentUsecase(comments, tasks, new(mockPublisher), time.Second*2)
+	page, err := u.FetchByTaskID(context.Background(), taskID, domain.PageRequest{Size: 1000})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Page[domain.Comment]{Items: []domain.Comment{{Body: "hi"}}, Page: 1, Size: domain.MaxPageSize, Total: 1}, page)
+}
+
+func TestCommentDelete(t *testing.T) {
+	taskObjectID, commentID := primitive.NewObjectID(), primitive.NewObjectID().Hex()
+	taskID := taskObjectID.Hex()
+
+	newUsecase := func(author string) (domain.CommentUsecase, *repository.MockCommentRepository) {
+		tasks := new(repository.MockTaskRepository)
+		comments := new(repository.MockCommentRepository)
+		tasks.On("FetchByTaskID", mock.Anything, taskID).Return(domain.Task{ID: taskObjectID}, nil).Once()
+		comments.On("FetchByID", mock.Anything, taskObjectID, commentID).Return(domain.Comment{Author: author}, nil).Once()
+		return usecase.NewCommentUsecase(comments, tasks, new(mockPublisher), time.Second*2), comments
+	}
+
+	t.Run("author", func(t *testing.T) {
+		u, comments := newUsecase("user-1")
+		comments.On("Delete", mock.Anything, commentID).Return(nil).Once()
+
+		err := u.Delete(domain.WithActor(context.Background(), "user-1"), taskID, commentID)
+
+		assert.NoError(t, err)
+		comments.AssertExpectations(t)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		u, comments := newUsecase("user-1")
+
+		err := u.Delete(domain.WithActor(context.Background(), "user-2"), taskID, commentID)
+
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+		comments.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
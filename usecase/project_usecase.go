@@ -0,0 +1,369 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// projectAccess resolves projects for the actor of a request, nil repositories leave tasks unchecked.
+type projectAccess struct {
+	projects   domain.ProjectRepository
+	workspaces domain.WorkspaceRepository
+}
+
+func (a projectAccess) enabled() bool {
+	return a.projects != nil
+}
+
+// project returns the project with the role of the actor of c in its workspace, a project outside the
+// workspaces of the actor is reported as ErrNotFound.
+func (a projectAccess) project(c context.Context, projectID string) (domain.Project, domain.WorkspaceRole, error) {
+	project, err := a.projects.FetchByID(c, projectID)
+	if err != nil {
+		return domain.Project{}, "", err
+	}
+
+	_, role, err := workspaceOf(c, a.workspaces, project.WorkspaceID.Hex())
+	if err != nil {
+		return domain.Project{}, "", err
+	}
+	return project, role, nil
+}
+
+// scope lists the projects of the workspaces of the actor of c, a request without an actor reads only the
+// tasks without a project.
+func (a projectAccess) scope(c context.Context) (domain.TaskScope, error) {
+	if !a.enabled() {
+		return domain.TaskScope{AllProjects: true}, nil
+	}
+
+	actor := domain.ActorFromContext(c)
+	if actor == "" {
+		return domain.TaskScope{}, nil
+	}
+	workspaces, err := a.workspaces.FetchByMember(c, actor)
+	if err != nil || len(workspaces) == 0 {
+		return domain.TaskScope{}, err
+	}
+
+	ids := make([]primitive.ObjectID, len(workspaces))
+	for i, workspace := range workspaces {
+		ids[i] = workspace.ID
+	}
+	projects, err := a.projects.FetchByWorkspaceIDs(c, ids)
+	if err != nil {
+		return domain.TaskScope{}, err
+	}
+
+	scope := domain.TaskScope{ProjectIDs: make([]primitive.ObjectID, len(projects))}
+	for i, project := range projects {
+		scope.ProjectIDs[i] = project.ID
+	}
+	return scope, nil
+}
+
+// visible narrows filter to the tasks the actor of c may read.
+func (a projectAccess) visible(c context.Context, filter domain.TaskFilter) (domain.TaskFilter, error) {
+	if !a.enabled() {
+		return filter, nil
+	}
+
+	scope, err := a.scope(c)
+	if err != nil {
+		return filter, err
+	}
+	filter.Scope = &scope
+	return filter, nil
+}
+
+// readable reports a task of a project outside the workspaces of the actor of c as ErrNotFound.
+func (a projectAccess) readable(c context.Context, task domain.Task) error {
+	if !a.enabled() || task.ProjectID.IsZero() {
+		return nil
+	}
+	_, _, err := a.project(c, task.ProjectID.Hex())
+	return err
+}
+
+// writable is readable that also refuses the tasks of an archived project.
+func (a projectAccess) writable(c context.Context, task domain.Task) error {
+	if !a.enabled() || task.ProjectID.IsZero() {
+		return nil
+	}
+
+	project, _, err := a.project(c, task.ProjectID.Hex())
+	if err != nil {
+		return err
+	}
+	if project.Archived() {
+		return &domain.ValidationError{Field: "projectId", Message: fmt.Sprintf("project %s is archived", project.ID.Hex())}
+	}
+	return nil
+}
+
+// fetchTask reads the task when the actor of c may read it.
+func (a projectAccess) fetchTask(c context.Context, tasks domain.TaskRepository, taskID string) (domain.Task, error) {
+	task, err := tasks.FetchByTaskID(c, taskID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if err := a.readable(c, task); err != nil {
+		return domain.Task{}, err
+	}
+	return task, nil
+}
+
+// validateTaskProjects checks that the actor of c may write a task in each of the projects, the one the
+// task leaves and the one it joins. An archived project takes no task writes.
+func (a projectAccess) validateTaskProjects(c context.Context, projectIDs ...primitive.ObjectID) error {
+	if !a.enabled() {
+		return nil
+	}
+
+	for i, id := range projectIDs {
+		if id.IsZero() || (i > 0 && id == projectIDs[i-1]) {
+			continue
+		}
+
+		project, _, err := a.project(c, id.Hex())
+		if errors.Is(err, domain.ErrNotFound) {
+			return &domain.ValidationError{Field: "projectId", Message: fmt.Sprintf("project %s not found", id.Hex())}
+		}
+		if err != nil {
+			return err
+		}
+		if project.Archived() {
+			return &domain.ValidationError{Field: "projectId", Message: fmt.Sprintf("project %s is archived", id.Hex())}
+		}
+	}
+	return nil
+}
+
+type projectUsecase struct {
+	access         projectAccess
+	taskRepository domain.TaskRepository
+	projectTasks   domain.TaskProjectRepository
+	publisher      domain.EventPublisher
+	history        taskHistory
+	transactions   domain.UnitOfWork
+	contextTimeout time.Duration
+}
+
+// ProjectDeps are the collaborators of the project usecase, Publisher, History and Transactions may be
+// nil.
+type ProjectDeps struct {
+	Projects   domain.ProjectRepository
+	Workspaces domain.WorkspaceRepository
+	Tasks      domain.TaskRepository
+	// ProjectTasks lists the tasks of a project.
+	ProjectTasks domain.TaskProjectRepository
+	// Publisher receives a TaskStatusChanged event for each task archived with its project, nil
+	// publishes nothing.
+	Publisher domain.EventPublisher
+	// History records the tasks archived with their project, nil records nothing.
+	History domain.TaskActivityRepository
+	// Transactions archives a project, its tasks and their history in one unit of work, nil runs the
+	// writes one by one.
+	Transactions domain.UnitOfWork
+}
+
+func NewProjectUsecase(projectRepository domain.ProjectRepository, workspaceRepository domain.WorkspaceRepository, taskRepository domain.TaskRepository, projectTasks domain.TaskProjectRepository, history domain.TaskActivityRepository, timeout time.Duration) domain.ProjectUsecase {
+	return NewProjectUsecaseWithDeps(ProjectDeps{
+		Projects:     projectRepository,
+		Workspaces:   workspaceRepository,
+		Tasks:        taskRepository,
+		ProjectTasks: projectTasks,
+		History:      history,
+	}, timeout)
+}
+
+func NewProjectUsecaseWithDeps(deps ProjectDeps, timeout time.Duration) domain.ProjectUsecase {
+	return &projectUsecase{
+		access:         projectAccess{projects: deps.Projects, workspaces: deps.Workspaces},
+		taskRepository: deps.Tasks,
+		projectTasks:   deps.ProjectTasks,
+		publisher:      orNoPublisher(deps.Publisher),
+		history:        taskHistory{repository: deps.History},
+		transactions:   orNoTransaction(deps.Transactions),
+		contextTimeout: timeout,
+	}
+}
+
+func (u *projectUsecase) Create(c context.Context, workspaceID string, project *domain.Project) error {
+	if err := validateName(&project.Name); err != nil {
+		return err
+	}
+	project.Description = strings.TrimSpace(project.Description)
+
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	workspace, _, err := workspaceOf(ctx, u.access.workspaces, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	project.WorkspaceID = workspace.ID
+	return u.access.projects.Create(ctx, project)
+}
+
+func (u *projectUsecase) FetchByID(c context.Context, projectID string) (domain.Project, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	project, _, err := u.access.project(ctx, projectID)
+	return project, err
+}
+
+func (u *projectUsecase) FetchByWorkspaceID(c context.Context, workspaceID string, archived bool) ([]domain.Project, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	workspace, _, err := workspaceOf(ctx, u.access.workspaces, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	return u.access.projects.FetchByWorkspaceID(ctx, workspace.ID, archived)
+}
+
+func (u *projectUsecase) Update(c context.Context, project *domain.Project) error {
+	if err := validateName(&project.Name); err != nil {
+		return err
+	}
+	if project.Version == 0 {
+		return &domain.ValidationError{Field: "version", Message: "is required"}
+	}
+	project.Description = strings.TrimSpace(project.Description)
+
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	if _, _, err := u.access.project(ctx, project.ID.Hex()); err != nil {
+		return err
+	}
+	return u.access.projects.Update(ctx, project)
+}
+
+// Archive archives the open tasks even when the project already is, a retry completes an earlier archive
+// whose tasks could not all be archived. The project is returned together with an ErrEventNotPublished
+// error when it was archived but the events of its tasks could not all be published.
+func (u *projectUsecase) Archive(c context.Context, projectID string) (domain.Project, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	input, err := u.owned(ctx, projectID)
+	if err != nil {
+		return domain.Project{}, err
+	}
+
+	var project domain.Project
+	var open, archived []domain.Task
+	err = u.transactions.WithTransaction(ctx, func(ctx context.Context) error {
+		// A retried transaction starts over from the project as it was read
+		project = input
+		if !project.Archived() {
+			if err := u.access.projects.SetArchived(ctx, &project, true); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		open, err = u.projectTasks.FetchOpenByProjectID(ctx, project.ID)
+		if err != nil {
+			return err
+		}
+
+		archived = make([]domain.Task, len(open))
+		activities := make([]domain.TaskActivity, len(open))
+		for i, before := range open {
+			task := before
+			task.Status = domain.StatusArchived
+			if err := u.taskRepository.UpdateStatus(ctx, &task); err != nil {
+				return err
+			}
+			archived[i] = task
+			activities[i] = changed(domain.ActivityStatusChanged, before, task)
+		}
+		return u.history.record(ctx, activities...)
+	})
+	if err != nil {
+		return domain.Project{}, err
+	}
+
+	var errs []error
+	for i, task := range archived {
+		event := domain.TaskStatusChanged{
+			TaskID: task.ID.Hex(),
+			From:   open[i].Status.OrDefault(),
+			To:     domain.StatusArchived,
+			Actor:  domain.ActorFromContext(c),
+			At:     task.UpdatedAt,
+		}
+		if err := u.publisher.Publish(ctx, domain.TopicTaskStatusChanged, event.TaskID, event); err != nil {
+			errs = append(errs, fmt.Errorf("task %s: %w", event.TaskID, err))
+		}
+	}
+	if len(errs) > 0 {
+		return project, fmt.Errorf("%w: %v", domain.ErrEventNotPublished, errors.Join(errs...))
+	}
+	return project, nil
+}
+
+// Unarchive leaves the tasks of the project archived.
+func (u *projectUsecase) Unarchive(c context.Context, projectID string) (domain.Project, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	project, err := u.owned(ctx, projectID)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	if project.Archived() {
+		if err := u.access.projects.SetArchived(ctx, &project, false); err != nil {
+			return domain.Project{}, err
+		}
+	}
+	return project, nil
+}
+
+// owned returns the project when the actor of c owns its workspace.
+func (u *projectUsecase) owned(c context.Context, projectID string) (domain.Project, error) {
+	project, role, err := u.access.project(c, projectID)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	if role != domain.RoleOwner {
+		return domain.Project{}, domain.ErrForbidden
+	}
+	return project, nil
+}
+
+func (u *projectUsecase) FetchTasks(c context.Context, projectID string, page domain.PageRequest) (domain.Page[domain.Task], error) {
+	page = page.Normalize()
+	result := domain.Page[domain.Task]{Items: []domain.Task{}, Page: page.Page, Size: page.Size}
+
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	project, _, err := u.access.project(ctx, projectID)
+	if err != nil {
+		return result, err
+	}
+
+	tasks, total, err := u.projectTasks.FetchByProjectID(ctx, project.ID, page)
+	if err != nil {
+		return result, err
+	}
+
+	if tasks != nil {
+		result.Items = tasks
+	}
+	result.Total = total
+	return result, nil
+}
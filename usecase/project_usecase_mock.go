@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProjectUsecase is a mock for the ProjectUsecase interface
+type MockProjectUsecase struct {
+	mock.Mock
+}
+
+func (m *MockProjectUsecase) Create(c context.Context, workspaceID string, project *domain.Project) error {
+	args := m.Called(c, workspaceID, project)
+	return args.Error(0)
+}
+
+func (m *MockProjectUsecase) FetchByID(c context.Context, projectID string) (domain.Project, error) {
+	args := m.Called(c, projectID)
+	return args.Get(0).(domain.Project), args.Error(1)
+}
+
+func (m *MockProjectUsecase) FetchByWorkspaceID(c context.Context, workspaceID string, archived bool) ([]domain.Project, error) {
+	args := m.Called(c, workspaceID, archived)
+	return args.Get(0).([]domain.Project), args.Error(1)
+}
+
+func (m *MockProjectUsecase) Update(c context.Context, project *domain.Project) error {
+	args := m.Called(c, project)
+	return args.Error(0)
+}
+
+func (m *MockProjectUsecase) Archive(c context.Context, projectID string) (domain.Project, error) {
+	args := m.Called(c, projectID)
+	return args.Get(0).(domain.Project), args.Error(1)
+}
+
+func (m *MockProjectUsecase) Unarchive(c context.Context, projectID string) (domain.Project, error) {
+	args := m.Called(c, projectID)
+	return args.Get(0).(domain.Project), args.Error(1)
+}
+
+func (m *MockProjectUsecase) FetchTasks(c context.Context, projectID string, page domain.PageRequest) (domain.Page[domain.Task], error) {
+	args := m.Called(c, projectID, page)
+	return args.Get(0).(domain.Page[domain.Task]), args.Error(1)
+}
@@ -0,0 +1,396 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo/mocks"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// projectFixture is a workspace with an owner and a member holding one project.
+type projectFixture struct {
+	workspace domain.Workspace
+	project   domain.Project
+}
+
+func newProjectFixture(archived bool) projectFixture {
+	workspace := domain.Workspace{ID: primitive.NewObjectID(), Members: []domain.Member{
+		{UserID: "owner", Role: domain.RoleOwner},
+		{UserID: "member", Role: domain.RoleMember},
+	}}
+	project := domain.Project{ID: primitive.NewObjectID(), WorkspaceID: workspace.ID, Name: "Launch", Version: 1}
+	if archived {
+		at := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+		project.ArchivedAt = &at
+	}
+	return projectFixture{workspace: workspace, project: project}
+}
+
+func (f projectFixture) repositories() (*repository.MockProjectRepository, *repository.MockWorkspaceRepository) {
+	projects := new(repository.MockProjectRepository)
+	workspaces := new(repository.MockWorkspaceRepository)
+	projects.On("FetchByID", mock.Anything, f.project.ID.Hex()).Return(f.project, nil).Maybe()
+	projects.On("FetchByWorkspaceIDs", mock.Anything, []primitive.ObjectID{f.workspace.ID}).Return([]domain.Project{f.project}, nil).Maybe()
+	workspaces.On("FetchByID", mock.Anything, f.workspace.ID.Hex()).Return(f.workspace, nil).Maybe()
+	for _, member := range f.workspace.Members {
+		workspaces.On("FetchByMember", mock.Anything, member.UserID).Return([]domain.Workspace{f.workspace}, nil).Maybe()
+	}
+	workspaces.On("FetchByMember", mock.Anything, mock.Anything).Return([]domain.Workspace{}, nil).Maybe()
+	return projects, workspaces
+}
+
+func as(actor string) context.Context {
+	return domain.WithActor(context.Background(), actor)
+}
+
+func TestProjectArchive(t *testing.T) {
+	t.Run("archives the open tasks", func(t *testing.T) {
+		fixture := newProjectFixture(false)
+		projects, workspaces := fixture.repositories()
+		tasks := new(repository.MockTaskRepository)
+		history := new(repository.MockActivityRepository)
+		publisher := new(mockPublisher)
+		transactions := mocks.NewUnitOfWork(t)
+		open := domain.Task{ID: primitive.NewObjectID(), Status: domain.StatusInProgress, ProjectID: fixture.project.ID, Version: 4}
+		archivedAt := time.Date(2025, 3, 2, 9, 0, 0, 0, time.UTC)
+
+		transactions.On("WithTransaction", mock.Anything, mock.Anything).Return(nil).Once()
+		projects.On("SetArchived", mock.Anything, mock.Anything, true).Run(func(args mock.Arguments) {
+			project := args.Get(1).(*domain.Project)
+			project.ArchivedAt, project.Version = &time.Time{}, 2
+		}).Return(nil).Once()
+		tasks.On("FetchOpenByProjectID", mock.Anything, fixture.project.ID).Return([]domain.Task{open}, nil).Once()
+		tasks.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(task *domain.Task) bool {
+			return task.ID == open.ID && task.Status == domain.StatusArchived && task.Version == 4
+		})).Run(func(args mock.Arguments) {
+			task := args.Get(1).(*domain.Task)
+			task.Version, task.UpdatedAt = 5, archivedAt
+		}).Return(nil).Once()
+		history.On("Append", mock.Anything, []domain.TaskActivity{{
+			TaskID:  open.ID,
+			Action:  domain.ActivityStatusChanged,
+			Version: 5,
+			Changes: []domain.FieldChange{{Field: "status", From: "in_progress", To: "archived"}},
+		}}).Return(nil).Once()
+		publisher.On("Publish", mock.Anything, domain.TopicTaskStatusChanged, open.ID.Hex(), domain.TaskStatusChanged{
+			TaskID: open.ID.Hex(),
+			From:   domain.StatusInProgress,
+			To:     domain.StatusArchived,
+			Actor:  "owner",
+			At:     archivedAt,
+		}).Return(nil).Once()
+
+		u := usecase.NewProjectUsecaseWithDeps(usecase.ProjectDeps{Projects: projects, Workspaces: workspaces, Tasks: tasks, ProjectTasks: tasks, Publisher: publisher, History: history, Transactions: transactions}, time.Second*2)
+		project, err := u.Archive(as("owner"), fixture.project.ID.Hex())
+
+		assert.NoError(t, err)
+		assert.True(t, project.Archived())
+		projects.AssertExpectations(t)
+		tasks.AssertExpectations(t)
+		history.AssertExpectations(t)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("an archived project archives the tasks left open", func(t *testing.T) {
+		fixture := newProjectFixture(true)
+		projects, workspaces := fixture.repositories()
+		tasks := new(repository.MockTaskRepository)
+		tasks.On("FetchOpenByProjectID", mock.Anything, fixture.project.ID).Return([]domain.Task{}, nil).Once()
+
+		_, err := usecase.NewProjectUsecase(projects, workspaces, tasks, tasks, nil, time.Second*2).Archive(as("owner"), fixture.project.ID.Hex())
+
+		assert.NoError(t, err)
+		projects.AssertNotCalled(t, "SetArchived", mock.Anything, mock.Anything, mock.Anything)
+		tasks.AssertExpectations(t)
+	})
+
+	t.Run("aborted transaction", func(t *testing.T) {
+		fixture := newProjectFixture(false)
+		projects, workspaces := fixture.repositories()
+		tasks := new(repository.MockTaskRepository)
+		publisher := new(mockPublisher)
+		transactions := mocks.NewUnitOfWork(t)
+		transactions.On("WithTransaction", mock.Anything, mock.Anything).Return(assert.AnError).Once()
+
+		u := usecase.NewProjectUsecaseWithDeps(usecase.ProjectDeps{Projects: projects, Workspaces: workspaces, Tasks: tasks, ProjectTasks: tasks, Publisher: publisher, Transactions: transactions}, time.Second*2)
+		_, err := u.Archive(as("owner"), fixture.project.ID.Hex())
+
+		assert.ErrorIs(t, err, assert.AnError)
+		publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("a task that cannot be archived fails the archive", func(t *testing.T) {
+		fixture := newProjectFixture(true)
+		projects, workspaces := fixture.repositories()
+		tasks := new(repository.MockTaskRepository)
+		history := new(repository.MockActivityRepository)
+		open := domain.Task{ID: primitive.NewObjectID(), Status: domain.StatusTodo, ProjectID: fixture.project.ID, Version: 1}
+		tasks.On("FetchOpenByProjectID", mock.Anything, fixture.project.ID).Return([]domain.Task{open}, nil).Once()
+		tasks.On("UpdateStatus", mock.Anything, mock.Anything).Return(domain.ErrConflict).Once()
+
+		_, err := usecase.NewProjectUsecase(projects, workspaces, tasks, tasks, history, time.Second*2).Archive(as("owner"), fixture.project.ID.Hex())
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+		history.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+	})
+
+	t.Run("events that cannot be published are reported", func(t *testing.T) {
+		fixture := newProjectFixture(true)
+		projects, workspaces := fixture.repositories()
+		tasks := new(repository.MockTaskRepository)
+		publisher := new(mockPublisher)
+		open := domain.Task{ID: primitive.NewObjectID(), Status: domain.StatusTodo, ProjectID: fixture.project.ID, Version: 1}
+		tasks.On("FetchOpenByProjectID", mock.Anything, fixture.project.ID).Return([]domain.Task{open}, nil).Once()
+		tasks.On("UpdateStatus", mock.Anything, mock.Anything).Return(nil).Once()
+		publisher.On("Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(assert.AnError).Once()
+
+		u := usecase.NewProjectUsecaseWithDeps(usecase.ProjectDeps{Projects: projects, Workspaces: workspaces, Tasks: tasks, ProjectTasks: tasks, Publisher: publisher}, time.Second*2)
+		project, err := u.Archive(as("owner"), fixture.project.ID.Hex())
+
+		assert.ErrorIs(t, err, domain.ErrEventNotPublished)
+		assert.Equal(t, fixture.project.ID, project.ID)
+	})
+
+	t.Run("only owners archive", func(t *testing.T) {
+		fixture := newProjectFixture(false)
+		projects, workspaces := fixture.repositories()
+		tasks := new(repository.MockTaskRepository)
+
+		_, err := usecase.NewProjectUsecase(projects, workspaces, tasks, tasks, nil, time.Second*2).Archive(as("member"), fixture.project.ID.Hex())
+
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+		tasks.AssertNotCalled(t, "FetchOpenByProjectID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestProjectVisibility(t *testing.T) {
+	fixture := newProjectFixture(false)
+
+	t.Run("members list the tasks", func(t *testing.T) {
+		projects, workspaces := fixture.repositories()
+		tasks := new(repository.MockTaskRepository)
+		tasks.On("FetchByProjectID", mock.Anything, fixture.project.ID, domain.PageRequest{Page: 1, Size: domain.DefaultPageSize}).
+			Return([]domain.Task{{Title: "Ship"}}, int64(1), nil).Once()
+
+		page, err := usecase.NewProjectUsecase(projects, workspaces, tasks, tasks, nil, time.Second*2).
+			FetchTasks(as("member"), fixture.project.ID.Hex(), domain.PageRequest{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), page.Total)
+		assert.Equal(t, "Ship", page.Items[0].Title)
+	})
+
+	t.Run("outsiders do not see the project", func(t *testing.T) {
+		projects, workspaces := fixture.repositories()
+		tasks := new(repository.MockTaskRepository)
+		u := usecase.NewProjectUsecase(projects, workspaces, tasks, tasks, nil, time.Second*2)
+
+		_, err := u.FetchByID(as("stranger"), fixture.project.ID.Hex())
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		_, err = u.FetchTasks(context.Background(), fixture.project.ID.Hex(), domain.PageRequest{})
+		assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+	})
+
+	t.Run("members create projects", func(t *testing.T) {
+		projects, workspaces := fixture.repositories()
+		projects.On("Create", mock.Anything, mock.MatchedBy(func(project *domain.Project) bool {
+			return project.WorkspaceID == fixture.workspace.ID && project.Name == "Beta"
+		})).Return(nil).Once()
+
+		err := usecase.NewProjectUsecase(projects, workspaces, nil, nil, nil, time.Second*2).
+			Create(as("member"), fixture.workspace.ID.Hex(), &domain.Project{Name: " Beta "})
+
+		assert.NoError(t, err)
+		projects.AssertExpectations(t)
+	})
+}
+
+func TestTaskProjectRules(t *testing.T) {
+	newUsecase := func(fixture projectFixture, tasks *repository.MockTaskRepository) domain.TaskUsecase {
+		projects, workspaces := fixture.repositories()
+		return usecase.NewTaskUsecaseWithDeps(usecase.TaskDeps{Tasks: tasks, Publisher: new(mockPublisher), Projects: projects, Workspaces: workspaces}, time.Second*2)
+	}
+
+	t.Run("create in a project", func(t *testing.T) {
+		fixture := newProjectFixture(false)
+		tasks := new(repository.MockTaskRepository)
+		tasks.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+
+		err := newUsecase(fixture, tasks).Create(as("member"), &domain.Task{Title: "Ship", ProjectID: fixture.project.ID})
+
+		assert.NoError(t, err)
+		tasks.AssertExpectations(t)
+	})
+
+	t.Run("outsiders do not write to the project", func(t *testing.T) {
+		fixture := newProjectFixture(false)
+		tasks := new(repository.MockTaskRepository)
+
+		err := newUsecase(fixture, tasks).Create(as("stranger"), &domain.Task{Title: "Ship", ProjectID: fixture.project.ID})
+
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		tasks.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("a task does not move out of an archived project", func(t *testing.T) {
+		fixture := newProjectFixture(true)
+		tasks := new(repository.MockTaskRepository)
+		id := primitive.NewObjectID()
+		tasks.On("FetchByTaskID", mock.Anything, id.Hex()).Return(domain.Task{ID: id, ProjectID: fixture.project.ID, Version: 1}, nil).Once()
+
+		err := newUsecase(fixture, tasks).Update(as("owner"), &domain.Task{ID: id, Title: "Ship", Version: 1})
+
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		tasks.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("a task of an archived project stays archived", func(t *testing.T) {
+		fixture := newProjectFixture(true)
+		tasks := new(repository.MockTaskRepository)
+		id := primitive.NewObjectID()
+		tasks.On("FetchByTaskID", mock.Anything, id.Hex()).
+			Return(domain.Task{ID: id, Status: domain.StatusArchived, ProjectID: fixture.project.ID, Version: 1}, nil).Once()
+
+		_, err := newUsecase(fixture, tasks).Transition(as("owner"), id.Hex(), domain.StatusTodo)
+
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		tasks.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTaskMembership(t *testing.T) {
+	fixture := newProjectFixture(false)
+	inProject := domain.Task{ID: primitive.NewObjectID(), Title: "Ship", ProjectID: fixture.project.ID, Version: 1}
+	elsewhere := domain.Task{ID: primitive.NewObjectID(), Title: "Other", ProjectID: primitive.NewObjectID(), Version: 1}
+	personal := domain.Task{ID: primitive.NewObjectID(), Title: "Mine", Version: 1}
+
+	newUsecase := func(tasks *repository.MockTaskRepository) domain.TaskUsecase {
+		projects, workspaces := fixture.repositories()
+		return usecase.NewTaskUsecaseWithDeps(usecase.TaskDeps{Tasks: tasks, Publisher: new(mockPublisher), Projects: projects, Workspaces: workspaces}, time.Second*2)
+	}
+
+	t.Run("listings ask for the tasks of the workspaces of the actor", func(t *testing.T) {
+		member := &domain.TaskScope{ProjectIDs: []primitive.ObjectID{fixture.project.ID}}
+		userID := primitive.NewObjectID().Hex()
+		tasks := new(repository.MockTaskRepository)
+		tasks.On("FetchByFilter", mock.Anything, domain.TaskFilter{Scope: member}).Return([]domain.Task{inProject, personal}, nil).Once()
+		tasks.On("FetchByFilter", mock.Anything, domain.TaskFilter{Status: domain.StatusTodo, Scope: member}).Return([]domain.Task{inProject}, nil).Once()
+		tasks.On("FetchByFilter", mock.Anything, domain.TaskFilter{UserID: userID, Scope: member}).Return([]domain.Task{personal}, nil).Once()
+		tasks.On("FetchByFilter", mock.Anything, domain.TaskFilter{Scope: &domain.TaskScope{}}).Return([]domain.Task{personal}, nil).Once()
+
+		all, err := newUsecase(tasks).FetchAll(as("member"))
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Task{inProject, personal}, all)
+
+		_, err = newUsecase(tasks).FetchByFilter(as("member"), domain.TaskFilter{Status: domain.StatusTodo})
+		assert.NoError(t, err)
+
+		_, err = newUsecase(tasks).FetchByUserID(as("member"), userID)
+		assert.NoError(t, err)
+
+		stranger, err := newUsecase(tasks).FetchAll(as("stranger"))
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Task{personal}, stranger)
+		tasks.AssertExpectations(t)
+	})
+
+	t.Run("outsiders do not read the task", func(t *testing.T) {
+		tasks := new(repository.MockTaskRepository)
+		tasks.On("FetchByTaskID", mock.Anything, inProject.ID.Hex()).Return(inProject, nil)
+
+		_, err := newUsecase(tasks).FetchByTaskID(as("stranger"), inProject.ID.Hex())
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		task, err := newUsecase(tasks).FetchByTaskID(as("member"), inProject.ID.Hex())
+		assert.NoError(t, err)
+		assert.Equal(t, inProject.ID, task.ID)
+	})
+
+	t.Run("outsiders do not delete the task", func(t *testing.T) {
+		tasks := new(repository.MockTaskRepository)
+		tasks.On("FetchByTaskID", mock.Anything, inProject.ID.Hex()).Return(inProject, nil).Once()
+
+		err := newUsecase(tasks).Delete(as("stranger"), inProject.ID.Hex())
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		tasks.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("outsiders do not archive the task", func(t *testing.T) {
+		tasks := new(repository.MockTaskRepository)
+		tasks.On("FetchByTaskID", mock.Anything, inProject.ID.Hex()).Return(inProject, nil).Once()
+
+		_, err := newUsecase(tasks).Transition(as("stranger"), inProject.ID.Hex(), domain.StatusArchived)
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		tasks.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("a task of an archived project is not restored", func(t *testing.T) {
+		archived := newProjectFixture(true)
+		projects, workspaces := archived.repositories()
+		tasks := new(repository.MockTaskRepository)
+		deleted := domain.Task{ID: primitive.NewObjectID(), ProjectID: archived.project.ID}
+		tasks.On("FetchDeletedByTaskID", mock.Anything, deleted.ID.Hex()).Return(deleted, nil).Once()
+
+		_, err := usecase.NewTaskUsecaseWithDeps(usecase.TaskDeps{Tasks: tasks, Publisher: new(mockPublisher), Projects: projects, Workspaces: workspaces}, time.Second*2).
+			Restore(as("owner"), deleted.ID.Hex())
+
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		tasks.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+	})
+
+	t.Run("the tree leaves out the subtasks of other workspaces", func(t *testing.T) {
+		tasks := new(repository.MockTaskRepository)
+		tasks.On("FetchTree", mock.Anything, personal.ID.Hex()).Return(domain.TaskTree{Task: personal, Subtasks: []*domain.TaskTree{
+			{Task: inProject},
+			{Task: elsewhere, Subtasks: []*domain.TaskTree{{Task: personal}}},
+		}}, nil).Once()
+
+		tree, err := newUsecase(tasks).FetchTree(as("member"), personal.ID.Hex())
+
+		assert.NoError(t, err)
+		assert.Len(t, tree.Subtasks, 1)
+		assert.Equal(t, inProject.ID, tree.Subtasks[0].ID)
+	})
+
+	t.Run("search is limited to the workspaces of the actor", func(t *testing.T) {
+		projects, workspaces := fixture.repositories()
+		tasks := new(repository.MockTaskRepository)
+		tasks.On("Search", mock.Anything, domain.TaskScope{ProjectIDs: []primitive.ObjectID{fixture.project.ID}}, "ship", mock.Anything).
+			Return([]domain.TaskMatch{}, int64(0), nil).Once()
+
+		_, err := usecase.NewTaskUsecaseWithDeps(usecase.TaskDeps{Tasks: tasks, Publisher: new(mockPublisher), Projects: projects, Workspaces: workspaces}, time.Second*2).
+			Search(as("member"), "ship", domain.PageRequest{})
+
+		assert.NoError(t, err)
+		tasks.AssertExpectations(t)
+	})
+
+	t.Run("outsiders do not read the comments or the history", func(t *testing.T) {
+		projects, workspaces := fixture.repositories()
+		tasks := new(repository.MockTaskRepository)
+		comments := new(repository.MockCommentRepository)
+		history := new(repository.MockActivityRepository)
+		tasks.On("FetchByTaskID", mock.Anything, inProject.ID.Hex()).Return(inProject, nil)
+
+		_, err := usecase.NewCommentUsecaseWithDeps(usecase.CommentDeps{Comments: comments, Tasks: tasks, Publisher: new(mockPublisher), Projects: projects, Workspaces: workspaces}, time.Second*2).
+			FetchByTaskID(as("stranger"), inProject.ID.Hex(), domain.PageRequest{})
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		_, err = usecase.NewActivityUsecaseWithDeps(usecase.ActivityDeps{Activities: history, Tasks: tasks, Projects: projects, Workspaces: workspaces}, time.Second*2).
+			FetchByTaskID(as("stranger"), inProject.ID.Hex(), domain.PageRequest{})
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		comments.AssertNotCalled(t, "FetchByTaskID", mock.Anything, mock.Anything, mock.Anything)
+		history.AssertNotCalled(t, "FetchByTaskID", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type taskBulkUsecase struct {
+	// tasks holds the validation rules shared with single task writes
+	tasks          *taskUsecase
+	bulkRepository domain.TaskBulkRepository
+	history        taskHistory
+	transactions   domain.UnitOfWork
+	maxOperations  int
+	contextTimeout time.Duration
+}
+
+// TaskBulkDeps are the collaborators of the bulk usecase, every field but Tasks and Bulk may be nil.
+type TaskBulkDeps struct {
+	Tasks domain.TaskRepository
+	Bulk  domain.TaskBulkRepository
+	// History records the applied operations like the single writes do, nil records nothing.
+	History domain.TaskActivityRepository
+	// Projects and Workspaces check the projects of the operations like the single writes do, nil leaves
+	// projects unchecked.
+	Projects   domain.ProjectRepository
+	Workspaces domain.WorkspaceRepository
+	// Transactions writes the batch and its history in one unit of work, nil runs the writes one by one.
+	Transactions domain.UnitOfWork
+}
+
+// NewTaskBulkUsecase accepts at most maxOperations per request, domain.DefaultMaxBulkOperations when
+// maxOperations is not positive.
+func NewTaskBulkUsecase(taskRepository domain.TaskRepository, bulkRepository domain.TaskBulkRepository, maxOperations int, timeout time.Duration) domain.TaskBulkUsecase {
+	return NewTaskBulkUsecaseWithDeps(TaskBulkDeps{Tasks: taskRepository, Bulk: bulkRepository}, maxOperations, timeout)
+}
+
+func NewTaskBulkUsecaseWithDeps(deps TaskBulkDeps, maxOperations int, timeout time.Duration) domain.TaskBulkUsecase {
+	if maxOperations <= 0 {
+		maxOperations = domain.DefaultMaxBulkOperations
+	}
+	return &taskBulkUsecase{
+		tasks:          newTaskUsecase(TaskDeps{Tasks: deps.Tasks, Projects: deps.Projects, Workspaces: deps.Workspaces}, timeout),
+		bulkRepository: deps.Bulk,
+		history:        taskHistory{repository: deps.History},
+		transactions:   orNoTransaction(deps.Transactions),
+		maxOperations:  maxOperations,
+		contextTimeout: timeout,
+	}
+}
+
+// Bulk checks each operation like the single write it stands for and sends the valid ones to the
+// repository in one batch. An invalid operation fails on its own, in ordered mode it also skips the
+// operations after it. The batch fails as a whole when its history cannot be recorded.
+func (u *taskBulkUsecase) Bulk(c context.Context, operations []domain.BulkOperation, opts domain.BulkOptions) ([]domain.BulkResult, error) {
+	if len(operations) == 0 {
+		return nil, &domain.ValidationError{Field: "operations", Message: "must not be empty"}
+	}
+	if len(operations) > u.maxOperations {
+		return nil, fmt.Errorf("%w: at most %d operations per request", domain.ErrTooLarge, u.maxOperations)
+	}
+
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	results := make([]domain.BulkResult, len(operations))
+	valid := []domain.BulkOperation{}
+	// origins maps each valid operation to its index in operations
+	origins := []int{}
+	for i, op := range operations {
+		results[i] = domain.BulkResult{Index: i, Action: op.Action}
+		if err := u.validate(ctx, op); err != nil {
+			results[i].Err = err
+			if opts.Ordered {
+				break
+			}
+			continue
+		}
+		valid = append(valid, op)
+		origins = append(origins, i)
+	}
+
+	if len(valid) > 0 {
+		before, err := u.fetchUpdated(ctx, valid)
+		if err != nil {
+			return nil, err
+		}
+
+		var written []domain.BulkResult
+		err = u.transactions.WithTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			written, err = u.bulkRepository.BulkWrite(ctx, valid, opts)
+			if err != nil {
+				return err
+			}
+			return u.history.record(ctx, bulkActivities(valid, written, maps.Clone(before))...)
+		})
+		if err != nil {
+			return nil, err
+		}
+		for j, result := range written {
+			result.Index = origins[j]
+			results[origins[j]] = result
+		}
+	}
+
+	if opts.Ordered {
+		skipAfterFailure(results)
+	}
+	return results, nil
+}
+
+// fetchUpdated reads the tasks updated by operations when the history is recorded, the changes of an
+// update are diffed against them.
+func (u *taskBulkUsecase) fetchUpdated(c context.Context, operations []domain.BulkOperation) (map[primitive.ObjectID]domain.Task, error) {
+	tasks := map[primitive.ObjectID]domain.Task{}
+	ids := []primitive.ObjectID{}
+	for _, op := range operations {
+		if op.Action == domain.BulkUpdate {
+			ids = append(ids, op.Task.ID)
+		}
+	}
+	if !u.history.enabled() || len(ids) == 0 {
+		return tasks, nil
+	}
+
+	found, err := u.tasks.taskRepository.FetchByIDs(c, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range found {
+		tasks[task.ID] = task
+	}
+	return tasks, nil
+}
+
+// bulkActivities lists the activities of the operations that were applied, in order. An update of a
+// task updated earlier in the batch is diffed against the earlier update.
+func bulkActivities(operations []domain.BulkOperation, results []domain.BulkResult, before map[primitive.ObjectID]domain.Task) []domain.TaskActivity {
+	activities := []domain.TaskActivity{}
+	for i, op := range operations {
+		result := results[i]
+		if result.Err != nil {
+			continue
+		}
+
+		switch op.Action {
+		case domain.BulkCreate:
+			activities = append(activities, created(*op.Task))
+		case domain.BulkUpdate:
+			after := edited(before[result.ID], *op.Task)
+			after.ID, after.Version = result.ID, result.Version
+			activities = append(activities, changed(domain.ActivityUpdated, before[result.ID], after))
+			before[result.ID] = after
+		case domain.BulkDelete:
+			activities = append(activities, domain.TaskActivity{TaskID: result.ID, Action: domain.ActivityDeleted})
+		}
+	}
+	return activities
+}
+
+func (u *taskBulkUsecase) validate(c context.Context, op domain.BulkOperation) error {
+	if !op.Action.Valid() {
+		return &domain.ValidationError{Field: "action", Message: "must be create, update or delete"}
+	}
+	if op.Action != domain.BulkDelete && op.Task == nil {
+		return &domain.ValidationError{Field: "task", Message: "is required"}
+	}
+
+	switch op.Action {
+	case domain.BulkCreate:
+		op.Task.ID = primitive.NilObjectID
+		if err := prepareNew(op.Task); err != nil {
+			return err
+		}
+	case domain.BulkUpdate:
+		id, err := primitive.ObjectIDFromHex(op.ID)
+		if err != nil {
+			return fmt.Errorf("%w: %s", domain.ErrInvalidID, op.ID)
+		}
+		if op.Task.Version == 0 {
+			return &domain.ValidationError{Field: "version", Message: "is required"}
+		}
+		op.Task.ID = id
+	case domain.BulkDelete:
+		if _, err := primitive.ObjectIDFromHex(op.ID); err != nil {
+			return fmt.Errorf("%w: %s", domain.ErrInvalidID, op.ID)
+		}
+		return u.validateProjects(c, op)
+	}
+
+	if err := op.Task.ValidateRecurrence(); err != nil {
+		return err
+	}
+	if err := u.tasks.validateRelations(c, op.Task); err != nil {
+		return err
+	}
+	return u.validateProjects(c, op)
+}
+
+// validateProjects checks the project an update or delete takes the task out of as well, a task the
+// repository does not find is left for it to report. A task outside the workspaces of the actor is
+// reported as ErrNotFound.
+func (u *taskBulkUsecase) validateProjects(c context.Context, op domain.BulkOperation) error {
+	if !u.tasks.projects.enabled() {
+		return nil
+	}
+	if op.Action == domain.BulkCreate {
+		return u.tasks.projects.validateTaskProjects(c, op.Task.ProjectID)
+	}
+
+	current, err := u.tasks.taskRepository.FetchByTaskID(c, op.ID)
+	if errors.Is(err, domain.ErrNotFound) {
+		current, err = domain.Task{}, nil
+	}
+	if err != nil {
+		return err
+	}
+	if op.Action == domain.BulkDelete {
+		return u.tasks.projects.writable(c, current)
+	}
+	if err := u.tasks.projects.readable(c, current); err != nil {
+		return err
+	}
+	return u.tasks.projects.validateTaskProjects(c, current.ProjectID, op.Task.ProjectID)
+}
+
+// skipAfterFailure reports every operation after the first failed one as skipped, an ordered batch
+// does not run them.
+func skipAfterFailure(results []domain.BulkResult) {
+	for i := range results {
+		if results[i].Err == nil {
+			continue
+		}
+		for j := i + 1; j < len(results); j++ {
+			results[j].Err = domain.ErrSkipped
+		}
+		return
+	}
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTaskBulkUsecase is a mock for the TaskBulkUsecase interface
+type MockTaskBulkUsecase struct {
+	mock.Mock
+}
+
+func (m *MockTaskBulkUsecase) Bulk(c context.Context, operations []domain.BulkOperation, opts domain.BulkOptions) ([]domain.BulkResult, error) {
+	args := m.Called(c, operations, opts)
+
+	var results []domain.BulkResult
+	if args.Get(0) != nil {
+		results = args.Get(0).([]domain.BulkResult)
+	}
+	return results, args.Error(1)
+}
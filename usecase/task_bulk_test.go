@@ -0,0 +1,108 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTaskBulk(t *testing.T) {
+	taskID := primitive.NewObjectID()
+
+	t.Run("writes the valid operations", func(t *testing.T) {
+		repo := new(repository.MockTaskRepository)
+		repo.On("BulkWrite", mock.Anything, mock.MatchedBy(func(operations []domain.BulkOperation) bool {
+			return len(operations) == 2 && operations[0].Action == domain.BulkCreate && operations[1].Action == domain.BulkDelete
+		}), domain.BulkOptions{}).Return([]domain.BulkResult{
+			{Index: 0, Action: domain.BulkCreate, ID: taskID, Version: 1},
+			{Index: 1, Action: domain.BulkDelete, ID: taskID, Err: domain.ErrNotFound},
+		}, nil).Once()
+
+		u := usecase.NewTaskBulkUsecase(repo, repo, 0, time.Second*2)
+		results, err := u.Bulk(context.Background(), []domain.BulkOperation{
+			{Action: domain.BulkCreate, Task: &domain.Task{Title: "New"}},
+			{Action: domain.BulkUpdate, ID: taskID.Hex(), Task: &domain.Task{Title: "No version"}},
+			{Action: domain.BulkDelete, ID: taskID.Hex()},
+		}, domain.BulkOptions{})
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 3)
+		assert.Equal(t, domain.BulkResult{Index: 0, Action: domain.BulkCreate, ID: taskID, Version: 1}, results[0])
+		var validation *domain.ValidationError
+		if assert.ErrorAs(t, results[1].Err, &validation) {
+			assert.Equal(t, "version", validation.Field)
+		}
+		assert.Equal(t, 2, results[2].Index)
+		assert.ErrorIs(t, results[2].Err, domain.ErrNotFound)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("ordered skips after an invalid operation", func(t *testing.T) {
+		repo := new(repository.MockTaskRepository)
+		repo.On("BulkWrite", mock.Anything, mock.MatchedBy(func(operations []domain.BulkOperation) bool {
+			return len(operations) == 1
+		}), domain.BulkOptions{Ordered: true}).Return([]domain.BulkResult{
+			{Index: 0, Action: domain.BulkDelete, ID: taskID},
+		}, nil).Once()
+
+		u := usecase.NewTaskBulkUsecase(repo, repo, 0, time.Second*2)
+		results, err := u.Bulk(context.Background(), []domain.BulkOperation{
+			{Action: domain.BulkDelete, ID: taskID.Hex()},
+			{Action: "archive", ID: taskID.Hex()},
+			{Action: domain.BulkCreate, Task: &domain.Task{Title: "New"}},
+		}, domain.BulkOptions{Ordered: true})
+
+		assert.NoError(t, err)
+		assert.NoError(t, results[0].Err)
+		assert.ErrorIs(t, results[1].Err, domain.ErrValidation)
+		assert.ErrorIs(t, results[2].Err, domain.ErrSkipped)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("invalid operations", func(t *testing.T) {
+		missing := primitive.NewObjectID()
+		repo := new(repository.MockTaskRepository)
+		repo.On("FetchBlockerIDs", mock.Anything, []primitive.ObjectID{missing}).Return([]primitive.ObjectID{}, nil).Once()
+
+		u := usecase.NewTaskBulkUsecase(repo, repo, 0, time.Second*2)
+		results, err := u.Bulk(context.Background(), []domain.BulkOperation{
+			{Action: domain.BulkCreate},
+			{Action: domain.BulkCreate, Task: &domain.Task{Status: "later"}},
+			{Action: domain.BulkCreate, Task: &domain.Task{Status: domain.StatusDone}},
+			{Action: domain.BulkCreate, Task: &domain.Task{BlockedBy: []primitive.ObjectID{missing}}},
+			{Action: domain.BulkUpdate, ID: taskID.Hex(), Task: &domain.Task{Version: 1, Recurrence: "FREQ=SOMETIMES"}},
+			{Action: domain.BulkDelete, ID: "bad"},
+		}, domain.BulkOptions{})
+
+		assert.NoError(t, err)
+		fields := []string{}
+		for _, result := range results[:5] {
+			var validation *domain.ValidationError
+			if assert.ErrorAs(t, result.Err, &validation) {
+				fields = append(fields, validation.Field)
+			}
+		}
+		assert.Equal(t, []string{"task", "status", "status", "blockedBy", "recurrence"}, fields)
+		assert.ErrorIs(t, results[5].Err, domain.ErrInvalidID)
+		repo.AssertNotCalled(t, "BulkWrite", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("batch size", func(t *testing.T) {
+		repo := new(repository.MockTaskRepository)
+		u := usecase.NewTaskBulkUsecase(repo, repo, 2, time.Second*2)
+
+		_, err := u.Bulk(context.Background(), make([]domain.BulkOperation, 3), domain.BulkOptions{})
+		assert.ErrorIs(t, err, domain.ErrTooLarge)
+
+		_, err = u.Bulk(context.Background(), nil, domain.BulkOptions{})
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		repo.AssertNotCalled(t, "BulkWrite", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+)
+
+type taskReminderUsecase struct {
+	taskRepository domain.TaskReminderRepository
+	clock          domain.Clock
+	window         time.Duration
+	contextTimeout time.Duration
+}
+
+// NewTaskReminderUsecase reminds tasks due within window.
+func NewTaskReminderUsecase(taskRepository domain.TaskReminderRepository, clock domain.Clock, window, timeout time.Duration) domain.TaskReminderUsecase {
+	return &taskReminderUsecase{
+		taskRepository: taskRepository,
+		clock:          clock,
+		window:         window,
+		contextTimeout: timeout,
+	}
+}
+
+// SendReminders records a reminder once it is published, a task whose reminder could not be published
+// or recorded is reminded again by the next run. The errors of all tasks are joined.
+func (u *taskReminderUsecase) SendReminders(c context.Context, publisher domain.EventPublisher) (int, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	tasks, err := u.taskRepository.FetchDueForReminder(ctx, u.window)
+	cancel()
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var errs []error
+	for _, task := range tasks {
+		if err := u.remind(c, publisher, task); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		sent++
+	}
+
+	return sent, errors.Join(errs...)
+}
+
+func (u *taskReminderUsecase) remind(c context.Context, publisher domain.EventPublisher, task domain.Task) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	event := domain.TaskReminder{
+		TaskID:   task.ID.Hex(),
+		Title:    task.Title,
+		Priority: task.Priority,
+		At:       u.clock.Now(),
+	}
+	if !task.UserID.IsZero() {
+		event.UserID = task.UserID.Hex()
+	}
+	if task.DueDate != nil {
+		event.DueDate = *task.DueDate
+	}
+
+	if err := publisher.Publish(ctx, domain.TopicTaskReminder, event.TaskID, event); err != nil {
+		return err
+	}
+	return u.taskRepository.MarkReminded(ctx, event.TaskID)
+}
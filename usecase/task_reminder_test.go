@@ -0,0 +1,88 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestSendReminders(t *testing.T) {
+	now := time.Date(2025, 2, 1, 10, 0, 0, 0, time.UTC)
+	dueDate := now.Add(3 * time.Hour)
+	first, second := primitive.NewObjectID(), primitive.NewObjectID()
+	tasks := []domain.Task{
+		{ID: first, Title: "first", DueDate: &dueDate, Priority: domain.PriorityHigh},
+		{ID: second, Title: "second", DueDate: &dueDate},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockTaskRepository := new(repository.MockTaskRepository)
+		publisher := new(mockPublisher)
+
+		mockTaskRepository.On("FetchDueForReminder", mock.Anything, 24*time.Hour).Return(tasks, nil).Once()
+		publisher.On("Publish", mock.Anything, domain.TopicTaskReminder, first.Hex(), domain.TaskReminder{
+			TaskID:   first.Hex(),
+			Title:    "first",
+			Priority: domain.PriorityHigh,
+			DueDate:  dueDate,
+			At:       now,
+		}).Return(nil).Once()
+		publisher.On("Publish", mock.Anything, domain.TopicTaskReminder, second.Hex(), mock.Anything).Return(nil).Once()
+		mockTaskRepository.On("MarkReminded", mock.Anything, first.Hex()).Return(nil).Once()
+		mockTaskRepository.On("MarkReminded", mock.Anything, second.Hex()).Return(nil).Once()
+
+		u := usecase.NewTaskReminderUsecase(mockTaskRepository, fixedClock(now), 24*time.Hour, time.Second*2)
+		sent, err := u.SendReminders(context.TODO(), publisher)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, sent)
+		mockTaskRepository.AssertExpectations(t)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("publish failure is not recorded", func(t *testing.T) {
+		mockTaskRepository := new(repository.MockTaskRepository)
+		publisher := new(mockPublisher)
+
+		mockTaskRepository.On("FetchDueForReminder", mock.Anything, 24*time.Hour).Return(tasks, nil).Once()
+		publisher.On("Publish", mock.Anything, domain.TopicTaskReminder, first.Hex(), mock.Anything).Return(errors.New("broker down")).Once()
+		publisher.On("Publish", mock.Anything, domain.TopicTaskReminder, second.Hex(), mock.Anything).Return(nil).Once()
+		mockTaskRepository.On("MarkReminded", mock.Anything, second.Hex()).Return(nil).Once()
+
+		u := usecase.NewTaskReminderUsecase(mockTaskRepository, fixedClock(now), 24*time.Hour, time.Second*2)
+		sent, err := u.SendReminders(context.TODO(), publisher)
+
+		assert.EqualError(t, err, "broker down")
+		assert.Equal(t, 1, sent)
+		mockTaskRepository.AssertNotCalled(t, "MarkReminded", mock.Anything, first.Hex())
+		mockTaskRepository.AssertExpectations(t)
+	})
+
+	t.Run("fetch error", func(t *testing.T) {
+		mockTaskRepository := new(repository.MockTaskRepository)
+		publisher := new(mockPublisher)
+
+		mockTaskRepository.On("FetchDueForReminder", mock.Anything, 24*time.Hour).Return(nil, errors.New("Unexpected")).Once()
+
+		u := usecase.NewTaskReminderUsecase(mockTaskRepository, fixedClock(now), 24*time.Hour, time.Second*2)
+		sent, err := u.SendReminders(context.TODO(), publisher)
+
+		assert.Error(t, err)
+		assert.Zero(t, sent)
+		publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,60 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearch(t *testing.T) {
+	t.Run("sanitizes the query and highlights matches", func(t *testing.T) {
+		repo := new(repository.MockTaskRepository)
+		repo.On("Search", mock.Anything, domain.TaskScope{AllProjects: true}, "login bug where", domain.PageRequest{Page: 1, Size: domain.DefaultPageSize}).Return([]domain.TaskMatch{
+			{Task: domain.Task{Title: "Fix login", Description: "Users see a bug"}, Score: 2},
+			{Task: domain.Task{Title: "Logins", Description: "Nothing else"}, Score: 1},
+		}, int64(2), nil).Once()
+
+		u := usecase.NewTaskUsecase(repo, time.Second*2)
+		page, err := u.Search(context.Background(), `"login" -bug LOGIN {"$where"}`, domain.PageRequest{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), page.Total)
+		assert.Equal(t, map[string]string{
+			"title":       "Fix <mark>login</mark>",
+			"description": "Users see a <mark>bug</mark>",
+		}, page.Items[0].Highlights)
+		assert.Equal(t, map[string]string{"title": "<mark>Logins</mark>"}, page.Items[1].Highlights)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("limits the number of terms", func(t *testing.T) {
+		repo := new(repository.MockTaskRepository)
+		repo.On("Search", mock.Anything, mock.Anything, mock.MatchedBy(func(query string) bool {
+			return len(strings.Fields(query)) == domain.MaxSearchTerms
+		}), mock.Anything).Return([]domain.TaskMatch{}, int64(0), nil).Once()
+
+		u := usecase.NewTaskUsecase(repo, time.Second*2)
+		_, err := u.Search(context.Background(), "a b c d e f g h i j k l", domain.PageRequest{})
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		repo := new(repository.MockTaskRepository)
+		u := usecase.NewTaskUsecase(repo, time.Second*2)
+
+		for _, query := range []string{"", ` "-" `, strings.Repeat("a", domain.MaxSearchQueryLength+1)} {
+			_, err := u.Search(context.Background(), query, domain.PageRequest{})
+			assert.ErrorIs(t, err, domain.ErrValidation)
+		}
+		repo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
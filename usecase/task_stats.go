@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+)
+
+type taskStatsUsecase struct {
+	statsRepository domain.TaskStatsRepository
+	projects        projectAccess
+	clock           domain.Clock
+	contextTimeout  time.Duration
+}
+
+// TaskStatsDeps are the collaborators of the stats usecase, Projects and Workspaces may be nil.
+type TaskStatsDeps struct {
+	Stats domain.TaskStatsRepository
+	// Projects and Workspaces count only the tasks of the workspaces of the actor, nil counts every task.
+	Projects   domain.ProjectRepository
+	Workspaces domain.WorkspaceRepository
+}
+
+func NewTaskStatsUsecase(statsRepository domain.TaskStatsRepository, clock domain.Clock, timeout time.Duration) domain.TaskStatsUsecase {
+	return NewTaskStatsUsecaseWithDeps(TaskStatsDeps{Stats: statsRepository}, clock, timeout)
+}
+
+func NewTaskStatsUsecaseWithDeps(deps TaskStatsDeps, clock domain.Clock, timeout time.Duration) domain.TaskStatsUsecase {
+	return &taskStatsUsecase{
+		statsRepository: deps.Stats,
+		projects:        projectAccess{projects: deps.Projects, workspaces: deps.Workspaces},
+		clock:           clock,
+		contextTimeout:  timeout,
+	}
+}
+
+// Stats reports weekly over the last domain.DefaultStatsRange unless the query says otherwise.
+func (u *taskStatsUsecase) Stats(c context.Context, query domain.TaskStatsQuery) (domain.TaskStats, error) {
+	if query.Interval == "" {
+		query.Interval = domain.IntervalWeek
+	}
+	if !query.Interval.Valid() {
+		return domain.TaskStats{}, &domain.ValidationError{Field: "interval", Message: "must be day, week or month"}
+	}
+	if _, err := time.LoadLocation(query.TimeZone); err != nil {
+		return domain.TaskStats{}, &domain.ValidationError{Field: "tz", Message: "unknown time zone " + query.TimeZone}
+	}
+
+	if query.To.IsZero() {
+		query.To = u.clock.Now()
+	}
+	if query.From.IsZero() {
+		query.From = query.To.Add(-domain.DefaultStatsRange)
+	}
+	if !query.From.Before(query.To) {
+		return domain.TaskStats{}, &domain.ValidationError{Field: "from", Message: "must be before to"}
+	}
+	if query.To.Sub(query.From) > domain.MaxStatsRange {
+		return domain.TaskStats{}, &domain.ValidationError{Field: "to", Message: fmt.Sprintf("must be at most %d days after from", domain.MaxStatsRange/(24*time.Hour))}
+	}
+
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	scope, err := u.projects.scope(ctx)
+	if err != nil {
+		return domain.TaskStats{}, err
+	}
+	return u.statsRepository.Stats(ctx, scope, query)
+}
@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTaskStatsUsecase is a mock for the TaskStatsUsecase interface
+type MockTaskStatsUsecase struct {
+	mock.Mock
+}
+
+func (m *MockTaskStatsUsecase) Stats(c context.Context, query domain.TaskStatsQuery) (domain.TaskStats, error) {
+	args := m.Called(c, query)
+	return args.Get(0).(domain.TaskStats), args.Error(1)
+}
@@ -0,0 +1,57 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTaskStats(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("defaults", func(t *testing.T) {
+		repo := new(repository.MockTaskStatsRepository)
+		repo.On("Stats", mock.Anything, domain.TaskScope{AllProjects: true}, domain.TaskStatsQuery{
+			From:     now.Add(-domain.DefaultStatsRange),
+			To:       now,
+			Interval: domain.IntervalWeek,
+		}).Return(domain.TaskStats{Total: 3}, nil).Once()
+
+		u := usecase.NewTaskStatsUsecase(repo, fixedClock(now), time.Second*2)
+		stats, err := u.Stats(context.Background(), domain.TaskStatsQuery{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), stats.Total)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		tests := []struct {
+			field string
+			query domain.TaskStatsQuery
+		}{
+			{field: "interval", query: domain.TaskStatsQuery{Interval: "year"}},
+			{field: "tz", query: domain.TaskStatsQuery{TimeZone: "Mars/Olympus"}},
+			{field: "from", query: domain.TaskStatsQuery{From: now, To: now}},
+			{field: "to", query: domain.TaskStatsQuery{From: now.Add(-domain.MaxStatsRange - time.Hour), To: now}},
+		}
+
+		repo := new(repository.MockTaskStatsRepository)
+		u := usecase.NewTaskStatsUsecase(repo, fixedClock(now), time.Second*2)
+		for _, tt := range tests {
+			_, err := u.Stats(context.Background(), tt.query)
+
+			var validation *domain.ValidationError
+			if assert.ErrorAs(t, err, &validation) {
+				assert.Equal(t, tt.field, validation.Field)
+			}
+		}
+		repo.AssertNotCalled(t, "Stats", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,629 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type taskTransferUsecase struct {
+	taskRepository     domain.TaskRepository
+	transferRepository domain.TaskTransferRepository
+	projects           projectAccess
+	history            taskHistory
+	transactions       domain.UnitOfWork
+	contextTimeout     time.Duration
+}
+
+// TaskTransferDeps are the collaborators of the transfer usecase, every field but Tasks and Transfers
+// may be nil.
+type TaskTransferDeps struct {
+	Tasks     domain.TaskRepository
+	Transfers domain.TaskTransferRepository
+	// History records the creation of every imported task, nil records nothing.
+	History domain.TaskActivityRepository
+	// Projects and Workspaces limit an export to the tasks the actor may read and check the project of
+	// every imported row like a created task, nil leaves projects unchecked.
+	Projects   domain.ProjectRepository
+	Workspaces domain.WorkspaceRepository
+	// Transactions writes an import and its history in one unit of work, nil runs the writes one by one.
+	Transactions domain.UnitOfWork
+}
+
+func NewTaskTransferUsecase(taskRepository domain.TaskRepository, transferRepository domain.TaskTransferRepository, timeout time.Duration) domain.TaskTransferUsecase {
+	return NewTaskTransferUsecaseWithDeps(TaskTransferDeps{Tasks: taskRepository, Transfers: transferRepository}, timeout)
+}
+
+func NewTaskTransferUsecaseWithDeps(deps TaskTransferDeps, timeout time.Duration) domain.TaskTransferUsecase {
+	return &taskTransferUsecase{
+		taskRepository:     deps.Tasks,
+		transferRepository: deps.Transfers,
+		projects:           projectAccess{projects: deps.Projects, workspaces: deps.Workspaces},
+		history:            taskHistory{repository: deps.History},
+		transactions:       orNoTransaction(deps.Transactions),
+		contextTimeout:     timeout,
+	}
+}
+
+func formatError() error {
+	return &domain.ValidationError{Field: "format", Message: "must be csv or ndjson"}
+}
+
+// Export encodes the tasks as they are read, the whole collection is never in memory.
+func (u *taskTransferUsecase) Export(c context.Context, format domain.TaskFormat, w io.Writer) error {
+	if !format.Valid() {
+		return formatError()
+	}
+
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	scope, err := u.projects.scope(ctx)
+	if err != nil {
+		return err
+	}
+
+	if format == domain.FormatNDJSON {
+		encoder := json.NewEncoder(w)
+		return u.transferRepository.Each(ctx, scope, func(task domain.Task) error {
+			return encoder.Encode(task)
+		})
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(domain.TaskColumns); err != nil {
+		return err
+	}
+	err = u.transferRepository.Each(ctx, scope, func(task domain.Task) error {
+		return writer.Write(taskRecord(task))
+	})
+	if err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// taskRecord lists the fields of task in the order of domain.TaskColumns.
+func taskRecord(task domain.Task) []string {
+	blockedBy := make([]string, len(task.BlockedBy))
+	for i, id := range task.BlockedBy {
+		blockedBy[i] = id.Hex()
+	}
+
+	return []string{
+		task.ID.Hex(),
+		toSpreadsheet(task.Title),
+		toSpreadsheet(task.Description),
+		string(task.Status),
+		task.Priority.String(),
+		csvTime(task.DueDate),
+		task.Recurrence,
+		task.TimeZone,
+		csvID(task.ParentID),
+		csvID(task.ProjectID),
+		strings.Join(blockedBy, " "),
+		csvTime(task.CompletedAt),
+		csvTime(&task.CreatedAt),
+		toSpreadsheet(task.CreatedBy),
+		csvTime(&task.UpdatedAt),
+		toSpreadsheet(task.UpdatedBy),
+	}
+}
+
+func csvID(id primitive.ObjectID) string {
+	if id.IsZero() {
+		return ""
+	}
+	return id.Hex()
+}
+
+func csvTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// formulaPrefixes start a formula in a spreadsheet cell.
+const formulaPrefixes = "=+-@\t\r"
+
+// toSpreadsheet quotes free text that a spreadsheet would run as a formula, fromSpreadsheet undoes it.
+func toSpreadsheet(text string) string {
+	if text != "" && strings.ContainsRune(formulaPrefixes, rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
+
+func fromSpreadsheet(text string) string {
+	if len(text) > 1 && text[0] == '\'' && strings.ContainsRune(formulaPrefixes, rune(text[1])) {
+		return text[1:]
+	}
+	return text
+}
+
+// importRow is a task read from line of an imported file, err is why it cannot be imported.
+type importRow struct {
+	line int
+	task domain.Task
+	err  error
+}
+
+// Import validates every row before writing any, the valid rows are then written in one batch together
+// with their history. A row the database rejects, such as a task created with its id since the
+// validation, aborts the unit of work and fails the import with the error of the row.
+func (u *taskTransferUsecase) Import(c context.Context, format domain.TaskFormat, r io.Reader, dryRun bool) (domain.ImportResult, error) {
+	var rows []importRow
+	var err error
+	switch format {
+	case domain.FormatCSV:
+		rows, err = readCSV(r)
+	case domain.FormatNDJSON:
+		rows, err = readNDJSON(r)
+	default:
+		err = formatError()
+	}
+	if err != nil {
+		return domain.ImportResult{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	if err := u.validateImport(ctx, rows); err != nil {
+		return domain.ImportResult{}, err
+	}
+
+	result := domain.ImportResult{DryRun: dryRun, Total: len(rows), Errors: []domain.ImportRowError{}}
+	tasks := []domain.Task{}
+	// lines holds the line of each task
+	lines := []int{}
+	for _, row := range rows {
+		if row.err != nil {
+			result.Errors = append(result.Errors, importRowError(row.line, row.err))
+			continue
+		}
+		tasks = append(tasks, row.task)
+		lines = append(lines, row.line)
+	}
+	result.Valid = len(tasks)
+	if dryRun || len(tasks) == 0 {
+		return result, nil
+	}
+
+	err = u.transactions.WithTransaction(ctx, func(ctx context.Context) error {
+		errs, err := u.transferRepository.Import(ctx, tasks)
+		if err != nil {
+			return err
+		}
+		if i := slices.IndexFunc(errs, func(err error) bool { return err != nil }); i >= 0 {
+			return fmt.Errorf("row %d: %w", lines[i], errs[i])
+		}
+
+		activities := make([]domain.TaskActivity, len(tasks))
+		for i, task := range tasks {
+			activities[i] = created(task)
+		}
+		return u.history.record(ctx, activities...)
+	})
+	if err != nil {
+		return domain.ImportResult{}, err
+	}
+
+	result.Imported = len(tasks)
+	slices.SortFunc(result.Errors, func(a, b domain.ImportRowError) int {
+		return a.Row - b.Row
+	})
+	return result, nil
+}
+
+func importRowError(line int, err error) domain.ImportRowError {
+	var validation *domain.ValidationError
+	if errors.As(err, &validation) {
+		return domain.ImportRowError{Row: line, Field: validation.Field, Message: validation.Message}
+	}
+	if errors.Is(err, domain.ErrDuplicate) {
+		return domain.ImportRowError{Row: line, Field: "id", Message: "a task with this id already exists"}
+	}
+	return domain.ImportRowError{Row: line, Message: err.Error()}
+}
+
+func fileError(message string) error {
+	return &domain.ValidationError{Field: "file", Message: message}
+}
+
+func tooManyRows() error {
+	return fmt.Errorf("%w: at most %d tasks per import", domain.ErrTooLarge, domain.MaxImportRows)
+}
+
+// readCSV reads the header then one task per record, a malformed record fails the whole file since the
+// records after it cannot be told apart.
+func readCSV(r io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(r)
+	// a short record leaves its missing columns empty
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fileError("is empty")
+	} else if err != nil {
+		return nil, fileError(err.Error())
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		// spreadsheets may start the file with a byte order mark
+		columns[strings.TrimSpace(strings.TrimPrefix(name, "\uFEFF"))] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, fileError("the title column is missing")
+	}
+
+	rows := []importRow{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		} else if err != nil {
+			return nil, fileError(err.Error())
+		}
+		if len(rows) == domain.MaxImportRows {
+			return nil, tooManyRows()
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, csvRow(line, record, columns))
+	}
+}
+
+func csvRow(line int, record []string, columns map[string]int) importRow {
+	field := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	row := importRow{line: line}
+	task := &row.task
+	task.Title = fromSpreadsheet(field("title"))
+	task.Description = fromSpreadsheet(field("description"))
+	task.Status = domain.TaskStatus(strings.TrimSpace(field("status")))
+	task.Recurrence = strings.TrimSpace(field("recurrence"))
+	task.TimeZone = strings.TrimSpace(field("timeZone"))
+
+	var err error
+	if task.ID, err = csvObjectID("id", field("id")); err != nil {
+		row.err = err
+		return row
+	}
+	if task.ParentID, err = csvObjectID("parentId", field("parentId")); err != nil {
+		row.err = err
+		return row
+	}
+	if task.ProjectID, err = csvObjectID("projectId", field("projectId")); err != nil {
+		row.err = err
+		return row
+	}
+	for _, hex := range strings.Fields(field("blockedBy")) {
+		id, err := csvObjectID("blockedBy", hex)
+		if err != nil {
+			row.err = err
+			return row
+		}
+		task.BlockedBy = append(task.BlockedBy, id)
+	}
+	if priority := strings.TrimSpace(field("priority")); priority != "" {
+		if task.Priority, err = domain.ParseTaskPriority(priority); err != nil {
+			row.err = err
+			return row
+		}
+	}
+	if task.DueDate, err = csvTimeValue("dueDate", field("dueDate")); err != nil {
+		row.err = err
+		return row
+	}
+	if task.CompletedAt, err = csvTimeValue("completedAt", field("completedAt")); err != nil {
+		row.err = err
+	}
+	return row
+}
+
+func csvObjectID(field, value string) (primitive.ObjectID, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return primitive.NilObjectID, nil
+	}
+	id, err := primitive.ObjectIDFromHex(value)
+	if err != nil {
+		return id, &domain.ValidationError{Field: field, Message: fmt.Sprintf("invalid id %q", value)}
+	}
+	return id, nil
+}
+
+func csvTimeValue(field, value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, &domain.ValidationError{Field: field, Message: "must be an RFC 3339 time"}
+	}
+	return &t, nil
+}
+
+// readNDJSON reads one task per line, blank lines are skipped.
+func readNDJSON(r io.Reader) ([]importRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64<<10), int(domain.MaxImportSize))
+
+	rows := []importRow{}
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		if len(rows) == domain.MaxImportRows {
+			return nil, tooManyRows()
+		}
+
+		row := importRow{line: line}
+		var task domain.Task
+		if err := json.Unmarshal(text, &task); err != nil {
+			row.err = jsonRowError(err)
+		}
+		row.task = importable(task)
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fileError(err.Error())
+	}
+	return rows, nil
+}
+
+func jsonRowError(err error) error {
+	var validation *domain.ValidationError
+	if errors.As(err, &validation) {
+		return validation
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return &domain.ValidationError{Field: typeErr.Field, Message: "must be a " + typeErr.Type.String()}
+	}
+	return fileError("invalid JSON: " + err.Error())
+}
+
+// importable keeps the fields of an exported task that an import reads, the same as the CSV columns.
+func importable(task domain.Task) domain.Task {
+	return domain.Task{
+		ID:          task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      task.Status,
+		Priority:    task.Priority,
+		DueDate:     task.DueDate,
+		Recurrence:  task.Recurrence,
+		TimeZone:    task.TimeZone,
+		ParentID:    task.ParentID,
+		ProjectID:   task.ProjectID,
+		BlockedBy:   task.BlockedBy,
+		CompletedAt: task.CompletedAt,
+	}
+}
+
+// validateImport applies the rules of a created task to every row. A parent or blocker is either another
+// row of the file or an existing task, a row that depends on a row that is not imported is not imported.
+// The project of a row is checked like the project of a created task.
+func (u *taskTransferUsecase) validateImport(c context.Context, rows []importRow) error {
+	// index maps the id of a row to the first row with that id
+	index := map[primitive.ObjectID]int{}
+	for i := range rows {
+		row := &rows[i]
+		if row.err == nil {
+			row.err = validateImportTask(&row.task)
+		}
+
+		id := row.task.ID
+		if id.IsZero() {
+			continue
+		}
+		if first, ok := index[id]; ok {
+			if row.err == nil {
+				row.err = &domain.ValidationError{Field: "id", Message: fmt.Sprintf("duplicate of line %d", rows[first].line)}
+			}
+			continue
+		}
+		index[id] = i
+	}
+
+	lookup := []primitive.ObjectID{}
+	for id := range index {
+		lookup = append(lookup, id)
+	}
+	outside := map[primitive.ObjectID]bool{}
+	for _, row := range rows {
+		for _, ref := range references(row.task) {
+			if _, ok := index[ref]; !ok && !outside[ref] {
+				outside[ref] = true
+				lookup = append(lookup, ref)
+			}
+		}
+	}
+
+	exists := map[primitive.ObjectID]bool{}
+	if len(lookup) > 0 {
+		existing, err := u.taskRepository.FetchByIDs(c, lookup)
+		if err != nil {
+			return err
+		}
+		for _, task := range existing {
+			exists[task.ID] = true
+		}
+	}
+
+	for i := range rows {
+		row := &rows[i]
+		if row.err != nil {
+			continue
+		}
+		if exists[row.task.ID] {
+			row.err = domain.ErrDuplicate
+			continue
+		}
+		for _, ref := range references(row.task) {
+			if _, ok := index[ref]; !ok && !exists[ref] {
+				field, kind := referenceField(row.task, ref)
+				row.err = &domain.ValidationError{Field: field, Message: fmt.Sprintf("%s %s not found", kind, ref.Hex())}
+				break
+			}
+		}
+	}
+	if err := u.validateProjects(c, rows); err != nil {
+		return err
+	}
+
+	markCycles(rows, index, func(task domain.Task) []primitive.ObjectID {
+		if task.ParentID.IsZero() {
+			return nil
+		}
+		return []primitive.ObjectID{task.ParentID}
+	}, &domain.ValidationError{Field: "parentId", Message: "the parent chain loops back to the task"})
+	markCycles(rows, index, func(task domain.Task) []primitive.ObjectID {
+		return task.BlockedBy
+	}, &domain.ValidationError{Field: "blockedBy", Message: "the dependencies form a cycle"})
+
+	failDependents(rows, index)
+	return nil
+}
+
+// validateProjects fails the rows filed under a project the importing user may not write to, each project
+// is checked once.
+func (u *taskTransferUsecase) validateProjects(c context.Context, rows []importRow) error {
+	checked := map[primitive.ObjectID]error{}
+	for i := range rows {
+		row := &rows[i]
+		if row.err != nil || row.task.ProjectID.IsZero() {
+			continue
+		}
+
+		err, ok := checked[row.task.ProjectID]
+		if !ok {
+			err = u.projects.validateTaskProjects(c, row.task.ProjectID)
+			if err != nil && !errors.Is(err, domain.ErrValidation) {
+				return err
+			}
+			checked[row.task.ProjectID] = err
+		}
+		row.err = err
+	}
+	return nil
+}
+
+func validateImportTask(task *domain.Task) error {
+	if strings.TrimSpace(task.Title) == "" {
+		return &domain.ValidationError{Field: "title", Message: "is required"}
+	}
+	if task.Status != "" && !task.Status.Valid() {
+		return &domain.ValidationError{Field: "status", Message: fmt.Sprintf("unknown status %q", task.Status)}
+	}
+	if task.Status != domain.StatusDone {
+		task.CompletedAt = nil
+	}
+	return task.ValidateRecurrence()
+}
+
+func references(task domain.Task) []primitive.ObjectID {
+	if task.ParentID.IsZero() {
+		return task.BlockedBy
+	}
+	return append([]primitive.ObjectID{task.ParentID}, task.BlockedBy...)
+}
+
+func referenceField(task domain.Task, ref primitive.ObjectID) (field, kind string) {
+	if task.ParentID == ref {
+		return "parentId", "parent"
+	}
+	return "blockedBy", "blocker"
+}
+
+// markCycles fails the rows on a loop of edges between rows of the file with err. Existing tasks cannot
+// close a loop, none of them refers to a task that is not imported yet.
+func markCycles(rows []importRow, index map[primitive.ObjectID]int, edges func(domain.Task) []primitive.ObjectID, err error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(rows))
+
+	var visit func(i int, path []int)
+	visit = func(i int, path []int) {
+		state[i] = visiting
+		path = append(path, i)
+		for _, ref := range edges(rows[i].task) {
+			j, ok := index[ref]
+			if !ok {
+				continue
+			}
+			switch state[j] {
+			case visiting:
+				for _, k := range path[slices.Index(path, j):] {
+					if rows[k].err == nil {
+						rows[k].err = err
+					}
+				}
+			case unvisited:
+				visit(j, path)
+			}
+		}
+		state[i] = visited
+	}
+
+	for i := range rows {
+		if state[i] == unvisited {
+			visit(i, nil)
+		}
+	}
+}
+
+// failDependents fails the rows that refer to a failed row, and the rows that refer to those.
+func failDependents(rows []importRow, index map[primitive.ObjectID]int) {
+	dependents := map[int][]int{}
+	failed := []int{}
+	for i, row := range rows {
+		for _, ref := range references(row.task) {
+			if j, ok := index[ref]; ok {
+				dependents[j] = append(dependents[j], i)
+			}
+		}
+		if row.err != nil {
+			failed = append(failed, i)
+		}
+	}
+
+	for len(failed) > 0 {
+		j := failed[0]
+		failed = failed[1:]
+		for _, i := range dependents[j] {
+			if rows[i].err != nil {
+				continue
+			}
+			field, kind := referenceField(rows[i].task, rows[j].task.ID)
+			rows[i].err = &domain.ValidationError{Field: field, Message: fmt.Sprintf("the %s on line %d is not imported", kind, rows[j].line)}
+			failed = append(failed, i)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"context"
+	"io"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTaskTransferUsecase is a mock for the TaskTransferUsecase interface
+type MockTaskTransferUsecase struct {
+	mock.Mock
+}
+
+func (m *MockTaskTransferUsecase) Export(c context.Context, format domain.TaskFormat, w io.Writer) error {
+	args := m.Called(c, format, w)
+	return args.Error(0)
+}
+
+func (m *MockTaskTransferUsecase) Import(c context.Context, format domain.TaskFormat, r io.Reader, dryRun bool) (domain.ImportResult, error) {
+	args := m.Called(c, format, r, dryRun)
+	return args.Get(0).(domain.ImportResult), args.Error(1)
+}
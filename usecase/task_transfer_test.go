@@ -0,0 +1,227 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTaskExport(t *testing.T) {
+	due := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	blocker, project := primitive.NewObjectID(), primitive.NewObjectID()
+	task := domain.Task{
+		ID:        primitive.NewObjectID(),
+		ProjectID: project,
+		Title:     "=HYPERLINK(\"x\")",
+		Status:    domain.StatusTodo,
+		Priority:  domain.PriorityHigh,
+		DueDate:   &due,
+		BlockedBy: []primitive.ObjectID{blocker},
+		CreatedAt: due,
+	}
+
+	newUsecase := func() domain.TaskTransferUsecase {
+		repo := new(repository.MockTaskRepository)
+		repo.On("Each", mock.Anything, domain.TaskScope{AllProjects: true}, mock.Anything).Run(func(args mock.Arguments) {
+			fn := args.Get(2).(func(domain.Task) error)
+			assert.NoError(t, fn(task))
+		}).Return(nil).Once()
+		return usecase.NewTaskTransferUsecase(repo, repo, time.Second*2)
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, newUsecase().Export(context.Background(), domain.FormatCSV, &out))
+
+		records, err := csv.NewReader(&out).ReadAll()
+		assert.NoError(t, err)
+		assert.Equal(t, domain.TaskColumns, records[0])
+		assert.Equal(t, []string{
+			task.ID.Hex(), "'=HYPERLINK(\"x\")", "", "todo", "high", "2025-03-01T09:00:00Z", "", "", "",
+			project.Hex(), blocker.Hex(), "", "2025-03-01T09:00:00Z", "", "", "",
+		}, records[1])
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, newUsecase().Export(context.Background(), domain.FormatNDJSON, &out))
+
+		var actual domain.Task
+		assert.NoError(t, json.Unmarshal(out.Bytes(), &actual))
+		assert.Equal(t, task.ID, actual.ID)
+		assert.Equal(t, task.Title, actual.Title)
+		assert.Equal(t, task.ProjectID, actual.ProjectID)
+		assert.Equal(t, 1, strings.Count(out.String(), "\n"))
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		repo := new(repository.MockTaskRepository)
+		err := usecase.NewTaskTransferUsecase(repo, repo, time.Second*2).Export(context.Background(), "xlsx", &bytes.Buffer{})
+
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		repo.AssertNotCalled(t, "Each", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestTaskImport(t *testing.T) {
+	existing, parent := primitive.NewObjectID(), primitive.NewObjectID()
+
+	t.Run("csv", func(t *testing.T) {
+		missing := primitive.NewObjectID()
+		file := strings.Join([]string{
+			"\uFEFFtitle,id,parentId,priority,dueDate,status",
+			"'=Parent," + parent.Hex() + ",,urgent,2025-03-01T09:00:00Z,todo",
+			"Child,," + parent.Hex() + ",,,",
+			"Orphan,," + missing.Hex() + ",,,",
+			",,,,,",
+			"Late,,,,tomorrow,",
+			"Again," + existing.Hex() + ",,,,",
+		}, "\n")
+
+		repo := new(repository.MockTaskRepository)
+		repo.On("FetchByIDs", mock.Anything, mock.MatchedBy(func(ids []primitive.ObjectID) bool {
+			return len(ids) == 3
+		})).Return([]domain.Task{{ID: existing}}, nil).Once()
+		repo.On("Import", mock.Anything, mock.MatchedBy(func(tasks []domain.Task) bool {
+			return len(tasks) == 2 && tasks[0].Title == "=Parent" && tasks[0].ID == parent &&
+				tasks[0].Priority == domain.PriorityUrgent && tasks[0].DueDate != nil &&
+				tasks[1].ParentID == parent
+		})).Return([]error{nil, nil}, nil).Once()
+
+		result, err := usecase.NewTaskTransferUsecase(repo, repo, time.Second*2).
+			Import(context.Background(), domain.FormatCSV, strings.NewReader(file), false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 6, result.Total)
+		assert.Equal(t, 2, result.Valid)
+		assert.Equal(t, 2, result.Imported)
+		assert.Equal(t, []domain.ImportRowError{
+			{Row: 4, Field: "parentId", Message: fmt.Sprintf("parent %s not found", missing.Hex())},
+			{Row: 5, Field: "title", Message: "is required"},
+			{Row: 6, Field: "dueDate", Message: "must be an RFC 3339 time"},
+			{Row: 7, Field: "id", Message: "a task with this id already exists"},
+		}, result.Errors)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("ndjson dry run", func(t *testing.T) {
+		child := primitive.NewObjectID()
+		file := strings.Join([]string{
+			fmt.Sprintf(`{"id":%q,"title":"Parent","parentId":%q,"version":7}`, parent.Hex(), child.Hex()),
+			fmt.Sprintf(`{"id":%q,"title":"Child","parentId":%q}`, child.Hex(), parent.Hex()),
+			"",
+			fmt.Sprintf(`{"title":"Blocked","blockedBy":[%q]}`, child.Hex()),
+			`{"title":"Done","status":"done","completedAt":"2025-03-01T09:00:00Z"}`,
+			`{"title":"Bad","priority":"someday"}`,
+			`{"title":`,
+		}, "\n")
+
+		repo := new(repository.MockTaskRepository)
+		repo.On("FetchByIDs", mock.Anything, mock.Anything).Return([]domain.Task{}, nil).Once()
+
+		result, err := usecase.NewTaskTransferUsecase(repo, repo, time.Second*2).
+			Import(context.Background(), domain.FormatNDJSON, strings.NewReader(file), true)
+
+		assert.NoError(t, err)
+		assert.True(t, result.DryRun)
+		assert.Equal(t, 6, result.Total)
+		assert.Equal(t, 1, result.Valid)
+		assert.Equal(t, 0, result.Imported)
+		fields := []string{}
+		for _, rowErr := range result.Errors {
+			fields = append(fields, fmt.Sprintf("%d:%s", rowErr.Row, rowErr.Field))
+		}
+		assert.Equal(t, []string{"1:parentId", "2:parentId", "4:blockedBy", "6:priority", "7:file"}, fields)
+		repo.AssertNotCalled(t, "Import", mock.Anything, mock.Anything)
+	})
+
+	t.Run("projects", func(t *testing.T) {
+		fixture, archived := newProjectFixture(false), newProjectFixture(true)
+		projects, workspaces := fixture.repositories()
+		projects.On("FetchByID", mock.Anything, archived.project.ID.Hex()).Return(archived.project, nil).Once()
+		workspaces.On("FetchByID", mock.Anything, archived.workspace.ID.Hex()).Return(archived.workspace, nil).Once()
+		file := strings.Join([]string{
+			"title,projectId",
+			"Ship," + fixture.project.ID.Hex(),
+			"Launch," + fixture.project.ID.Hex(),
+			"Old," + archived.project.ID.Hex(),
+			"Lost,nope",
+		}, "\n")
+
+		repo := new(repository.MockTaskRepository)
+		repo.On("Import", mock.Anything, mock.MatchedBy(func(tasks []domain.Task) bool {
+			return len(tasks) == 2 && tasks[0].ProjectID == fixture.project.ID && tasks[1].ProjectID == fixture.project.ID
+		})).Return([]error{nil, nil}, nil).Once()
+
+		result, err := usecase.NewTaskTransferUsecaseWithDeps(usecase.TaskTransferDeps{Tasks: repo, Transfers: repo, Projects: projects, Workspaces: workspaces}, time.Second*2).
+			Import(as("member"), domain.FormatCSV, strings.NewReader(file), false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Imported)
+		assert.Equal(t, []domain.ImportRowError{
+			{Row: 4, Field: "projectId", Message: fmt.Sprintf("project %s is archived", archived.project.ID.Hex())},
+			{Row: 5, Field: "projectId", Message: `invalid id "nope"`},
+		}, result.Errors)
+		projects.AssertNumberOfCalls(t, "FetchByID", 2)
+	})
+
+	t.Run("outsiders do not import into a project", func(t *testing.T) {
+		fixture := newProjectFixture(false)
+		projects, workspaces := fixture.repositories()
+		repo := new(repository.MockTaskRepository)
+
+		result, err := usecase.NewTaskTransferUsecaseWithDeps(usecase.TaskTransferDeps{Tasks: repo, Transfers: repo, Projects: projects, Workspaces: workspaces}, time.Second*2).
+			Import(as("stranger"), domain.FormatCSV, strings.NewReader("title,projectId\nShip,"+fixture.project.ID.Hex()+"\n"), false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, result.Valid)
+		assert.Equal(t, "projectId", result.Errors[0].Field)
+		repo.AssertNotCalled(t, "Import", mock.Anything, mock.Anything)
+	})
+
+	t.Run("a write error fails the import", func(t *testing.T) {
+		repo := new(repository.MockTaskRepository)
+		history := new(repository.MockActivityRepository)
+		repo.On("Import", mock.Anything, mock.Anything).Return([]error{domain.ErrDuplicate, nil}, nil).Once()
+
+		_, err := usecase.NewTaskTransferUsecaseWithDeps(usecase.TaskTransferDeps{Tasks: repo, Transfers: repo, History: history}, time.Second*2).
+			Import(context.Background(), domain.FormatCSV, strings.NewReader("title\nFirst\nSecond\n"), false)
+
+		assert.ErrorIs(t, err, domain.ErrDuplicate)
+		assert.ErrorContains(t, err, "row 2")
+		history.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		tests := []struct {
+			name string
+			file string
+			err  error
+		}{
+			{name: "empty", file: "", err: domain.ErrValidation},
+			{name: "no title column", file: "name\nTask\n", err: domain.ErrValidation},
+			{name: "bare quote", file: "title\nsay \"hi\n", err: domain.ErrValidation},
+			{name: "too many rows", file: "title\n" + strings.Repeat("Task\n", domain.MaxImportRows+1), err: domain.ErrTooLarge},
+		}
+
+		repo := new(repository.MockTaskRepository)
+		u := usecase.NewTaskTransferUsecase(repo, repo, time.Second*2)
+		for _, tt := range tests {
+			_, err := u.Import(context.Background(), domain.FormatCSV, strings.NewReader(tt.file), false)
+			assert.ErrorIs(t, err, tt.err, tt.name)
+		}
+		repo.AssertNotCalled(t, "Import", mock.Anything, mock.Anything)
+	})
+}
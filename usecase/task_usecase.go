@@ -2,43 +2,521 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/sing3demons/go-backend-clean-architecture/domain"
 )
 
 type taskUsecase struct {
 	taskRepository domain.TaskRepository
+	publisher      domain.EventPublisher
+	history        taskHistory
+	projects       projectAccess
+	transactions   domain.UnitOfWork
 	contextTimeout time.Duration
 }
 
+type noopPublisher struct{}
+
+func (noopPublisher) Publish(context.Context, string, string, any) error {
+	return nil
+}
+
+// noTransaction runs fn on its own, the writes made in fn are not atomic.
+type noTransaction struct{}
+
+func (noTransaction) WithTransaction(c context.Context, fn func(ctx context.Context) error) error {
+	return fn(c)
+}
+
+// orNoTransaction returns transactions, noTransaction{} when it is nil.
+func orNoTransaction(transactions domain.UnitOfWork) domain.UnitOfWork {
+	if transactions == nil {
+		return noTransaction{}
+	}
+	return transactions
+}
+
+// orNoPublisher returns publisher, noopPublisher{} when it is nil.
+func orNoPublisher(publisher domain.EventPublisher) domain.EventPublisher {
+	if publisher == nil {
+		return noopPublisher{}
+	}
+	return publisher
+}
+
+// TaskDeps are the collaborators of the task usecase, every field but Tasks may be nil.
+type TaskDeps struct {
+	Tasks domain.TaskRepository
+	// Publisher receives the status changes, nil publishes nothing.
+	Publisher domain.EventPublisher
+	// History records every change to a task, nil records nothing. A change that cannot be recorded fails.
+	History domain.TaskActivityRepository
+	// Projects and Workspaces restrict the tasks filed under a project to the members of its workspace
+	// and enforce the rules of archived projects, nil leaves projects unchecked.
+	Projects   domain.ProjectRepository
+	Workspaces domain.WorkspaceRepository
+	// Transactions writes a change and its history in one unit of work, nil runs the writes one by one.
+	Transactions domain.UnitOfWork
+}
+
 func NewTaskUsecase(taskRepository domain.TaskRepository, timeout time.Duration) domain.TaskUsecase {
+	return NewTaskUsecaseWithDeps(TaskDeps{Tasks: taskRepository}, timeout)
+}
+
+func NewTaskUsecaseWithDeps(deps TaskDeps, timeout time.Duration) domain.TaskUsecase {
+	return newTaskUsecase(deps, timeout)
+}
+
+func newTaskUsecase(deps TaskDeps, timeout time.Duration) *taskUsecase {
 	return &taskUsecase{
-		taskRepository: taskRepository,
+		taskRepository: deps.Tasks,
+		publisher:      orNoPublisher(deps.Publisher),
+		history:        taskHistory{repository: deps.History},
+		projects:       projectAccess{projects: deps.Projects, workspaces: deps.Workspaces},
+		transactions:   orNoTransaction(deps.Transactions),
 		contextTimeout: timeout,
 	}
 }
 
 func (u *taskUsecase) Create(c context.Context, task *domain.Task) error {
+	if err := prepareNew(task); err != nil {
+		return err
+	}
+	if err := task.ValidateRecurrence(); err != nil {
+		return err
+	}
+
 	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
 	defer cancel()
-	return u.taskRepository.Create(ctx, task)
+
+	if err := u.validateRelations(ctx, task); err != nil {
+		return err
+	}
+	if err := u.projects.validateTaskProjects(ctx, task.ProjectID); err != nil {
+		return err
+	}
+
+	input := *task
+	return u.transactions.WithTransaction(ctx, func(ctx context.Context) error {
+		// A retried transaction starts over from the task as it was given
+		*task = input
+		if err := u.taskRepository.Create(ctx, task); err != nil {
+			return err
+		}
+		return u.history.record(ctx, created(*task))
+	})
+}
+
+// prepareNew accepts only StatusTodo for a new task, any other status is reached through the workflow
+// of Transition. It drops the fields only the server sets.
+func prepareNew(task *domain.Task) error {
+	if task.Status != "" && !task.Status.Valid() {
+		return &domain.ValidationError{Field: "status", Message: fmt.Sprintf("unknown status %q", task.Status)}
+	}
+	if status := task.Status.OrDefault(); status != domain.StatusTodo {
+		return &domain.ValidationError{Field: "status", Message: fmt.Sprintf("a new task starts in %s, not %s", domain.StatusTodo, status)}
+	}
+	task.ClearServerFields()
+	return nil
 }
 
 func (u *taskUsecase) FetchByUserID(c context.Context, userID string) ([]domain.Task, error) {
 	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
 	defer cancel()
-	return u.taskRepository.FetchByUserID(ctx, userID)
+
+	if !u.projects.enabled() {
+		return u.taskRepository.FetchByUserID(ctx, userID)
+	}
+	filter, err := u.projects.visible(ctx, domain.TaskFilter{UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+	return u.taskRepository.FetchByFilter(ctx, filter)
 }
 
 func (u *taskUsecase) FetchByTaskID(c context.Context, taskID string) (domain.Task, error) {
 	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
 	defer cancel()
-	return u.taskRepository.FetchByTaskID(ctx, taskID)
+	return u.projects.fetchTask(ctx, u.taskRepository, taskID)
 }
 
 func (u *taskUsecase) FetchAll(c context.Context) ([]domain.Task, error) {
 	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
 	defer cancel()
-	return u.taskRepository.FetchAll(ctx)
+
+	if !u.projects.enabled() {
+		return u.taskRepository.FetchAll(ctx)
+	}
+	filter, err := u.projects.visible(ctx, domain.TaskFilter{})
+	if err != nil {
+		return nil, err
+	}
+	return u.taskRepository.FetchByFilter(ctx, filter)
+}
+
+func (u *taskUsecase) FetchByFilter(c context.Context, filter domain.TaskFilter) ([]domain.Task, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	filter, err := u.projects.visible(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return u.taskRepository.FetchByFilter(ctx, filter)
+}
+
+// Search passes only the words of query to the text index: quotes, negations and other operators
+// of $text are dropped, so every word matches on its own.
+func (u *taskUsecase) Search(c context.Context, query string, page domain.PageRequest) (domain.Page[domain.TaskMatch], error) {
+	page = page.Normalize()
+	result := domain.Page[domain.TaskMatch]{Items: []domain.TaskMatch{}, Page: page.Page, Size: page.Size}
+
+	terms, err := searchTerms(query)
+	if err != nil {
+		return result, err
+	}
+
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	scope, err := u.projects.scope(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	matches, total, err := u.taskRepository.Search(ctx, scope, strings.Join(terms, " "), page)
+	if err != nil {
+		return result, err
+	}
+
+	for i := range matches {
+		matches[i].Highlights = highlights(matches[i].Task, terms)
+	}
+	result.Items, result.Total = matches, total
+	return result, nil
+}
+
+// searchTerms returns the distinct words of query, at most domain.MaxSearchTerms of them.
+func searchTerms(query string) ([]string, error) {
+	if utf8.RuneCountInString(query) > domain.MaxSearchQueryLength {
+		return nil, &domain.ValidationError{Field: "q", Message: fmt.Sprintf("must be at most %d characters", domain.MaxSearchQueryLength)}
+	}
+
+	var terms []string
+	seen := make(map[string]bool)
+	for _, term := range domain.SearchTerms(query) {
+		key := strings.ToLower(term)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		terms = append(terms, term)
+		if len(terms) == domain.MaxSearchTerms {
+			break
+		}
+	}
+
+	if len(terms) == 0 {
+		return nil, &domain.ValidationError{Field: "q", Message: "must contain a word"}
+	}
+	return terms, nil
+}
+
+func highlights(task domain.Task, terms []string) map[string]string {
+	fields := make(map[string]string)
+	for field, text := range map[string]string{"title": task.Title, "description": task.Description} {
+		if highlighted, ok := domain.Highlight(text, terms); ok {
+			fields[field] = highlighted
+		}
+	}
+	return fields
+}
+
+func (u *taskUsecase) Update(c context.Context, task *domain.Task) error {
+	if err := task.ValidateRecurrence(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	if err := u.validateRelations(ctx, task); err != nil {
+		return err
+	}
+
+	// the task as it was, for the project it leaves and the changes recorded in the history
+	var before domain.Task
+	if u.history.enabled() || u.projects.enabled() {
+		current, err := u.taskRepository.FetchByTaskID(ctx, task.ID.Hex())
+		if err != nil {
+			return err
+		}
+		before = current
+	}
+	if err := u.projects.readable(ctx, before); err != nil {
+		return err
+	}
+	if err := u.projects.validateTaskProjects(ctx, before.ProjectID, task.ProjectID); err != nil {
+		return err
+	}
+
+	input := *task
+	return u.transactions.WithTransaction(ctx, func(ctx context.Context) error {
+		*task = input
+		if err := u.taskRepository.Update(ctx, task); err != nil {
+			return err
+		}
+		return u.history.record(ctx, changed(domain.ActivityUpdated, before, *task))
+	})
+}
+
+// validateRelations checks that the parent and blockers of the task exist and that neither the parent
+// chain nor the dependency graph loops back to the task. A new task has no id and cannot close a loop.
+func (u *taskUsecase) validateRelations(c context.Context, task *domain.Task) error {
+	if !task.ParentID.IsZero() {
+		if task.ParentID == task.ID {
+			return &domain.ValidationError{Field: "parentId", Message: "a task cannot be its own parent"}
+		}
+
+		ancestors, err := u.taskRepository.FetchAncestorIDs(c, task.ParentID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return &domain.ValidationError{Field: "parentId", Message: fmt.Sprintf("parent %s not found", task.ParentID.Hex())}
+		}
+		if err != nil {
+			return err
+		}
+		if !task.ID.IsZero() && slices.Contains(ancestors, task.ID) {
+			return &domain.ValidationError{Field: "parentId", Message: "the parent is a subtask of the task"}
+		}
+	}
+
+	if len(task.BlockedBy) == 0 {
+		return nil
+	}
+	if !task.ID.IsZero() && slices.Contains(task.BlockedBy, task.ID) {
+		return &domain.ValidationError{Field: "blockedBy", Message: "a task cannot block itself"}
+	}
+
+	blockers, err := u.taskRepository.FetchBlockerIDs(c, task.BlockedBy)
+	if err != nil {
+		return err
+	}
+	for _, id := range task.BlockedBy {
+		if !slices.Contains(blockers, id) {
+			return &domain.ValidationError{Field: "blockedBy", Message: fmt.Sprintf("blocker %s not found", id.Hex())}
+		}
+	}
+	if !task.ID.IsZero() && slices.Contains(blockers, task.ID) {
+		return &domain.ValidationError{Field: "blockedBy", Message: "the dependencies form a cycle"}
+	}
+	return nil
+}
+
+// Transition returns the updated task together with an ErrEventNotPublished error when the status
+// was saved but the event could not be published. Completing a recurring task creates its next
+// occurrence in the same unit of work as the status and its history, the transition fails when either
+// cannot be written.
+func (u *taskUsecase) Transition(c context.Context, taskID string, status domain.TaskStatus) (domain.Task, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	before, err := u.taskRepository.FetchByTaskID(ctx, taskID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	if err := u.projects.readable(ctx, before); err != nil {
+		return domain.Task{}, err
+	}
+
+	from := before.Status.OrDefault()
+	if err := from.ValidateTransition(status); err != nil {
+		return domain.Task{}, err
+	}
+	if status != domain.StatusArchived {
+		if err := u.projects.writable(ctx, before); err != nil {
+			return domain.Task{}, err
+		}
+	}
+	if status == domain.StatusDone {
+		if err := u.checkBlockers(ctx, before); err != nil {
+			return domain.Task{}, err
+		}
+	}
+
+	var task domain.Task
+	err = u.transactions.WithTransaction(ctx, func(ctx context.Context) error {
+		// A retried transaction starts over from the task as it was read
+		task = before
+		task.Status = status
+		if err := u.taskRepository.UpdateStatus(ctx, &task); err != nil {
+			return err
+		}
+		activities := []domain.TaskActivity{changed(domain.ActivityStatusChanged, before, task)}
+		if status == domain.StatusDone {
+			next, err := u.createNextOccurrence(ctx, &task)
+			if err != nil {
+				return err
+			}
+			if next != nil {
+				activities = append(activities, created(*next))
+			}
+		}
+		return u.history.record(ctx, activities...)
+	})
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	event := domain.TaskStatusChanged{
+		TaskID: task.ID.Hex(),
+		From:   from,
+		To:     status,
+		Actor:  domain.ActorFromContext(c),
+		At:     task.UpdatedAt,
+	}
+	if err := u.publisher.Publish(ctx, domain.TopicTaskStatusChanged, event.TaskID, event); err != nil {
+		return task, fmt.Errorf("%w: %v", domain.ErrEventNotPublished, err)
+	}
+	return task, nil
+}
+
+// checkBlockers reports the open blockers of the task, deleted blockers no longer block it.
+func (u *taskUsecase) checkBlockers(c context.Context, task domain.Task) error {
+	if len(task.BlockedBy) == 0 {
+		return nil
+	}
+
+	blockers, err := u.taskRepository.FetchByIDs(c, task.BlockedBy)
+	if err != nil {
+		return err
+	}
+
+	var open []string
+	for _, blocker := range blockers {
+		if !blocker.Status.Closed() {
+			open = append(open, blocker.ID.Hex())
+		}
+	}
+	if len(open) > 0 {
+		return &domain.ValidationError{Field: "status", Message: "blocked by open tasks " + strings.Join(open, ", ")}
+	}
+	return nil
+}
+
+// createNextOccurrence is idempotent, the occurrence may exist when the task was completed before. It
+// returns the occurrence it created, nil when there was none to create.
+func (u *taskUsecase) createNextOccurrence(c context.Context, task *domain.Task) (*domain.Task, error) {
+	next, ok, err := task.NextOccurrence()
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	if err := u.taskRepository.Create(c, &next); err != nil {
+		if errors.Is(err, domain.ErrDuplicate) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &next, nil
+}
+
+// Delete and Restore refuse the tasks of an archived project, like every task write.
+func (u *taskUsecase) Delete(c context.Context, taskID string) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	if u.projects.enabled() {
+		task, err := u.taskRepository.FetchByTaskID(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if err := u.projects.writable(ctx, task); err != nil {
+			return err
+		}
+	}
+
+	return u.transactions.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := u.taskRepository.Delete(ctx, taskID); err != nil {
+			return err
+		}
+		return u.history.record(ctx, removed(domain.ActivityDeleted, taskID))
+	})
+}
+
+func (u *taskUsecase) Restore(c context.Context, taskID string) (domain.Task, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	if u.projects.enabled() {
+		task, err := u.taskRepository.FetchDeletedByTaskID(ctx, taskID)
+		if err != nil {
+			return domain.Task{}, err
+		}
+		if err := u.projects.writable(ctx, task); err != nil {
+			return domain.Task{}, err
+		}
+	}
+
+	err := u.transactions.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := u.taskRepository.Restore(ctx, taskID); err != nil {
+			return err
+		}
+		return u.history.record(ctx, removed(domain.ActivityRestored, taskID))
+	})
+	if err != nil {
+		return domain.Task{}, err
+	}
+	return u.taskRepository.FetchByTaskID(ctx, taskID)
+}
+
+// FetchTree leaves out the subtasks the actor of c may not read, together with their own subtasks.
+func (u *taskUsecase) FetchTree(c context.Context, taskID string) (domain.TaskTree, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	tree, err := u.taskRepository.FetchTree(ctx, taskID)
+	if err != nil {
+		return domain.TaskTree{}, err
+	}
+	if err := u.projects.readable(ctx, tree.Task); err != nil {
+		return domain.TaskTree{}, err
+	}
+	if !u.projects.enabled() || len(tree.Subtasks) == 0 {
+		return tree, nil
+	}
+
+	scope, err := u.projects.scope(ctx)
+	if err != nil {
+		return domain.TaskTree{}, err
+	}
+	pruneSubtasks(&tree, scope)
+	return tree, nil
+}
+
+func pruneSubtasks(tree *domain.TaskTree, scope domain.TaskScope) {
+	tree.Subtasks = slices.DeleteFunc(tree.Subtasks, func(subtask *domain.TaskTree) bool {
+		return !scope.Includes(subtask.Task)
+	})
+	for _, subtask := range tree.Subtasks {
+		pruneSubtasks(subtask, scope)
+	}
+}
+
+// FetchDeleted lists the trash for the administrators, it is not limited to the workspaces of the actor.
+func (u *taskUsecase) FetchDeleted(c context.Context) ([]domain.Task, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+	return u.taskRepository.FetchDeleted(ctx)
+}
+
+// PurgeDeleted is not bound by contextTimeout, a purge may remove many documents.
+func (u *taskUsecase) PurgeDeleted(c context.Context, retention time.Duration) (int64, error) {
+	return u.taskRepository.PurgeDeleted(c, retention)
 }
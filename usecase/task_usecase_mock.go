@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/sing3demons/go-backend-clean-architecture/domain"
 	"github.com/stretchr/testify/mock"
@@ -31,3 +32,48 @@ func (m *MockTaskUsecase) FetchAll(c context.Context) ([]domain.Task, error) {
 	args := m.Called(c)
 	return args.Get(0).([]domain.Task), args.Error(1)
 }
+
+func (m *MockTaskUsecase) FetchByFilter(c context.Context, filter domain.TaskFilter) ([]domain.Task, error) {
+	args := m.Called(c, filter)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskUsecase) Update(c context.Context, task *domain.Task) error {
+	args := m.Called(c, task)
+	return args.Error(0)
+}
+
+func (m *MockTaskUsecase) Transition(c context.Context, taskID string, status domain.TaskStatus) (domain.Task, error) {
+	args := m.Called(c, taskID, status)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskUsecase) Delete(c context.Context, taskID string) error {
+	args := m.Called(c, taskID)
+	return args.Error(0)
+}
+
+func (m *MockTaskUsecase) Restore(c context.Context, taskID string) (domain.Task, error) {
+	args := m.Called(c, taskID)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskUsecase) FetchTree(c context.Context, taskID string) (domain.TaskTree, error) {
+	args := m.Called(c, taskID)
+	return args.Get(0).(domain.TaskTree), args.Error(1)
+}
+
+func (m *MockTaskUsecase) Search(c context.Context, query string, page domain.PageRequest) (domain.Page[domain.TaskMatch], error) {
+	args := m.Called(c, query, page)
+	return args.Get(0).(domain.Page[domain.TaskMatch]), args.Error(1)
+}
+
+func (m *MockTaskUsecase) FetchDeleted(c context.Context) ([]domain.Task, error) {
+	args := m.Called(c)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskUsecase) PurgeDeleted(c context.Context, retention time.Duration) (int64, error) {
+	args := m.Called(c, retention)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/mongo/mocks"
 	"github.com/sing3demons/go-backend-clean-architecture/repository"
 	"github.com/sing3demons/go-backend-clean-architecture/usecase"
 	"github.com/stretchr/testify/assert"
@@ -93,6 +94,38 @@ func TestCreate(t *testing.T) {
 
 		mockTaskRepository.AssertExpectations(t)
 	})
+	t.Run("server fields are dropped", func(t *testing.T) {
+		at := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+		mockTask := domain.Task{
+			Title:       "Test Title",
+			Status:      domain.StatusTodo,
+			CompletedAt: &at,
+			RemindedAt:  &at,
+			DeletedAt:   &at,
+			SeriesID:    primitive.NewObjectID(),
+			Occurrence:  3,
+		}
+
+		mockTaskRepository.On("Create", mock.Anything, mock.MatchedBy(func(task *domain.Task) bool {
+			return task.CompletedAt == nil && task.RemindedAt == nil && task.DeletedAt == nil &&
+				task.SeriesID.IsZero() && task.Occurrence == 0
+		})).Return(nil).Once()
+
+		err := usecase.NewTaskUsecase(mockTaskRepository, time.Second*2).Create(context.Background(), &mockTask)
+
+		assert.NoError(t, err)
+		mockTaskRepository.AssertExpectations(t)
+	})
+	t.Run("starts in todo", func(t *testing.T) {
+		repo := new(repository.MockTaskRepository)
+		u := usecase.NewTaskUsecase(repo, time.Second*2)
+
+		for _, status := range []domain.TaskStatus{domain.StatusInProgress, domain.StatusDone, domain.StatusArchived, "later"} {
+			err := u.Create(context.Background(), &domain.Task{Title: "Test Title", Status: status})
+			assert.ErrorIs(t, err, domain.ErrValidation, status)
+		}
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
 }
 
 func TestFetchByTaskID(t *testing.T) {
@@ -132,3 +165,320 @@ func TestFetchByTaskID(t *testing.T) {
 		mockTaskRepository.AssertExpectations(t)
 	})
 }
+
+func TestRestore(t *testing.T) {
+	mockTaskRepository := new(repository.MockTaskRepository)
+	taskID := primitive.NewObjectID().Hex()
+
+	t.Run("success", func(t *testing.T) {
+		mockTask := domain.Task{Title: "Test_Title"}
+
+		mockTaskRepository.On("Restore", mock.Anything, taskID).Return(nil).Once()
+		mockTaskRepository.On("FetchByTaskID", mock.Anything, taskID).Return(mockTask, nil).Once()
+
+		u := usecase.NewTaskUsecase(mockTaskRepository, time.Second*2)
+
+		task, err := u.Restore(context.Background(), taskID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, mockTask, task)
+
+		mockTaskRepository.AssertExpectations(t)
+	})
+
+	t.Run("not deleted", func(t *testing.T) {
+		mockTaskRepository.On("Restore", mock.Anything, taskID).Return(domain.ErrNotFound).Once()
+
+		u := usecase.NewTaskUsecase(mockTaskRepository, time.Second*2)
+
+		_, err := u.Restore(context.Background(), taskID)
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		mockTaskRepository.AssertExpectations(t)
+	})
+}
+
+type mockPublisher struct {
+	mock.Mock
+}
+
+func (m *mockPublisher) Publish(c context.Context, topic, key string, payload any) error {
+	args := m.Called(c, topic, key, payload)
+	return args.Error(0)
+}
+
+func TestTransition(t *testing.T) {
+	taskObjectID := primitive.NewObjectID()
+	taskID := taskObjectID.Hex()
+	updatedAt := time.Date(2025, 2, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		mockTaskRepository := new(repository.MockTaskRepository)
+		publisher := new(mockPublisher)
+
+		mockTaskRepository.On("FetchByTaskID", mock.Anything, taskID).Return(domain.Task{ID: taskObjectID, Version: 1}, nil).Once()
+		mockTaskRepository.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(task *domain.Task) bool {
+			return task.Status == domain.StatusInProgress && task.Version == 1
+		})).Run(func(args mock.Arguments) {
+			task := args.Get(1).(*domain.Task)
+			task.Version, task.UpdatedAt = 2, updatedAt
+		}).Return(nil).Once()
+		publisher.On("Publish", mock.Anything, domain.TopicTaskStatusChanged, taskID, domain.TaskStatusChanged{
+			TaskID: taskID,
+			From:   domain.StatusTodo,
+			To:     domain.StatusInProgress,
+			Actor:  "user-1",
+			At:     updatedAt,
+		}).Return(nil).Once()
+
+		u := usecase.NewTaskUsecaseWithDeps(usecase.TaskDeps{Tasks: mockTaskRepository, Publisher: publisher}, time.Second*2)
+
+		task, err := u.Transition(domain.WithActor(context.Background(), "user-1"), taskID, domain.StatusInProgress)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StatusInProgress, task.Status)
+		assert.Equal(t, int64(2), task.Version)
+
+		mockTaskRepository.AssertExpectations(t)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("illegal move", func(t *testing.T) {
+		mockTaskRepository := new(repository.MockTaskRepository)
+		publisher := new(mockPublisher)
+
+		mockTaskRepository.On("FetchByTaskID", mock.Anything, taskID).Return(domain.Task{ID: taskObjectID, Status: domain.StatusArchived}, nil).Once()
+
+		u := usecase.NewTaskUsecaseWithDeps(usecase.TaskDeps{Tasks: mockTaskRepository, Publisher: publisher}, time.Second*2)
+
+		_, err := u.Transition(context.Background(), taskID, domain.StatusDone)
+
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		mockTaskRepository.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+		publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("publish failure", func(t *testing.T) {
+		mockTaskRepository := new(repository.MockTaskRepository)
+		publisher := new(mockPublisher)
+
+		mockTaskRepository.On("FetchByTaskID", mock.Anything, taskID).Return(domain.Task{ID: taskObjectID}, nil).Once()
+		mockTaskRepository.On("UpdateStatus", mock.Anything, mock.Anything).Return(nil).Once()
+		publisher.On("Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("broker down")).Once()
+
+		u := usecase.NewTaskUsecaseWithDeps(usecase.TaskDeps{Tasks: mockTaskRepository, Publisher: publisher}, time.Second*2)
+
+		task, err := u.Transition(context.Background(), taskID, domain.StatusDone)
+
+		assert.ErrorIs(t, err, domain.ErrEventNotPublished)
+		assert.Equal(t, domain.StatusDone, task.Status)
+	})
+}
+
+func TestTransitionRecurring(t *testing.T) {
+	taskObjectID := primitive.NewObjectID()
+	taskID := taskObjectID.Hex()
+	due := time.Date(2025, 2, 3, 9, 0, 0, 0, time.UTC)
+	recurring := domain.Task{ID: taskObjectID, Title: "standup", Version: 1, Recurrence: "FREQ=DAILY", DueDate: &due}
+
+	newUsecase := func(createErr error) (domain.TaskUsecase, *repository.MockTaskRepository) {
+		mockTaskRepository := new(repository.MockTaskRepository)
+		publisher := new(mockPublisher)
+
+		mockTaskRepository.On("FetchByTaskID", mock.Anything, taskID).Return(recurring, nil).Once()
+		mockTaskRepository.On("UpdateStatus", mock.Anything, mock.Anything).Return(nil).Once()
+		mockTaskRepository.On("Create", mock.Anything, mock.MatchedBy(func(task *domain.Task) bool {
+			return task.DueDate.Equal(due.AddDate(0, 0, 1)) && task.SeriesID == taskObjectID && task.Occurrence == 2
+		})).Return(createErr).Once()
+		publisher.On("Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+		return usecase.NewTaskUsecaseWithDeps(usecase.TaskDeps{Tasks: mockTaskRepository, Publisher: publisher}, time.Second*2), mockTaskRepository
+	}
+
+	t.Run("done creates the next occurrence", func(t *testing.T) {
+		u, mockTaskRepository := newUsecase(nil)
+
+		task, err := u.Transition(context.Background(), taskID, domain.StatusDone)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StatusDone, task.Status)
+		mockTaskRepository.AssertExpectations(t)
+	})
+
+	t.Run("existing occurrence", func(t *testing.T) {
+		u, _ := newUsecase(domain.ErrDuplicate)
+
+		_, err := u.Transition(context.Background(), taskID, domain.StatusDone)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("create failure fails the transition", func(t *testing.T) {
+		u, _ := newUsecase(errors.New("Unexpected"))
+
+		_, err := u.Transition(context.Background(), taskID, domain.StatusDone)
+
+		assert.EqualError(t, err, "Unexpected")
+	})
+
+	t.Run("one transaction", func(t *testing.T) {
+		mockTaskRepository := new(repository.MockTaskRepository)
+		publisher := new(mockPublisher)
+		transactions := mocks.NewUnitOfWork(t)
+
+		mockTaskRepository.On("FetchByTaskID", mock.Anything, taskID).Return(recurring, nil).Once()
+		mockTaskRepository.On("UpdateStatus", mock.Anything, mock.Anything).Return(nil).Once()
+		mockTaskRepository.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+		transactions.On("WithTransaction", mock.Anything, mock.Anything).Return(nil).Once()
+		publisher.On("Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+		u := usecase.NewTaskUsecaseWithDeps(usecase.TaskDeps{Tasks: mockTaskRepository, Publisher: publisher, Transactions: transactions}, time.Second*2)
+
+		_, err := u.Transition(context.Background(), taskID, domain.StatusDone)
+
+		assert.NoError(t, err)
+		mockTaskRepository.AssertExpectations(t)
+	})
+
+	t.Run("aborted transaction", func(t *testing.T) {
+		mockTaskRepository := new(repository.MockTaskRepository)
+		publisher := new(mockPublisher)
+		transactions := mocks.NewUnitOfWork(t)
+
+		mockTaskRepository.On("FetchByTaskID", mock.Anything, taskID).Return(recurring, nil).Once()
+		transactions.On("WithTransaction", mock.Anything, mock.Anything).Return(assert.AnError).Once()
+
+		u := usecase.NewTaskUsecaseWithDeps(usecase.TaskDeps{Tasks: mockTaskRepository, Publisher: publisher, Transactions: transactions}, time.Second*2)
+
+		_, err := u.Transition(context.Background(), taskID, domain.StatusDone)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("other statuses do not recur", func(t *testing.T) {
+		mockTaskRepository := new(repository.MockTaskRepository)
+		mockTaskRepository.On("FetchByTaskID", mock.Anything, taskID).Return(recurring, nil).Once()
+		mockTaskRepository.On("UpdateStatus", mock.Anything, mock.Anything).Return(nil).Once()
+
+		u := usecase.NewTaskUsecase(mockTaskRepository, time.Second*2)
+		_, err := u.Transition(context.Background(), taskID, domain.StatusInProgress)
+
+		assert.NoError(t, err)
+		mockTaskRepository.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestCreateRecurrenceValidation(t *testing.T) {
+	mockTaskRepository := new(repository.MockTaskRepository)
+	u := usecase.NewTaskUsecase(mockTaskRepository, time.Second*2)
+
+	err := u.Create(context.Background(), &domain.Task{Title: "standup", Recurrence: "FREQ=DAILY;BYHOUR=9"})
+
+	assert.ErrorIs(t, err, domain.ErrValidation)
+	mockTaskRepository.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestTaskRelations(t *testing.T) {
+	taskID, parentID, blockerID := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+
+	validationField := func(t *testing.T, err error) string {
+		var validationErr *domain.ValidationError
+		if assert.ErrorAs(t, err, &validationErr) {
+			return validationErr.Field
+		}
+		return ""
+	}
+
+	t.Run("create subtask", func(t *testing.T) {
+		mockTaskRepository := new(repository.MockTaskRepository)
+		mockTaskRepository.On("FetchAncestorIDs", mock.Anything, parentID).Return([]primitive.ObjectID{}, nil).Once()
+		mockTaskRepository.On("FetchBlockerIDs", mock.Anything, []primitive.ObjectID{blockerID}).Return([]primitive.ObjectID{blockerID}, nil).Once()
+		mockTaskRepository.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+
+		u := usecase.NewTaskUsecase(mockTaskRepository, time.Second*2)
+		err := u.Create(context.Background(), &domain.Task{Title: "subtask", ParentID: parentID, BlockedBy: []primitive.ObjectID{blockerID}})
+
+		assert.NoError(t, err)
+		mockTaskRepository.AssertExpectations(t)
+	})
+
+	t.Run("missing parent", func(t *testing.T) {
+		mockTaskRepository := new(repository.MockTaskRepository)
+		mockTaskRepository.On("FetchAncestorIDs", mock.Anything, parentID).Return(nil, domain.ErrNotFound).Once()
+
+		u := usecase.NewTaskUsecase(mockTaskRepository, time.Second*2)
+		err := u.Create(context.Background(), &domain.Task{Title: "subtask", ParentID: parentID})
+
+		assert.Equal(t, "parentId", validationField(t, err))
+		mockTaskRepository.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("parent is a descendant", func(t *testing.T) {
+		mockTaskRepository := new(repository.MockTaskRepository)
+		mockTaskRepository.On("FetchAncestorIDs", mock.Anything, parentID).Return([]primitive.ObjectID{taskID}, nil).Once()
+
+		u := usecase.NewTaskUsecase(mockTaskRepository, time.Second*2)
+		err := u.Update(context.Background(), &domain.Task{ID: taskID, ParentID: parentID, Version: 1})
+
+		assert.Equal(t, "parentId", validationField(t, err))
+		mockTaskRepository.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("own parent", func(t *testing.T) {
+		u := usecase.NewTaskUsecase(new(repository.MockTaskRepository), time.Second*2)
+
+		err := u.Update(context.Background(), &domain.Task{ID: taskID, ParentID: taskID, Version: 1})
+
+		assert.Equal(t, "parentId", validationField(t, err))
+	})
+
+	t.Run("dependency cycle", func(t *testing.T) {
+		mockTaskRepository := new(repository.MockTaskRepository)
+		// blocker is blocked by the task being updated
+		mockTaskRepository.On("FetchBlockerIDs", mock.Anything, []primitive.ObjectID{blockerID}).Return([]primitive.ObjectID{blockerID, taskID}, nil).Once()
+
+		u := usecase.NewTaskUsecase(mockTaskRepository, time.Second*2)
+		err := u.Update(context.Background(), &domain.Task{ID: taskID, BlockedBy: []primitive.ObjectID{blockerID}, Version: 1})
+
+		assert.Equal(t, "blockedBy", validationField(t, err))
+		mockTaskRepository.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("missing blocker", func(t *testing.T) {
+		mockTaskRepository := new(repository.MockTaskRepository)
+		mockTaskRepository.On("FetchBlockerIDs", mock.Anything, []primitive.ObjectID{blockerID}).Return([]primitive.ObjectID{}, nil).Once()
+
+		u := usecase.NewTaskUsecase(mockTaskRepository, time.Second*2)
+		err := u.Create(context.Background(), &domain.Task{Title: "task", BlockedBy: []primitive.ObjectID{blockerID}})
+
+		assert.Equal(t, "blockedBy", validationField(t, err))
+	})
+
+	t.Run("done with open blockers", func(t *testing.T) {
+		mockTaskRepository := new(repository.MockTaskRepository)
+		mockTaskRepository.On("FetchByTaskID", mock.Anything, taskID.Hex()).Return(domain.Task{ID: taskID, BlockedBy: []primitive.ObjectID{blockerID}}, nil).Once()
+		mockTaskRepository.On("FetchByIDs", mock.Anything, []primitive.ObjectID{blockerID}).Return([]domain.Task{{ID: blockerID, Status: domain.StatusInProgress}}, nil).Once()
+
+		u := usecase.NewTaskUsecase(mockTaskRepository, time.Second*2)
+		_, err := u.Transition(context.Background(), taskID.Hex(), domain.StatusDone)
+
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		assert.Contains(t, err.Error(), blockerID.Hex())
+		mockTaskRepository.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("done with closed blockers", func(t *testing.T) {
+		mockTaskRepository := new(repository.MockTaskRepository)
+		mockTaskRepository.On("FetchByTaskID", mock.Anything, taskID.Hex()).Return(domain.Task{ID: taskID, BlockedBy: []primitive.ObjectID{blockerID}}, nil).Once()
+		mockTaskRepository.On("FetchByIDs", mock.Anything, []primitive.ObjectID{blockerID}).Return([]domain.Task{{ID: blockerID, Status: domain.StatusArchived}}, nil).Once()
+		mockTaskRepository.On("UpdateStatus", mock.Anything, mock.Anything).Return(nil).Once()
+
+		u := usecase.NewTaskUsecase(mockTaskRepository, time.Second*2)
+		task, err := u.Transition(context.Background(), taskID.Hex(), domain.StatusDone)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StatusDone, task.Status)
+	})
+}
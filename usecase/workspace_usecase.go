@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+)
+
+type workspaceUsecase struct {
+	workspaceRepository domain.WorkspaceRepository
+	contextTimeout      time.Duration
+}
+
+func NewWorkspaceUsecase(workspaceRepository domain.WorkspaceRepository, timeout time.Duration) domain.WorkspaceUsecase {
+	return &workspaceUsecase{
+		workspaceRepository: workspaceRepository,
+		contextTimeout:      timeout,
+	}
+}
+
+// workspaceOf returns the workspace with the role of the actor of c in it. A workspace the actor is not a
+// member of is reported as ErrNotFound, its existence is not disclosed.
+func workspaceOf(c context.Context, workspaces domain.WorkspaceRepository, workspaceID string) (domain.Workspace, domain.WorkspaceRole, error) {
+	actor := domain.ActorFromContext(c)
+	if actor == "" {
+		return domain.Workspace{}, "", domain.ErrUnauthenticated
+	}
+
+	workspace, err := workspaces.FetchByID(c, workspaceID)
+	if err != nil {
+		return domain.Workspace{}, "", err
+	}
+
+	role, ok := workspace.Role(actor)
+	if !ok {
+		return domain.Workspace{}, "", domain.ErrNotFound
+	}
+	return workspace, role, nil
+}
+
+// validateName trims name and checks it is set and at most domain.MaxNameLength characters.
+func validateName(name *string) error {
+	*name = strings.TrimSpace(*name)
+	if *name == "" {
+		return &domain.ValidationError{Field: "name", Message: "is required"}
+	}
+	if utf8.RuneCountInString(*name) > domain.MaxNameLength {
+		return &domain.ValidationError{Field: "name", Message: fmt.Sprintf("must be at most %d characters", domain.MaxNameLength)}
+	}
+	return nil
+}
+
+func validateMember(member *domain.Member) error {
+	member.UserID = strings.TrimSpace(member.UserID)
+	if member.UserID == "" {
+		return &domain.ValidationError{Field: "userId", Message: "is required"}
+	}
+	if member.Role == "" {
+		member.Role = domain.RoleMember
+	}
+	if !member.Role.Valid() {
+		return &domain.ValidationError{Field: "role", Message: fmt.Sprintf("unknown role %q", member.Role)}
+	}
+	return nil
+}
+
+// Create keeps the members listed in workspace, the actor is added or promoted to owner.
+func (u *workspaceUsecase) Create(c context.Context, workspace *domain.Workspace) error {
+	actor := domain.ActorFromContext(c)
+	if actor == "" {
+		return domain.ErrUnauthenticated
+	}
+	if err := validateName(&workspace.Name); err != nil {
+		return err
+	}
+
+	members := []domain.Member{}
+	for _, member := range workspace.Members {
+		if err := validateMember(&member); err != nil {
+			return err
+		}
+		members = setMember(members, member)
+	}
+	workspace.Members = setMember(members, domain.Member{UserID: actor, Role: domain.RoleOwner})
+
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+	return u.workspaceRepository.Create(ctx, workspace)
+}
+
+func (u *workspaceUsecase) FetchByID(c context.Context, workspaceID string) (domain.Workspace, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	workspace, _, err := workspaceOf(ctx, u.workspaceRepository, workspaceID)
+	return workspace, err
+}
+
+func (u *workspaceUsecase) FetchMine(c context.Context) ([]domain.Workspace, error) {
+	actor := domain.ActorFromContext(c)
+	if actor == "" {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+	return u.workspaceRepository.FetchByMember(ctx, actor)
+}
+
+func (u *workspaceUsecase) SetMember(c context.Context, workspaceID string, member domain.Member) (domain.Workspace, error) {
+	if err := validateMember(&member); err != nil {
+		return domain.Workspace{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	workspace, role, err := workspaceOf(ctx, u.workspaceRepository, workspaceID)
+	if err != nil {
+		return domain.Workspace{}, err
+	}
+	if role != domain.RoleOwner {
+		return domain.Workspace{}, domain.ErrForbidden
+	}
+
+	workspace.Members = setMember(workspace.Members, member)
+	return u.updateMembers(ctx, workspace)
+}
+
+// RemoveMember lets owners remove anyone and members leave the workspace.
+func (u *workspaceUsecase) RemoveMember(c context.Context, workspaceID, userID string) (domain.Workspace, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	workspace, role, err := workspaceOf(ctx, u.workspaceRepository, workspaceID)
+	if err != nil {
+		return domain.Workspace{}, err
+	}
+	if role != domain.RoleOwner && userID != domain.ActorFromContext(c) {
+		return domain.Workspace{}, domain.ErrForbidden
+	}
+	if _, ok := workspace.Role(userID); !ok {
+		return domain.Workspace{}, domain.ErrNotFound
+	}
+
+	members := []domain.Member{}
+	for _, member := range workspace.Members {
+		if member.UserID != userID {
+			members = append(members, member)
+		}
+	}
+	workspace.Members = members
+	return u.updateMembers(ctx, workspace)
+}
+
+func (u *workspaceUsecase) updateMembers(c context.Context, workspace domain.Workspace) (domain.Workspace, error) {
+	owners := 0
+	for _, member := range workspace.Members {
+		if member.Role == domain.RoleOwner {
+			owners++
+		}
+	}
+	if owners == 0 {
+		return domain.Workspace{}, &domain.ValidationError{Field: "members", Message: "a workspace needs an owner"}
+	}
+
+	if err := u.workspaceRepository.Update(c, &workspace); err != nil {
+		return domain.Workspace{}, err
+	}
+	return workspace, nil
+}
+
+// setMember replaces the entry of member.UserID in members or appends member.
+func setMember(members []domain.Member, member domain.Member) []domain.Member {
+	for i := range members {
+		if members[i].UserID == member.UserID {
+			members[i] = member
+			return members
+		}
+	}
+	return append(members, member)
+}
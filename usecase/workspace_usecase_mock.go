@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWorkspaceUsecase is a mock for the WorkspaceUsecase interface
+type MockWorkspaceUsecase struct {
+	mock.Mock
+}
+
+func (m *MockWorkspaceUsecase) Create(c context.Context, workspace *domain.Workspace) error {
+	args := m.Called(c, workspace)
+	return args.Error(0)
+}
+
+func (m *MockWorkspaceUsecase) FetchByID(c context.Context, workspaceID string) (domain.Workspace, error) {
+	args := m.Called(c, workspaceID)
+	return args.Get(0).(domain.Workspace), args.Error(1)
+}
+
+func (m *MockWorkspaceUsecase) FetchMine(c context.Context) ([]domain.Workspace, error) {
+	args := m.Called(c)
+	return args.Get(0).([]domain.Workspace), args.Error(1)
+}
+
+func (m *MockWorkspaceUsecase) SetMember(c context.Context, workspaceID string, member domain.Member) (domain.Workspace, error) {
+	args := m.Called(c, workspaceID, member)
+	return args.Get(0).(domain.Workspace), args.Error(1)
+}
+
+func (m *MockWorkspaceUsecase) RemoveMember(c context.Context, workspaceID, userID string) (domain.Workspace, error) {
+	args := m.Called(c, workspaceID, userID)
+	return args.Get(0).(domain.Workspace), args.Error(1)
+}
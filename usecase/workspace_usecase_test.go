@@ -0,0 +1,128 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sing3demons/go-backend-clean-architecture/domain"
+	"github.com/sing3demons/go-backend-clean-architecture/repository"
+	"github.com/sing3demons/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestWorkspaceCreate(t *testing.T) {
+	t.Run("the actor owns the workspace", func(t *testing.T) {
+		repo := new(repository.MockWorkspaceRepository)
+		repo.On("Create", mock.Anything, mock.MatchedBy(func(workspace *domain.Workspace) bool {
+			return workspace.Name == "Acme" && assert.ObjectsAreEqual([]domain.Member{
+				{UserID: "user-2", Role: domain.RoleMember},
+				{UserID: "user-1", Role: domain.RoleOwner},
+			}, workspace.Members)
+		})).Return(nil).Once()
+
+		err := usecase.NewWorkspaceUsecase(repo, time.Second*2).Create(domain.WithActor(context.Background(), "user-1"), &domain.Workspace{
+			Name:    "  Acme ",
+			Members: []domain.Member{{UserID: "user-2"}, {UserID: "user-1", Role: domain.RoleMember}},
+		})
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			ctx       context.Context
+			workspace domain.Workspace
+			err       error
+		}{
+			{name: "no actor", ctx: context.Background(), workspace: domain.Workspace{Name: "Acme"}, err: domain.ErrUnauthenticated},
+			{name: "no name", workspace: domain.Workspace{Name: " "}, err: domain.ErrValidation},
+			{name: "unknown role", workspace: domain.Workspace{Name: "Acme", Members: []domain.Member{{UserID: "user-2", Role: "admin"}}}, err: domain.ErrValidation},
+		}
+
+		repo := new(repository.MockWorkspaceRepository)
+		u := usecase.NewWorkspaceUsecase(repo, time.Second*2)
+		for _, tt := range tests {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = domain.WithActor(context.Background(), "user-1")
+			}
+			assert.ErrorIs(t, u.Create(ctx, &tt.workspace), tt.err, tt.name)
+		}
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestWorkspaceMembers(t *testing.T) {
+	id := primitive.NewObjectID()
+	workspace := func() domain.Workspace {
+		return domain.Workspace{ID: id, Version: 1, Members: []domain.Member{
+			{UserID: "owner", Role: domain.RoleOwner},
+			{UserID: "member", Role: domain.RoleMember},
+		}}
+	}
+	as := func(actor string) context.Context {
+		return domain.WithActor(context.Background(), actor)
+	}
+
+	t.Run("owner adds a member", func(t *testing.T) {
+		repo := new(repository.MockWorkspaceRepository)
+		repo.On("FetchByID", mock.Anything, id.Hex()).Return(workspace(), nil).Once()
+		repo.On("Update", mock.Anything, mock.MatchedBy(func(w *domain.Workspace) bool {
+			return len(w.Members) == 3 && w.Members[2] == domain.Member{UserID: "new", Role: domain.RoleMember}
+		})).Return(nil).Once()
+
+		_, err := usecase.NewWorkspaceUsecase(repo, time.Second*2).SetMember(as("owner"), id.Hex(), domain.Member{UserID: "new"})
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("members do not manage members", func(t *testing.T) {
+		repo := new(repository.MockWorkspaceRepository)
+		repo.On("FetchByID", mock.Anything, id.Hex()).Return(workspace(), nil).Once()
+
+		_, err := usecase.NewWorkspaceUsecase(repo, time.Second*2).SetMember(as("member"), id.Hex(), domain.Member{UserID: "new"})
+
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+	})
+
+	t.Run("outsiders do not see the workspace", func(t *testing.T) {
+		repo := new(repository.MockWorkspaceRepository)
+		repo.On("FetchByID", mock.Anything, id.Hex()).Return(workspace(), nil).Once()
+
+		_, err := usecase.NewWorkspaceUsecase(repo, time.Second*2).FetchByID(as("stranger"), id.Hex())
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("a member leaves", func(t *testing.T) {
+		repo := new(repository.MockWorkspaceRepository)
+		repo.On("FetchByID", mock.Anything, id.Hex()).Return(workspace(), nil).Once()
+		repo.On("Update", mock.Anything, mock.MatchedBy(func(w *domain.Workspace) bool {
+			return len(w.Members) == 1
+		})).Return(nil).Once()
+
+		_, err := usecase.NewWorkspaceUsecase(repo, time.Second*2).RemoveMember(as("member"), id.Hex(), "member")
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("the last owner stays", func(t *testing.T) {
+		repo := new(repository.MockWorkspaceRepository)
+		repo.On("FetchByID", mock.Anything, id.Hex()).Return(workspace(), nil).Twice()
+		u := usecase.NewWorkspaceUsecase(repo, time.Second*2)
+
+		_, err := u.RemoveMember(as("owner"), id.Hex(), "owner")
+		assert.ErrorIs(t, err, domain.ErrValidation)
+
+		_, err = u.SetMember(as("owner"), id.Hex(), domain.Member{UserID: "owner", Role: domain.RoleMember})
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}